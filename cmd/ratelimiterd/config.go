@@ -1,23 +1,42 @@
 package main
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
+	"cogni/internal/adaptive"
+	"cogni/internal/api"
+	"cogni/internal/backend/memory"
+
 	"gopkg.in/yaml.v3"
 )
 
 // config describes the ratelimiterd YAML configuration.
 type config struct {
 	Server struct {
-		ListenAddr string `yaml:"listen_addr"`
-		Backend    string `yaml:"backend"`
+		ListenAddr string    `yaml:"listen_addr"`
+		Backend    string    `yaml:"backend"`
+		TLS        tlsConfig `yaml:"tls"`
 	} `yaml:"server"`
+	Auth struct {
+		Credentials []credentialConfig `yaml:"credentials"`
+	} `yaml:"auth"`
 	Registry struct {
 		Path string `yaml:"path"`
+		// WatchIntervalMs polls the file for changes; zero reloads only on SIGHUP.
+		WatchIntervalMs int `yaml:"watch_interval_ms"`
 	} `yaml:"registry"`
+	Adaptive  adaptiveConfig  `yaml:"adaptive"`
+	Admission admissionConfig `yaml:"admission"`
+	Memory    struct {
+		PersistDir    string `yaml:"persist_dir"`
+		SnapshotEvery int    `yaml:"snapshot_every"`
+	} `yaml:"memory"`
 	TigerBeetle struct {
 		ClusterID           string   `yaml:"cluster_id"`
 		Addresses           []string `yaml:"addresses"`
@@ -27,6 +46,74 @@ type config struct {
 	} `yaml:"tigerbeetle"`
 }
 
+// tlsConfig serves HTTPS; a client CA additionally requires and verifies
+// client certificates (mTLS).
+type tlsConfig struct {
+	CertFile     string `yaml:"cert_file"`
+	KeyFile      string `yaml:"key_file"`
+	ClientCAFile string `yaml:"client_ca_file"`
+}
+
+// adaptiveConfig lets client-reported provider feedback lower effective
+// capacities. Zero tuning fields use the adaptive package defaults.
+type adaptiveConfig struct {
+	Enabled            bool    `yaml:"enabled"`
+	BackoffFactor      float64 `yaml:"backoff_factor"`
+	MinFraction        float64 `yaml:"min_fraction"`
+	RecoveryStep       float64 `yaml:"recovery_step"`
+	RecoveryIntervalMs int     `yaml:"recovery_interval_ms"`
+	ProviderLimitTTLMs int     `yaml:"provider_limit_ttl_ms"`
+}
+
+// settings converts the configuration to controller tuning.
+func (c adaptiveConfig) settings() adaptive.Config {
+	return adaptive.Config{
+		BackoffFactor:    c.BackoffFactor,
+		MinFraction:      c.MinFraction,
+		RecoveryStep:     c.RecoveryStep,
+		RecoveryInterval: time.Duration(c.RecoveryIntervalMs) * time.Millisecond,
+		ProviderLimitTTL: time.Duration(c.ProviderLimitTTLMs) * time.Millisecond,
+	}
+}
+
+// admissionConfig sets the share of each limit that low- and normal-priority
+// reservations leave free for higher priorities, and whether limits are
+// shared fairly across tenants. Unset fields keep the memory backend
+// defaults.
+type admissionConfig struct {
+	LowHeadroom     *float64           `yaml:"low_headroom"`
+	NormalHeadroom  *float64           `yaml:"normal_headroom"`
+	TenantFairShare bool               `yaml:"tenant_fair_share"`
+	TenantWeights   map[string]float64 `yaml:"tenant_weights"`
+}
+
+// set reports whether any admission option is configured.
+func (c admissionConfig) set() bool {
+	return c.LowHeadroom != nil || c.NormalHeadroom != nil || c.TenantFairShare || len(c.TenantWeights) > 0
+}
+
+// headroom returns the configured fractions, filling in defaults.
+func (c admissionConfig) headroom() (low, normal float64) {
+	low, normal = memory.DefaultLowHeadroom, memory.DefaultNormalHeadroom
+	if c.LowHeadroom != nil {
+		low = *c.LowHeadroom
+	}
+	if c.NormalHeadroom != nil {
+		normal = *c.NormalHeadroom
+	}
+	return low, normal
+}
+
+// credentialConfig grants a scope to a bearer token or a client certificate
+// common name. Tokens are read from TokenEnv so they stay out of the file.
+type credentialConfig struct {
+	Name         string `yaml:"name"`
+	Scope        string `yaml:"scope"`
+	Tenant       string `yaml:"tenant"`
+	TokenEnv     string `yaml:"token_env"`
+	ClientCertCN string `yaml:"client_cert_cn"`
+}
+
 // loadConfig reads and validates the configuration file.
 func loadConfig(path string) (config, error) {
 	var cfg config
@@ -46,6 +133,39 @@ func loadConfig(path string) (config, error) {
 	if cfg.Registry.Path == "" {
 		return cfg, fmt.Errorf("registry.path is required")
 	}
+	if cfg.Registry.WatchIntervalMs < 0 {
+		return cfg, fmt.Errorf("registry.watch_interval_ms must be >= 0")
+	}
+	if err := cfg.Adaptive.settings().Validate(); err != nil {
+		return cfg, fmt.Errorf("adaptive: %w", err)
+	}
+	if cfg.Adaptive.Enabled && cfg.Server.Backend != "memory" {
+		return cfg, fmt.Errorf("adaptive limits require the memory backend")
+	}
+	if err := memory.ValidateHeadroom(cfg.Admission.headroom()); err != nil {
+		return cfg, fmt.Errorf("admission: %w", err)
+	}
+	if err := memory.ValidateTenantWeights(cfg.Admission.TenantWeights); err != nil {
+		return cfg, fmt.Errorf("admission: %w", err)
+	}
+	if len(cfg.Admission.TenantWeights) > 0 && !cfg.Admission.TenantFairShare {
+		return cfg, fmt.Errorf("admission.tenant_weights requires admission.tenant_fair_share")
+	}
+	if cfg.Admission.set() && cfg.Server.Backend != "memory" {
+		return cfg, fmt.Errorf("admission settings require the memory backend")
+	}
+	tls := cfg.Server.TLS
+	if (tls.CertFile == "") != (tls.KeyFile == "") {
+		return cfg, fmt.Errorf("server.tls.cert_file and server.tls.key_file must be set together")
+	}
+	if tls.ClientCAFile != "" && tls.CertFile == "" {
+		return cfg, fmt.Errorf("server.tls.client_ca_file requires server.tls.cert_file")
+	}
+	for _, cred := range cfg.Auth.Credentials {
+		if cred.ClientCertCN != "" && tls.ClientCAFile == "" {
+			return cfg, fmt.Errorf("auth credential %s: client_cert_cn requires server.tls.client_ca_file", cred.Name)
+		}
+	}
 	if cfg.Server.Backend == "tigerbeetle" {
 		if len(cfg.TigerBeetle.Addresses) == 0 {
 			return cfg, fmt.Errorf("tigerbeetle.addresses is required")
@@ -57,6 +177,54 @@ func loadConfig(path string) (config, error) {
 	return cfg, nil
 }
 
+// buildAuthenticator resolves credential tokens from the environment. No
+// credentials leaves the API unauthenticated.
+func buildAuthenticator(cfg config) (api.Authenticator, error) {
+	if len(cfg.Auth.Credentials) == 0 {
+		return nil, nil
+	}
+	creds := make([]api.Credential, 0, len(cfg.Auth.Credentials))
+	for _, entry := range cfg.Auth.Credentials {
+		cred := api.Credential{
+			Principal:    api.Principal{Name: entry.Name, Scope: api.Scope(entry.Scope), Tenant: entry.Tenant},
+			ClientCertCN: entry.ClientCertCN,
+		}
+		if entry.TokenEnv != "" {
+			cred.Token = strings.TrimSpace(os.Getenv(entry.TokenEnv))
+			if cred.Token == "" {
+				return nil, fmt.Errorf("auth credential %s: %s is not set", entry.Name, entry.TokenEnv)
+			}
+		}
+		creds = append(creds, cred)
+	}
+	return api.NewStaticAuthenticator(creds)
+}
+
+// buildTLSConfig loads the server certificate and, for mTLS, the client CA.
+func buildTLSConfig(cfg tlsConfig) (*tls.Config, error) {
+	if cfg.CertFile == "" {
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load server certificate: %w", err)
+	}
+	tlsCfg := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	if cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("client CA %s contains no certificates", cfg.ClientCAFile)
+		}
+		tlsCfg.ClientCAs = pool
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsCfg, nil
+}
+
 // parseClusterID converts a config cluster_id string to uint32.
 func parseClusterID(value string) (uint32, error) {
 	parsed, err := strconv.ParseUint(value, 10, 32)
@@ -10,10 +10,12 @@ import (
 	"syscall"
 	"time"
 
+	"cogni/internal/adaptive"
 	"cogni/internal/api"
 	"cogni/internal/backend"
 	"cogni/internal/backend/memory"
 	"cogni/internal/backend/tb"
+	"cogni/internal/metrics"
 	"cogni/internal/registry"
 	"cogni/pkg/ratelimiter"
 )
@@ -40,6 +42,18 @@ func run() int {
 		return 1
 	}
 
+	auth, err := buildAuthenticator(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "auth config error: %v\n", err)
+		return 1
+	}
+	tlsConfig, err := buildTLSConfig(cfg.Server.TLS)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "tls config error: %v\n", err)
+		return 1
+	}
+
+	metricsRegistry := metrics.NewRegistry()
 	var limiter backend.Backend
 	var closeBackend func()
 	switch cfg.Server.Backend {
@@ -63,42 +77,91 @@ func run() int {
 			return 1
 		}
 		limiter = tbBackend
+		metrics.RegisterQueueDepth(metricsRegistry, tbBackend.SubmitterQueueDepth)
 		closeBackend = func() {
 			_ = tbBackend.Close()
 		}
 	default:
 		memBackend := memory.New(nil)
 		memBackend.AttachRegistry(reg, cfg.Registry.Path)
+		if err := memBackend.SetPriorityHeadroom(cfg.Admission.headroom()); err != nil {
+			fmt.Fprintf(os.Stderr, "admission error: %v\n", err)
+			return 1
+		}
+		if cfg.Admission.TenantFairShare {
+			if err := memBackend.EnableTenantFairShare(cfg.Admission.TenantWeights); err != nil {
+				fmt.Fprintf(os.Stderr, "admission error: %v\n", err)
+				return 1
+			}
+		}
 		if err := applyStates(memBackend, reg.List()); err != nil {
 			fmt.Fprintf(os.Stderr, "memory backend load error: %v\n", err)
 			return 1
 		}
+		if cfg.Memory.PersistDir != "" {
+			if err := memBackend.EnablePersistence(memory.PersistConfig{
+				Dir:           cfg.Memory.PersistDir,
+				SnapshotEvery: cfg.Memory.SnapshotEvery,
+			}); err != nil {
+				fmt.Fprintf(os.Stderr, "memory backend persistence error: %v\n", err)
+				return 1
+			}
+		}
 		limiter = memBackend
+		closeBackend = func() {
+			_ = memBackend.Close()
+		}
 	}
 
-	handler := api.NewHandler(api.Config{
+	apiConfig := api.Config{
 		Registry:     reg,
 		Backend:      limiter,
 		RegistryPath: cfg.Registry.Path,
 		Now:          time.Now,
-	})
+		Metrics:      metrics.NewLimiter(metricsRegistry),
+		Auth:         auth,
+	}
+	if cfg.Adaptive.Enabled {
+		adjuster, ok := limiter.(backend.CapacityAdjuster)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "adaptive limits: %s backend cannot adjust capacity\n", cfg.Server.Backend)
+			return 1
+		}
+		apiConfig.Adaptive = adaptive.New(adjuster, cfg.Adaptive.settings(), time.Now)
+	}
+	handler := api.NewHandler(apiConfig)
+	if reporter, ok := limiter.(backend.UsageReporter); ok {
+		metrics.RegisterUsage(metricsRegistry, reporter)
+	}
 	mux := http.NewServeMux()
 	mux.Handle("/healthz", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
 	}))
+	mux.Handle("/metrics", api.RequireScope(auth, api.ScopeAdmin, metricsRegistry.Handler()))
 	mux.Handle("/", handler)
 
 	server := &http.Server{
-		Addr:    cfg.Server.ListenAddr,
-		Handler: mux,
+		Addr:      cfg.Server.ListenAddr,
+		Handler:   mux,
+		TLSConfig: tlsConfig,
 	}
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
+	go newRegistryReloader(apiConfig).run(ctx, time.Duration(cfg.Registry.WatchIntervalMs)*time.Millisecond)
+	if apiConfig.Adaptive != nil {
+		go apiConfig.Adaptive.Run(ctx)
+	}
 
 	errCh := make(chan error, 1)
 	go func() {
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if tlsConfig != nil {
+			err = server.ListenAndServeTLS("", "")
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			errCh <- err
 		}
 	}()
@@ -118,14 +181,9 @@ func run() int {
 	return 0
 }
 
-// stateApplier loads persisted limit states into a backend.
-type stateApplier interface {
-	ApplyState(state ratelimiter.LimitState) error
-}
-
 // applyStates hydrates backends that support state loading.
 func applyStates(b backend.Backend, states []ratelimiter.LimitState) error {
-	applier, ok := b.(stateApplier)
+	applier, ok := b.(backend.StateApplier)
 	if !ok {
 		return nil
 	}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"cogni/internal/api"
+	"cogni/internal/registry"
+	"cogni/pkg/ratelimiter"
+)
+
+// registryReloader re-reads registry.path and applies it to the running
+// backend, so limits deployed as a file take effect without a restart.
+type registryReloader struct {
+	cfg api.Config
+	sum [sha256.Size]byte
+}
+
+// newRegistryReloader remembers the file contents loaded at startup.
+func newRegistryReloader(cfg api.Config) *registryReloader {
+	r := &registryReloader{cfg: cfg}
+	if data, err := os.ReadFile(cfg.RegistryPath); err == nil {
+		r.sum = sha256.Sum256(data)
+	}
+	return r
+}
+
+// run reloads on SIGHUP and, when interval is positive, whenever the file
+// contents change.
+func (r *registryReloader) run(ctx context.Context, interval time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	r.loop(ctx, hup, tick)
+}
+
+// loop reloads on every hangup and tick until ctx is done.
+func (r *registryReloader) loop(ctx context.Context, hup <-chan os.Signal, tick <-chan time.Time) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			r.reload(ctx, true)
+		case <-tick:
+			r.reload(ctx, false)
+		}
+	}
+}
+
+// reload applies the registry file when it changed, or always when forced.
+// The server saves the same file after admin writes, so contents it wrote
+// itself are skipped unless forced. The file is read and applied under the
+// registry edit lock, so it cannot revert an admin write that has not been
+// saved yet. A missing or malformed file is logged and ignored rather than
+// treated as an empty limit set, which would drain every limit.
+func (r *registryReloader) reload(ctx context.Context, force bool) {
+	defer r.cfg.Registry.LockEdits()()
+	data, err := os.ReadFile(r.cfg.RegistryPath)
+	if err != nil {
+		log.Printf("registry reload: %v", err)
+		return
+	}
+	sum := sha256.Sum256(data)
+	if !force && (sum == r.sum || r.cfg.Registry.LastSaved(data)) {
+		r.sum = sum
+		return
+	}
+	r.sum = sum
+	states, err := registry.DecodeStates(data)
+	if err != nil {
+		log.Printf("registry reload: parse %s: %v", r.cfg.RegistryPath, err)
+		return
+	}
+	res, err := api.ApplyLimits(ctx, r.cfg, ratelimiter.ApplyLimitsRequest{Limits: registry.TargetDefinitions(states)})
+	if err != nil {
+		log.Printf("registry reload: %v", err)
+		return
+	}
+	for _, change := range res.Changes {
+		logLimitChange(change)
+	}
+	if force || len(res.Changes) > 0 {
+		log.Printf("registry reload: %d changed, %d unchanged", len(res.Changes), res.Unchanged)
+	}
+}
+
+// logLimitChange logs one applied change.
+func logLimitChange(change ratelimiter.LimitChange) {
+	switch change.Action {
+	case ratelimiter.LimitChangeCreate:
+		log.Printf("registry reload: create %s capacity=%d", change.Key, change.After.Capacity)
+	case ratelimiter.LimitChangeDecrease:
+		log.Printf("registry reload: decrease %s capacity=%d->%d (decreasing until usage drains)", change.Key, change.Before.Capacity, change.After.Capacity)
+	case ratelimiter.LimitChangeDelete:
+		log.Printf("registry reload: delete %s (drains in-flight leases first)", change.Key)
+	default:
+		log.Printf("registry reload: %s %s capacity=%d->%d", change.Action, change.Key, change.Before.Capacity, change.After.Capacity)
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"cogni/internal/api"
+	"cogni/internal/backend/memory"
+	"cogni/internal/registry"
+	"cogni/internal/testutil"
+	"cogni/pkg/ratelimiter"
+)
+
+const reloadKey ratelimiter.LimitKey = "global:llm:p:m:rpm"
+
+// TestReloadSkipsServerWrites verifies the watcher ignores files the server
+// saved itself, so it cannot revert a change that is not yet on disk.
+func TestReloadSkipsServerWrites(t *testing.T) {
+	cfg := newReloadConfig(t, 10)
+	reloader := newRegistryReloader(cfg)
+
+	unlock := cfg.Registry.LockEdits()
+	_, err := api.ApplyLimits(testutil.Context(t, time.Second), cfg, ratelimiter.ApplyLimitsRequest{
+		Limits: []ratelimiter.LimitDefinition{reloadDefinition(20)},
+	})
+	unlock()
+	if err != nil {
+		t.Fatalf("apply limits: %v", err)
+	}
+	putCapacity(cfg.Registry, 30)
+
+	reloader.reload(testutil.Context(t, time.Second), false)
+	if got := registryCapacity(t, cfg.Registry); got != 30 {
+		t.Fatalf("expected the server's own write skipped, got capacity %d", got)
+	}
+}
+
+// TestReloadAppliesExternalEdits verifies an edited file is applied on the
+// next tick.
+func TestReloadAppliesExternalEdits(t *testing.T) {
+	cfg := newReloadConfig(t, 10)
+	reloader := newRegistryReloader(cfg)
+
+	writeRegistryFile(t, cfg.RegistryPath, 25)
+	reloader.reload(testutil.Context(t, time.Second), false)
+	if got := registryCapacity(t, cfg.Registry); got != 25 {
+		t.Fatalf("expected the edited file applied, got capacity %d", got)
+	}
+}
+
+// TestReloadForcedBySIGHUP verifies a hangup re-applies an unchanged file.
+func TestReloadForcedBySIGHUP(t *testing.T) {
+	cfg := newReloadConfig(t, 10)
+	reloader := newRegistryReloader(cfg)
+	putCapacity(cfg.Registry, 5)
+
+	ctx, cancel := context.WithCancel(testutil.Context(t, 2*time.Second))
+	hup := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		reloader.loop(ctx, hup, nil)
+	}()
+	hup <- syscall.SIGHUP
+	for registryCapacity(t, cfg.Registry) != 10 {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("expected SIGHUP to restore capacity 10, got %d", registryCapacity(t, cfg.Registry))
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+	cancel()
+	<-done
+}
+
+// TestReloadIgnoresMalformedFile verifies a parse error leaves every limit
+// in place instead of draining it.
+func TestReloadIgnoresMalformedFile(t *testing.T) {
+	cfg := newReloadConfig(t, 10)
+	reloader := newRegistryReloader(cfg)
+
+	if err := os.WriteFile(cfg.RegistryPath, []byte("{not json"), 0o644); err != nil {
+		t.Fatalf("write registry: %v", err)
+	}
+	reloader.reload(testutil.Context(t, time.Second), true)
+
+	state, ok := cfg.Registry.Get(reloadKey)
+	if !ok || state.Status != ratelimiter.LimitStatusActive {
+		t.Fatalf("expected the limit to stay active, got %+v (found=%v)", state, ok)
+	}
+	res, err := cfg.Backend.Reserve(testutil.Context(t, time.Second), ratelimiter.ReserveRequest{
+		LeaseID:      "L1",
+		Requirements: []ratelimiter.Requirement{{Key: reloadKey, Amount: 1}},
+	}, time.Now())
+	if err != nil || !res.Allowed {
+		t.Fatalf("expected reserve allowed after a bad reload, got %+v, %v", res, err)
+	}
+}
+
+func newReloadConfig(t *testing.T, capacity uint64) api.Config {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "limits.json")
+	writeRegistryFile(t, path, capacity)
+	reg := registry.New()
+	if err := reg.Load(path); err != nil {
+		t.Fatalf("load registry: %v", err)
+	}
+	backend := memory.New(nil)
+	backend.AttachRegistry(reg, path)
+	if err := applyStates(backend, reg.List()); err != nil {
+		t.Fatalf("apply states: %v", err)
+	}
+	return api.Config{Registry: reg, Backend: backend, RegistryPath: path, Now: time.Now}
+}
+
+func writeRegistryFile(t *testing.T, path string, capacity uint64) {
+	t.Helper()
+	reg := registry.New()
+	putCapacity(reg, capacity)
+	if err := reg.Save(path); err != nil {
+		t.Fatalf("save registry: %v", err)
+	}
+}
+
+func putCapacity(reg *registry.Registry, capacity uint64) {
+	reg.Put(ratelimiter.LimitState{Definition: reloadDefinition(capacity), Status: ratelimiter.LimitStatusActive})
+}
+
+func reloadDefinition(capacity uint64) ratelimiter.LimitDefinition {
+	return ratelimiter.LimitDefinition{
+		Key:           reloadKey,
+		Kind:          ratelimiter.KindRolling,
+		Capacity:      capacity,
+		WindowSeconds: 60,
+		Overage:       ratelimiter.OverageDebt,
+	}
+}
+
+func registryCapacity(t *testing.T, reg *registry.Registry) uint64 {
+	t.Helper()
+	state, ok := reg.Get(reloadKey)
+	if !ok {
+		t.Fatalf("expected %s in the registry", reloadKey)
+	}
+	return state.Definition.Capacity
+}
@@ -0,0 +1,86 @@
+package adaptive
+
+import (
+	"fmt"
+	"time"
+
+	"cogni/pkg/ratelimiter"
+)
+
+// Config tunes backoff and recovery.
+type Config struct {
+	// BackoffFactor multiplies the allowed fraction of capacity on each
+	// throttle signal.
+	BackoffFactor float64
+	// MinFraction is the lowest fraction of capacity backoff reaches.
+	MinFraction float64
+	// RecoveryStep is added to the fraction after every quiet RecoveryInterval.
+	RecoveryStep     float64
+	RecoveryInterval time.Duration
+	// ProviderLimitTTL is how long a provider-reported limit caps capacity
+	// after it was last reported.
+	ProviderLimitTTL time.Duration
+}
+
+// DefaultConfig halves capacity per throttle, never below 10%, recovers 10%
+// of capacity every 30 seconds without throttling, and trusts a provider's
+// reported limit for two minutes.
+func DefaultConfig() Config {
+	return Config{
+		BackoffFactor:    0.5,
+		MinFraction:      0.1,
+		RecoveryStep:     0.1,
+		RecoveryInterval: 30 * time.Second,
+		ProviderLimitTTL: 2 * time.Minute,
+	}
+}
+
+// FromSettings converts the public tuning of pkg/ratelimiter to a Config.
+func FromSettings(s ratelimiter.AdaptiveSettings) Config {
+	return Config{
+		BackoffFactor:    s.BackoffFactor,
+		MinFraction:      s.MinFraction,
+		RecoveryStep:     s.RecoveryStep,
+		RecoveryInterval: s.RecoveryInterval,
+		ProviderLimitTTL: s.ProviderLimitTTL,
+	}
+}
+
+// withDefaults fills unset fields from DefaultConfig.
+func (c Config) withDefaults() Config {
+	def := DefaultConfig()
+	if c.BackoffFactor == 0 {
+		c.BackoffFactor = def.BackoffFactor
+	}
+	if c.MinFraction == 0 {
+		c.MinFraction = def.MinFraction
+	}
+	if c.RecoveryStep == 0 {
+		c.RecoveryStep = def.RecoveryStep
+	}
+	if c.RecoveryInterval == 0 {
+		c.RecoveryInterval = def.RecoveryInterval
+	}
+	if c.ProviderLimitTTL == 0 {
+		c.ProviderLimitTTL = def.ProviderLimitTTL
+	}
+	return c
+}
+
+// Validate reports settings that would never back off or never recover.
+func (c Config) Validate() error {
+	c = c.withDefaults()
+	switch {
+	case c.BackoffFactor <= 0 || c.BackoffFactor >= 1:
+		return fmt.Errorf("backoff_factor must be between 0 and 1")
+	case c.MinFraction <= 0 || c.MinFraction > 1:
+		return fmt.Errorf("min_fraction must be in (0, 1]")
+	case c.RecoveryStep <= 0 || c.RecoveryStep > 1:
+		return fmt.Errorf("recovery_step must be in (0, 1]")
+	case c.RecoveryInterval < 0:
+		return fmt.Errorf("recovery_interval must be positive")
+	case c.ProviderLimitTTL < 0:
+		return fmt.Errorf("provider_limit_ttl must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+package adaptive
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"cogni/internal/backend"
+	"cogni/pkg/ratelimiter"
+)
+
+// throttleCooldown keeps a burst of 429s from one overload backing off
+// more than once.
+const throttleCooldown = time.Second
+
+// Controller turns provider feedback into effective capacities on a backend.
+type Controller struct {
+	target backend.CapacityAdjuster
+	cfg    Config
+	now    func() time.Time
+
+	mu   sync.Mutex
+	keys map[ratelimiter.LimitKey]*keyState
+}
+
+// keyState is the adaptive state of one limit.
+type keyState struct {
+	fraction      float64
+	providerLimit uint64
+	// providerUntil is when providerLimit stops capping capacity.
+	providerUntil time.Time
+	throttles     uint64
+	lastThrottled time.Time
+	cooldownUntil time.Time
+	nextRecovery  time.Time
+}
+
+// New builds a Controller for target. A nil now uses time.Now.
+func New(target backend.CapacityAdjuster, cfg Config, now func() time.Time) *Controller {
+	if now == nil {
+		now = time.Now
+	}
+	return &Controller{
+		target: target,
+		cfg:    cfg.withDefaults(),
+		now:    now,
+		keys:   map[ratelimiter.LimitKey]*keyState{},
+	}
+}
+
+// Observe applies provider feedback and returns the resulting adjustments.
+// Feedback for unknown keys is ignored.
+func (c *Controller) Observe(ctx context.Context, feedback []ratelimiter.LimitFeedback) ([]ratelimiter.LimitAdjustment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	out := []ratelimiter.LimitAdjustment{}
+	for _, fb := range feedback {
+		capacity, ok, err := c.target.DefinedCapacity(ctx, fb.Key)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			delete(c.keys, fb.Key)
+			continue
+		}
+		state := c.keys[fb.Key]
+		if state == nil {
+			state = &keyState{fraction: 1}
+		}
+		c.observe(state, fb, now)
+		adjustment, err := c.applyLocked(ctx, fb.Key, state, capacity, now)
+		if err != nil {
+			return nil, err
+		}
+		if adjustment != nil {
+			out = append(out, *adjustment)
+		}
+	}
+	return out, nil
+}
+
+// observe folds one feedback entry into a key's state. A 429 backs off; a
+// provider reporting nothing remaining only postpones recovery. A reported
+// provider limit caps capacity until ProviderLimitTTL passes without another
+// report, since its window need not match the key's.
+func (c *Controller) observe(state *keyState, fb ratelimiter.LimitFeedback, now time.Time) {
+	if fb.ProviderLimit > 0 {
+		state.providerLimit = fb.ProviderLimit
+		state.providerUntil = now.Add(c.cfg.ProviderLimitTTL)
+	}
+	if !fb.Throttled {
+		if fb.ProviderRemaining != nil && *fb.ProviderRemaining == 0 && state.fraction < 1 {
+			state.nextRecovery = now.Add(c.cfg.RecoveryInterval)
+		}
+		return
+	}
+	retryAfter := time.Duration(fb.RetryAfterMs) * time.Millisecond
+	state.throttles++
+	state.lastThrottled = now
+	if !now.Before(state.cooldownUntil) {
+		state.fraction = max(c.cfg.MinFraction, state.fraction*c.cfg.BackoffFactor)
+		state.cooldownUntil = now.Add(max(retryAfter, throttleCooldown))
+	}
+	state.nextRecovery = now.Add(max(retryAfter, c.cfg.RecoveryInterval))
+}
+
+// Recover raises every key that has not been throttled for a recovery
+// interval by one step and drops expired provider limits, clearing keys back
+// at full capacity.
+func (c *Controller) Recover(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for key, state := range c.keys {
+		expired := state.providerLimit > 0 && !now.Before(state.providerUntil)
+		recovering := state.fraction < 1 && !now.Before(state.nextRecovery)
+		if !expired && !recovering {
+			continue
+		}
+		if expired {
+			state.providerLimit = 0
+		}
+		if recovering {
+			state.fraction = min(1, state.fraction+c.cfg.RecoveryStep)
+			state.nextRecovery = now.Add(c.cfg.RecoveryInterval)
+		}
+		capacity, ok, err := c.target.DefinedCapacity(ctx, key)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			delete(c.keys, key)
+			continue
+		}
+		if _, err := c.applyLocked(ctx, key, state, capacity, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run calls Recover every recovery interval until ctx ends.
+func (c *Controller) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.cfg.RecoveryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = c.Recover(ctx)
+		}
+	}
+}
+
+// Reset drops key's adjustment and restores its defined capacity.
+func (c *Controller) Reset(ctx context.Context, key ratelimiter.LimitKey) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.keys[key]; !ok {
+		return false, nil
+	}
+	delete(c.keys, key)
+	return true, c.target.SetEffectiveCapacity(ctx, key, 0)
+}
+
+// Adjustment returns key's adjustment, if one is in force.
+func (c *Controller) Adjustment(ctx context.Context, key ratelimiter.LimitKey) (ratelimiter.LimitAdjustment, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	state, ok := c.keys[key]
+	if !ok {
+		return ratelimiter.LimitAdjustment{}, false, nil
+	}
+	capacity, ok, err := c.target.DefinedCapacity(ctx, key)
+	if err != nil || !ok {
+		return ratelimiter.LimitAdjustment{}, false, err
+	}
+	return c.adjustment(key, state, capacity, c.now()), true, nil
+}
+
+// Adjustments lists the adjustments in force, ordered by key.
+func (c *Controller) Adjustments(ctx context.Context) ([]ratelimiter.LimitAdjustment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	out := []ratelimiter.LimitAdjustment{}
+	for key, state := range c.keys {
+		capacity, ok, err := c.target.DefinedCapacity(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			out = append(out, c.adjustment(key, state, capacity, now))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out, nil
+}
+
+// applyLocked pushes a key's effective capacity to the backend and keeps the
+// state only while backoff or a provider limit holds the key below its
+// defined capacity; Recover ends both.
+func (c *Controller) applyLocked(ctx context.Context, key ratelimiter.LimitKey, state *keyState, capacity uint64, now time.Time) (*ratelimiter.LimitAdjustment, error) {
+	effective := effectiveCapacity(state, capacity, now)
+	if effective >= capacity {
+		if state.fraction >= 1 {
+			delete(c.keys, key)
+		} else {
+			c.keys[key] = state
+		}
+		return nil, c.target.SetEffectiveCapacity(ctx, key, 0)
+	}
+	c.keys[key] = state
+	if err := c.target.SetEffectiveCapacity(ctx, key, effective); err != nil {
+		return nil, err
+	}
+	adjustment := c.adjustment(key, state, capacity, now)
+	return &adjustment, nil
+}
+
+// adjustment renders a key's state.
+func (c *Controller) adjustment(key ratelimiter.LimitKey, state *keyState, capacity uint64, now time.Time) ratelimiter.LimitAdjustment {
+	adjustment := ratelimiter.LimitAdjustment{
+		Key:               key,
+		Capacity:          capacity,
+		EffectiveCapacity: min(capacity, effectiveCapacity(state, capacity, now)),
+		Fraction:          state.fraction,
+		Throttles:         state.throttles,
+	}
+	if providerLimitActive(state, now) {
+		adjustment.ProviderLimit = state.providerLimit
+	}
+	if !state.lastThrottled.IsZero() {
+		adjustment.LastThrottledAtUnixMs = state.lastThrottled.UnixMilli()
+	}
+	if state.fraction < 1 {
+		adjustment.NextRecoveryAtUnixMs = state.nextRecovery.UnixMilli()
+	}
+	return adjustment
+}
+
+// effectiveCapacity scales the defined capacity, or the provider's reported
+// limit when lower and not expired, by the backoff fraction. It never drops
+// below one.
+func effectiveCapacity(state *keyState, capacity uint64, now time.Time) uint64 {
+	base := capacity
+	if providerLimitActive(state, now) && state.providerLimit < base {
+		base = state.providerLimit
+	}
+	effective := uint64(float64(base) * state.fraction)
+	if effective < 1 {
+		effective = 1
+	}
+	return effective
+}
+
+// providerLimitActive reports whether a reported provider limit still caps
+// capacity.
+func providerLimitActive(state *keyState, now time.Time) bool {
+	return state.providerLimit > 0 && now.Before(state.providerUntil)
+}
@@ -0,0 +1,165 @@
+package adaptive
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cogni/internal/testutil"
+	"cogni/pkg/ratelimiter"
+)
+
+type fakeTarget struct {
+	defined   map[ratelimiter.LimitKey]uint64
+	effective map[ratelimiter.LimitKey]uint64
+}
+
+func newFakeTarget(defined map[ratelimiter.LimitKey]uint64) *fakeTarget {
+	return &fakeTarget{defined: defined, effective: map[ratelimiter.LimitKey]uint64{}}
+}
+
+func (f *fakeTarget) DefinedCapacity(_ context.Context, key ratelimiter.LimitKey) (uint64, bool, error) {
+	capacity, ok := f.defined[key]
+	return capacity, ok, nil
+}
+
+func (f *fakeTarget) SetEffectiveCapacity(_ context.Context, key ratelimiter.LimitKey, capacity uint64) error {
+	if capacity == 0 {
+		delete(f.effective, key)
+		return nil
+	}
+	f.effective[key] = capacity
+	return nil
+}
+
+func throttle(key ratelimiter.LimitKey) []ratelimiter.LimitFeedback {
+	return []ratelimiter.LimitFeedback{{Key: key, Throttled: true}}
+}
+
+func TestControllerBacksOffOncePerCooldown(t *testing.T) {
+	ctx := context.Background()
+	clock := testutil.NewFakeClock(time.Unix(0, 0))
+	target := newFakeTarget(map[ratelimiter.LimitKey]uint64{"rpm": 100})
+	c := New(target, DefaultConfig(), clock.Now)
+
+	if _, err := c.Observe(ctx, throttle("rpm")); err != nil {
+		t.Fatalf("observe: %v", err)
+	}
+	if _, err := c.Observe(ctx, throttle("rpm")); err != nil {
+		t.Fatalf("observe: %v", err)
+	}
+	if target.effective["rpm"] != 50 {
+		t.Fatalf("expected one backoff to 50 within cooldown, got %d", target.effective["rpm"])
+	}
+	clock.Advance(2 * time.Second)
+	adjustments, err := c.Observe(ctx, throttle("rpm"))
+	if err != nil {
+		t.Fatalf("observe: %v", err)
+	}
+	if target.effective["rpm"] != 25 || len(adjustments) != 1 || adjustments[0].Throttles != 3 {
+		t.Fatalf("expected backoff to 25 after cooldown, got %d %+v", target.effective["rpm"], adjustments)
+	}
+	for i := 0; i < 5; i++ {
+		clock.Advance(2 * time.Second)
+		if _, err := c.Observe(ctx, throttle("rpm")); err != nil {
+			t.Fatalf("observe: %v", err)
+		}
+	}
+	if target.effective["rpm"] != 10 {
+		t.Fatalf("expected backoff floor of 10, got %d", target.effective["rpm"])
+	}
+}
+
+func TestControllerRecoversAfterQuietInterval(t *testing.T) {
+	ctx := context.Background()
+	clock := testutil.NewFakeClock(time.Unix(0, 0))
+	target := newFakeTarget(map[ratelimiter.LimitKey]uint64{"rpm": 100})
+	c := New(target, Config{BackoffFactor: 0.5, RecoveryStep: 0.25, RecoveryInterval: 10 * time.Second}, clock.Now)
+
+	if _, err := c.Observe(ctx, throttle("rpm")); err != nil {
+		t.Fatalf("observe: %v", err)
+	}
+	clock.Advance(5 * time.Second)
+	if err := c.Recover(ctx); err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+	if target.effective["rpm"] != 50 {
+		t.Fatalf("expected no recovery before interval, got %d", target.effective["rpm"])
+	}
+	clock.Advance(5 * time.Second)
+	if err := c.Recover(ctx); err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+	if target.effective["rpm"] != 75 {
+		t.Fatalf("expected recovery to 75, got %d", target.effective["rpm"])
+	}
+	clock.Advance(10 * time.Second)
+	if err := c.Recover(ctx); err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+	if _, ok := target.effective["rpm"]; ok {
+		t.Fatalf("expected defined capacity restored, got %d", target.effective["rpm"])
+	}
+	adjustments, err := c.Adjustments(ctx)
+	if err != nil || len(adjustments) != 0 {
+		t.Fatalf("expected no adjustments after recovery, got %+v %v", adjustments, err)
+	}
+}
+
+func TestControllerUsesLowerProviderLimit(t *testing.T) {
+	ctx := context.Background()
+	clock := testutil.NewFakeClock(time.Unix(0, 0))
+	target := newFakeTarget(map[ratelimiter.LimitKey]uint64{"rpm": 100})
+	c := New(target, DefaultConfig(), clock.Now)
+
+	feedback := []ratelimiter.LimitFeedback{{Key: "rpm", Throttled: true, ProviderLimit: 40, RetryAfterMs: 5000}}
+	adjustments, err := c.Observe(ctx, feedback)
+	if err != nil {
+		t.Fatalf("observe: %v", err)
+	}
+	if target.effective["rpm"] != 20 || len(adjustments) != 1 || adjustments[0].ProviderLimit != 40 {
+		t.Fatalf("expected backoff from provider limit to 20, got %d %+v", target.effective["rpm"], adjustments)
+	}
+	if adjustments[0].NextRecoveryAtUnixMs != 30_000 {
+		t.Fatalf("expected recovery after interval, got %d", adjustments[0].NextRecoveryAtUnixMs)
+	}
+
+	if _, err := c.Observe(ctx, []ratelimiter.LimitFeedback{{Key: "missing", Throttled: true}}); err != nil {
+		t.Fatalf("observe unknown key: %v", err)
+	}
+	if _, ok, _ := c.Adjustment(ctx, "missing"); ok {
+		t.Fatalf("unknown keys must not be tracked")
+	}
+}
+
+func TestControllerProviderLimitExpires(t *testing.T) {
+	ctx := context.Background()
+	clock := testutil.NewFakeClock(time.Unix(0, 0))
+	target := newFakeTarget(map[ratelimiter.LimitKey]uint64{"rpm": 100})
+	c := New(target, Config{ProviderLimitTTL: time.Minute}, clock.Now)
+
+	if _, err := c.Observe(ctx, []ratelimiter.LimitFeedback{{Key: "rpm", ProviderLimit: 40}}); err != nil {
+		t.Fatalf("observe: %v", err)
+	}
+	if target.effective["rpm"] != 40 {
+		t.Fatalf("expected provider limit of 40 applied, got %d", target.effective["rpm"])
+	}
+	clock.Advance(30 * time.Second)
+	if err := c.Recover(ctx); err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+	if target.effective["rpm"] != 40 {
+		t.Fatalf("expected provider limit kept before expiry, got %d", target.effective["rpm"])
+	}
+	clock.Advance(30 * time.Second)
+	if err := c.Recover(ctx); err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+	if _, ok := target.effective["rpm"]; ok {
+		t.Fatalf("expected defined capacity restored after expiry, got %d", target.effective["rpm"])
+	}
+	adjustments, err := c.Adjustments(ctx)
+	if err != nil || len(adjustments) != 0 {
+		t.Fatalf("expected no adjustments after expiry, got %+v %v", adjustments, err)
+	}
+}
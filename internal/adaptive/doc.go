@@ -0,0 +1,3 @@
+// Package adaptive lowers limit capacities when LLM providers throttle and
+// restores them gradually once throttling stops.
+package adaptive
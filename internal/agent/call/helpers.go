@@ -56,6 +56,9 @@ func failureReasonForError(err error) string {
 	if errors.Is(err, ErrBudgetExceeded) {
 		return "budget_exceeded"
 	}
+	if errors.Is(err, context.Canceled) {
+		return "cancelled"
+	}
 	return "runtime_error"
 }
 
@@ -23,6 +23,7 @@ type RunOptions struct {
 	TokenCounter     agent.TokenCounter
 	Compaction       agent.CompactionConfig
 	Limits           RunLimits
+	TerminalTool     string
 	Verbose          bool
 	VerboseWriter    io.Writer
 	VerboseLogWriter io.Writer
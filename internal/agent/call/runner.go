@@ -17,8 +17,13 @@ func RunCall(ctx context.Context, session *agent.Session, provider agent.Provide
 	var hookInput CallInput
 	hooksReady := false
 	var runErr error
+	var terminalCall *agent.ToolCall
 
 	for {
+		if err := ctx.Err(); err != nil {
+			runErr = err
+			break
+		}
 		if opts.TokenCounter != nil {
 			compacted, stats, err := agent.CompactHistory(ctx, session.History, provider, opts.TokenCounter, opts.Compaction)
 			if err != nil {
@@ -54,11 +59,15 @@ func RunCall(ctx context.Context, session *agent.Session, provider agent.Provide
 			break
 		}
 		metrics.Steps++
-		needsFollowUp, err := handleResponseStream(ctx, session, stream, executor, &metrics, opts)
+		needsFollowUp, terminal, err := handleResponseStream(ctx, session, stream, executor, &metrics, opts)
 		if err != nil {
 			runErr = err
 			break
 		}
+		if terminal != nil {
+			terminalCall = terminal
+			break
+		}
 		if !needsFollowUp {
 			break
 		}
@@ -67,6 +76,7 @@ func RunCall(ctx context.Context, session *agent.Session, provider agent.Provide
 	finalizeMetrics(start, opts, session.History, &metrics)
 	result := CallResult{
 		Output:        latestAssistantMessage(session.History),
+		TerminalCall:  terminalCall,
 		Metrics:       metrics,
 		FailureReason: failureReasonForError(runErr),
 	}
@@ -0,0 +1,55 @@
+package call
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"cogni/internal/agent"
+	"cogni/internal/testutil"
+	"cogni/internal/tools"
+)
+
+// recordingExecutor records executed tool names.
+type recordingExecutor struct {
+	names []string
+}
+
+// Execute records the call and returns an empty result.
+func (e *recordingExecutor) Execute(_ context.Context, call agent.ToolCall) tools.CallResult {
+	e.names = append(e.names, call.Name)
+	return tools.CallResult{Tool: call.Name, Output: "ok"}
+}
+
+// TestRunCallStopsAtTerminalTool verifies terminal tool calls end the run without execution.
+func TestRunCallStopsAtTerminalTool(t *testing.T) {
+	ctx := testutil.Context(t, 2*time.Second)
+	session := &agent.Session{Ctx: agent.TurnContext{ModelFamily: agent.ModelFamily{BaseInstructionsTemplate: "base"}}}
+	args := agent.ToolCallArgs{"answer": json.RawMessage(`"4"`)}
+	provider := &stubProvider{streams: [][]agent.StreamEvent{
+		{{Type: agent.StreamEventToolCall, ToolCall: agent.ToolCall{ID: "c1", Name: "search", Args: agent.ToolCallArgs{"query": json.RawMessage(`"x"`)}}}},
+		{
+			{Type: agent.StreamEventToolCall, ToolCall: agent.ToolCall{ID: "c2", Name: "submit_answer", Args: args}},
+			{Type: agent.StreamEventToolCall, ToolCall: agent.ToolCall{ID: "c3", Name: "search", Args: agent.ToolCallArgs{"query": json.RawMessage(`"y"`)}}},
+		},
+	}}
+	executor := &recordingExecutor{}
+
+	result, err := RunCall(ctx, session, provider, executor, "run", RunOptions{TerminalTool: "submit_answer"}, nil)
+	if err != nil {
+		t.Fatalf("run call: %v", err)
+	}
+	if provider.calls != 2 {
+		t.Fatalf("expected 2 provider calls, got %d", provider.calls)
+	}
+	if len(executor.names) != 1 || executor.names[0] != "search" {
+		t.Fatalf("expected only the first search to execute, got %v", executor.names)
+	}
+	if result.TerminalCall == nil || result.TerminalCall.ID != "c2" {
+		t.Fatalf("expected terminal call c2, got %+v", result.TerminalCall)
+	}
+	if result.Metrics.ToolCalls["submit_answer"] != 1 {
+		t.Fatalf("expected submit_answer to be counted, got %v", result.Metrics.ToolCalls)
+	}
+}
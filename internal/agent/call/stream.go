@@ -6,10 +6,15 @@ import (
 	"io"
 
 	"cogni/internal/agent"
+	"cogni/internal/tools"
 )
 
-// handleResponseStream consumes streamed output and executes any tools.
-func handleResponseStream(ctx context.Context, session *agent.Session, stream agent.Stream, executor agent.ToolExecutor, metrics *RunMetrics, opts RunOptions) (bool, error) {
+// terminalToolOutput acknowledges a terminal tool call in the session history.
+const terminalToolOutput = "received"
+
+// handleResponseStream consumes streamed output and executes any tools. A call
+// to opts.TerminalTool is recorded without execution and ends the stream.
+func handleResponseStream(ctx context.Context, session *agent.Session, stream agent.Stream, executor agent.ToolExecutor, metrics *RunMetrics, opts RunOptions) (bool, *agent.ToolCall, error) {
 	needsFollowUp := false
 	for {
 		event, err := stream.Recv()
@@ -17,7 +22,7 @@ func handleResponseStream(ctx context.Context, session *agent.Session, stream ag
 			if err == io.EOF {
 				break
 			}
-			return needsFollowUp, err
+			return needsFollowUp, nil, err
 		}
 		switch event.Type {
 		case agent.StreamEventMessage:
@@ -29,6 +34,23 @@ func handleResponseStream(ctx context.Context, session *agent.Session, stream ag
 			}
 			logVerbose(opts, styleHeadingToolCall, fmt.Sprintf("Tool call id=%s name=%s args=%s", event.ToolCall.ID, event.ToolCall.Name, formatArgs(event.ToolCall.Args)))
 			session.History = append(session.History, agent.HistoryItem{Role: "assistant", Content: event.ToolCall})
+			if opts.TerminalTool != "" && event.ToolCall.Name == opts.TerminalTool {
+				session.History = append(session.History, agent.HistoryItem{Role: "tool", Content: agent.ToolOutput{
+					ToolCallID: event.ToolCall.ID,
+					Result:     tools.CallResult{Tool: event.ToolCall.Name, Output: terminalToolOutput, OutputBytes: len(terminalToolOutput)},
+				}})
+				if metrics != nil {
+					if metrics.ToolCalls == nil {
+						metrics.ToolCalls = map[string]int{}
+					}
+					metrics.ToolCalls[event.ToolCall.Name]++
+				}
+				terminal := event.ToolCall
+				return false, &terminal, nil
+			}
+			if err := ctx.Err(); err != nil {
+				return needsFollowUp, nil, err
+			}
 			result := executor.Execute(ctx, event.ToolCall)
 			session.History = append(session.History, agent.HistoryItem{Role: "tool", Content: agent.ToolOutput{
 				ToolCallID: event.ToolCall.ID,
@@ -43,8 +65,8 @@ func handleResponseStream(ctx context.Context, session *agent.Session, stream ag
 			}
 			needsFollowUp = true
 		default:
-			return needsFollowUp, fmt.Errorf("unknown stream event type: %d", event.Type)
+			return needsFollowUp, nil, fmt.Errorf("unknown stream event type: %d", event.Type)
 		}
 	}
-	return needsFollowUp, nil
+	return needsFollowUp, nil, nil
 }
@@ -12,6 +12,7 @@ type CallInput struct {
 // CallResult captures the terminal output and metrics.
 type CallResult struct {
 	Output        string
+	TerminalCall  *agent.ToolCall
 	Metrics       RunMetrics
 	FailureReason string
 }
@@ -9,6 +9,9 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
+
+	"cogni/pkg/ratelimiter"
 )
 
 // defaultOpenRouterBaseURL is the default OpenRouter API base URL.
@@ -87,6 +90,19 @@ func (p *OpenRouterProvider) Stream(ctx context.Context, prompt Prompt) (Stream,
 		requestBody.Tools = buildOpenRouterTools(prompt.Tools)
 		requestBody.ToolChoice = "auto"
 	}
+	if strings.TrimSpace(prompt.OutputSchema) != "" {
+		if !json.Valid([]byte(prompt.OutputSchema)) {
+			return nil, fmt.Errorf("output schema is not valid JSON")
+		}
+		requestBody.ResponseFormat = &openRouterResponseFormat{
+			Type: "json_schema",
+			JSONSchema: openRouterJSONSchema{
+				Name:   "output",
+				Strict: true,
+				Schema: json.RawMessage(prompt.OutputSchema),
+			},
+		}
+	}
 	payload, err := json.Marshal(requestBody)
 	if err != nil {
 		return nil, fmt.Errorf("marshal request: %w", err)
@@ -105,9 +121,16 @@ func (p *OpenRouterProvider) Stream(ctx context.Context, prompt Prompt) (Stream,
 		return nil, err
 	}
 	defer resp.Body.Close()
+	feedback := providerFeedbackFromResponse(resp, time.Now())
+	ratelimiter.ReportProviderFeedback(ctx, feedback)
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("openrouter error: %s", strings.TrimSpace(string(body)))
+		return nil, &ProviderError{
+			Provider:   "openrouter",
+			StatusCode: resp.StatusCode,
+			RetryAfter: feedback.RetryAfter,
+			Body:       strings.TrimSpace(string(body)),
+		}
 	}
 
 	events, err := parseOpenRouterStream(resp.Body)
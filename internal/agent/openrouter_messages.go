@@ -8,11 +8,25 @@ import (
 
 // openRouterRequest is the JSON payload sent to OpenRouter.
 type openRouterRequest struct {
-	Model      string              `json:"model"`
-	Stream     bool                `json:"stream"`
-	Messages   []openRouterMessage `json:"messages"`
-	Tools      []openRouterTool    `json:"tools,omitempty"`
-	ToolChoice string              `json:"tool_choice,omitempty"`
+	Model          string                    `json:"model"`
+	Stream         bool                      `json:"stream"`
+	Messages       []openRouterMessage       `json:"messages"`
+	Tools          []openRouterTool          `json:"tools,omitempty"`
+	ToolChoice     string                    `json:"tool_choice,omitempty"`
+	ResponseFormat *openRouterResponseFormat `json:"response_format,omitempty"`
+}
+
+// openRouterResponseFormat requests structured output matching a JSON schema.
+type openRouterResponseFormat struct {
+	Type       string               `json:"type"`
+	JSONSchema openRouterJSONSchema `json:"json_schema"`
+}
+
+// openRouterJSONSchema names the schema used for structured output.
+type openRouterJSONSchema struct {
+	Name   string          `json:"name"`
+	Strict bool            `json:"strict"`
+	Schema json.RawMessage `json:"schema"`
 }
 
 // openRouterMessage represents a single OpenRouter chat message.
@@ -2,14 +2,17 @@ package agent
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"cogni/internal/testutil"
+	"cogni/pkg/ratelimiter"
 )
 
 // TestProviderFromEnvErrors verifies provider environment validation errors.
@@ -118,3 +121,95 @@ func TestOpenRouterRejectsToolHistoryWithoutID(t *testing.T) {
 		t.Fatalf("expected tool call id error, got %v", err)
 	}
 }
+
+// TestOpenRouterSendsOutputSchema verifies output schemas become a json_schema response format.
+func TestOpenRouterSendsOutputSchema(t *testing.T) {
+	var captured openRouterRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&captured); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"{\\\"answer\\\":\\\"4\\\"}\"}}]}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	t.Cleanup(server.Close)
+
+	provider, err := NewOpenRouterProvider("model", "key", server.URL, server.Client())
+	if err != nil {
+		t.Fatalf("new provider: %v", err)
+	}
+	ctx := testutil.Context(t, 0)
+	schema := `{"type":"object","properties":{"answer":{"type":"string"}},"required":["answer"]}`
+	stream, err := provider.Stream(ctx, Prompt{
+		InputItems:   []HistoryItem{{Role: "user", Content: HistoryText{Text: "hi"}}},
+		OutputSchema: schema,
+	})
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("recv: %v", err)
+	}
+	if captured.ResponseFormat == nil || captured.ResponseFormat.Type != "json_schema" {
+		t.Fatalf("expected json_schema response format, got %+v", captured.ResponseFormat)
+	}
+	if string(captured.ResponseFormat.JSONSchema.Schema) != schema {
+		t.Fatalf("unexpected schema: %s", captured.ResponseFormat.JSONSchema.Schema)
+	}
+
+	if _, err := provider.Stream(ctx, Prompt{OutputSchema: "{"}); err == nil {
+		t.Fatalf("expected invalid schema error")
+	}
+}
+
+// TestOpenRouterReportsRateLimitFeedback verifies 429s and rate-limit headers reach the feedback hook.
+func TestOpenRouterReportsRateLimitFeedback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "2")
+		w.Header().Set("X-RateLimit-Limit-Requests", "60")
+		w.Header().Set("X-RateLimit-Remaining-Requests", "0")
+		w.Header().Set("X-RateLimit-Remaining-Tokens", "5000")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"error":"rate limited"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	provider, err := NewOpenRouterProvider("model", "key", server.URL, server.Client())
+	if err != nil {
+		t.Fatalf("new provider: %v", err)
+	}
+	var got []ratelimiter.ProviderFeedback
+	ctx := ratelimiter.WithProviderFeedback(testutil.Context(t, 0), func(fb ratelimiter.ProviderFeedback) {
+		got = append(got, fb)
+	})
+	_, err = provider.Stream(ctx, Prompt{InputItems: []HistoryItem{{Role: "user", Content: HistoryText{Text: "hi"}}}})
+	var providerErr *ProviderError
+	if !errors.As(err, &providerErr) || providerErr.StatusCode != http.StatusTooManyRequests || !providerErr.Retryable() || providerErr.RetryDelay() != 2*time.Second {
+		t.Fatalf("expected retryable provider error, got %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected one feedback report, got %d", len(got))
+	}
+	fb := got[0]
+	if !fb.Throttled || fb.RetryAfter != 2*time.Second {
+		t.Fatalf("unexpected throttle feedback: %+v", fb)
+	}
+	if fb.LimitRequests == nil || *fb.LimitRequests != 60 || fb.RemainingRequests == nil || *fb.RemainingRequests != 0 {
+		t.Fatalf("unexpected request counts: %+v", fb)
+	}
+	if fb.RemainingTokens == nil || *fb.RemainingTokens != 5000 || fb.LimitTokens != nil {
+		t.Fatalf("unexpected token counts: %+v", fb)
+	}
+}
+
+// TestParseRetryAfterAcceptsHTTPDate verifies Retry-After dates become delays.
+func TestParseRetryAfterAcceptsHTTPDate(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if got := parseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now); got != 90*time.Second {
+		t.Fatalf("expected 90s, got %v", got)
+	}
+	if got := parseRetryAfter("soon", now); got != 0 {
+		t.Fatalf("expected 0 for invalid value, got %v", got)
+	}
+}
@@ -0,0 +1,36 @@
+package agent
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ProviderError is a non-2xx response from an LLM provider.
+type ProviderError struct {
+	Provider   string
+	StatusCode int
+	// RetryAfter is the provider's Retry-After hint, if any.
+	RetryAfter time.Duration
+	Body       string
+}
+
+// Error renders the provider name and response body.
+func (e *ProviderError) Error() string {
+	return fmt.Sprintf("%s error: %s", e.Provider, e.Body)
+}
+
+// Retryable reports whether the status is transient: throttling, timeouts
+// and server errors.
+func (e *ProviderError) Retryable() bool {
+	switch e.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return true
+	}
+	return e.StatusCode >= 500
+}
+
+// RetryDelay returns the provider's retry-after hint.
+func (e *ProviderError) RetryDelay() time.Duration {
+	return e.RetryAfter
+}
@@ -0,0 +1,58 @@
+package agent
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"cogni/pkg/ratelimiter"
+)
+
+// providerFeedbackFromResponse reads the provider's rate-limit signals from a
+// response: a 429 status, Retry-After, and the x-ratelimit-* headers. A bare
+// X-Ratelimit-Limit counts requests per provider-chosen interval rather than
+// per minute, so it is not read as the RPM limit.
+func providerFeedbackFromResponse(resp *http.Response, now time.Time) ratelimiter.ProviderFeedback {
+	header := resp.Header
+	return ratelimiter.ProviderFeedback{
+		Throttled:         resp.StatusCode == http.StatusTooManyRequests,
+		RetryAfter:        parseRetryAfter(header.Get("Retry-After"), now),
+		LimitRequests:     headerUint(header, "X-Ratelimit-Limit-Requests"),
+		LimitTokens:       headerUint(header, "X-Ratelimit-Limit-Tokens"),
+		RemainingRequests: headerUint(header, "X-Ratelimit-Remaining-Requests", "X-Ratelimit-Remaining"),
+		RemainingTokens:   headerUint(header, "X-Ratelimit-Remaining-Tokens"),
+	}
+}
+
+// parseRetryAfter accepts delay seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds * float64(time.Second))
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+// headerUint returns the first of names that holds an unsigned integer.
+func headerUint(header http.Header, names ...string) *uint64 {
+	for _, name := range names {
+		value := strings.TrimSpace(header.Get(name))
+		if value == "" {
+			continue
+		}
+		if n, err := strconv.ParseUint(value, 10, 64); err == nil {
+			return &n
+		}
+	}
+	return nil
+}
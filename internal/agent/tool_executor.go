@@ -9,8 +9,10 @@ import (
 )
 
 // RunnerExecutor executes built-in tool calls against a tools.Runner.
+// Write tools (apply_patch, write_file) are rejected unless AllowWrites is set.
 type RunnerExecutor struct {
-	Runner *tools.Runner
+	Runner      *tools.Runner
+	AllowWrites bool
 }
 
 // Execute dispatches a tool call to the underlying runner.
@@ -72,6 +74,28 @@ func (e RunnerExecutor) Execute(ctx context.Context, call ToolCall) tools.CallRe
 			return errorResult(call.Name, err.Error())
 		}
 		return e.Runner.ReadFile(ctx, tools.ReadFileArgs{Path: path, StartLine: startLine, EndLine: endLine})
+	case "apply_patch":
+		if !e.AllowWrites {
+			return errorResult(call.Name, "write tools are not enabled for this task")
+		}
+		patch, err := call.Args.RequiredString("patch")
+		if err != nil {
+			return errorResult(call.Name, err.Error())
+		}
+		return e.Runner.ApplyPatch(ctx, tools.ApplyPatchArgs{Patch: patch})
+	case "write_file":
+		if !e.AllowWrites {
+			return errorResult(call.Name, "write tools are not enabled for this task")
+		}
+		path, err := call.Args.RequiredString("path")
+		if err != nil {
+			return errorResult(call.Name, err.Error())
+		}
+		content, _, err := call.Args.OptionalString("content")
+		if err != nil {
+			return errorResult(call.Name, err.Error())
+		}
+		return e.Runner.WriteFile(ctx, tools.WriteFileArgs{Path: path, Content: content})
 	default:
 		return errorResult(call.Name, fmt.Sprintf("unknown tool %q", call.Name))
 	}
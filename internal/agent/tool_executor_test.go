@@ -1,6 +1,9 @@
 package agent
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -42,3 +45,29 @@ func TestRunnerExecutorUnknownTool(t *testing.T) {
 		t.Fatalf("unexpected error: %v", result.Error)
 	}
 }
+
+// TestRunnerExecutorWriteToolsRequireOptIn verifies write tools only run when allowed.
+func TestRunnerExecutorWriteToolsRequireOptIn(t *testing.T) {
+	root := t.TempDir()
+	runner, err := tools.NewRunner(root)
+	if err != nil {
+		t.Fatalf("new runner: %v", err)
+	}
+	call := ToolCall{Name: "write_file", Args: ToolCallArgs{
+		"path":    json.RawMessage(`"notes.txt"`),
+		"content": json.RawMessage(`"hello"`),
+	}}
+
+	ctx := testutil.Context(t, 0)
+	result := RunnerExecutor{Runner: runner}.Execute(ctx, call)
+	if !strings.Contains(result.Error, "not enabled") {
+		t.Fatalf("expected read-only executor to reject writes, got %+v", result)
+	}
+	result = RunnerExecutor{Runner: runner, AllowWrites: true}.Execute(ctx, call)
+	if result.Error != "" {
+		t.Fatalf("write_file: %s", result.Error)
+	}
+	if data, err := os.ReadFile(filepath.Join(root, "notes.txt")); err != nil || string(data) != "hello" {
+		t.Fatalf("unexpected file contents %q (%v)", data, err)
+	}
+}
@@ -0,0 +1,193 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"cogni/internal/backend"
+	"cogni/pkg/ratelimiter"
+)
+
+// errRemoveUnsupported rejects a limit set that deletes limits on a backend
+// that cannot remove them.
+var errRemoveUnsupported = errors.New("backend cannot remove limits")
+
+// ApplyLimits validates a full limit set and applies it to cfg's registry and
+// backend exactly as POST /v1/admin/limits:apply does: decreases go through
+// the decreasing flow, missing limits are drained and deleted, and a failed
+// change rolls back the others. The caller holds cfg.Registry.LockEdits, so
+// it can read the limit set and apply it without an admin write in between.
+func ApplyLimits(ctx context.Context, cfg Config, req ratelimiter.ApplyLimitsRequest) (ratelimiter.ApplyLimitsResponse, error) {
+	h := &handler{registry: cfg.Registry, backend: cfg.Backend, registryPath: cfg.RegistryPath}
+	limits, err := normalizeLimitSet(req.Limits)
+	if err != nil {
+		return ratelimiter.ApplyLimitsResponse{}, err
+	}
+	req.Limits = limits
+	return h.applyLimits(ctx, req)
+}
+
+func (h *handler) handleAdminApplyLimits(w http.ResponseWriter, r *http.Request) {
+	if h.registry == nil {
+		writeError(w, http.StatusInternalServerError, "backend_error")
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	req, err := decodeApplyLimits(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+	unlock := h.registry.LockEdits()
+	res, err := h.applyLimits(r.Context(), req)
+	unlock()
+	if errors.Is(err, errRemoveUnsupported) {
+		writeError(w, http.StatusNotImplemented, "not_implemented")
+		return
+	}
+	if err != nil {
+		writeApplyLimitsResponse(w, http.StatusInternalServerError, res)
+		return
+	}
+	writeApplyLimitsResponse(w, http.StatusOK, res)
+}
+
+// applyLimits diffs a validated limit set against the registry and, unless
+// it is a dry run, applies every change and saves the registry. The set is
+// applied all or nothing: when a change fails, the changes before it are
+// undone in reverse order, the registry is saved either way, and the error
+// comes with a response naming the failed key and any change left in effect.
+func (h *handler) applyLimits(ctx context.Context, req ratelimiter.ApplyLimitsRequest) (ratelimiter.ApplyLimitsResponse, error) {
+	changes, unchanged := h.registry.Diff(req.Limits)
+	res := ratelimiter.ApplyLimitsResponse{OK: true, DryRun: req.DryRun, Changes: changes, Unchanged: unchanged}
+	if req.DryRun {
+		return res, nil
+	}
+	remover, canRemove := h.backend.(backend.LimitRemover)
+	prior := make(map[ratelimiter.LimitKey]ratelimiter.LimitState, len(changes))
+	for _, change := range changes {
+		if change.Action == ratelimiter.LimitChangeDelete && !canRemove {
+			return ratelimiter.ApplyLimitsResponse{}, errRemoveUnsupported
+		}
+		if state, ok := h.registry.Get(change.Key); ok {
+			prior[change.Key] = state
+		}
+	}
+	applied := 0
+	var applyErr error
+	for _, change := range changes {
+		if applyErr = h.applyLimitChange(ctx, remover, change); applyErr != nil {
+			res.OK = false
+			res.Error = "backend_error"
+			res.FailedKey = change.Key
+			h.restoreRegistryEntry(change.Key, prior)
+			break
+		}
+		applied++
+	}
+	for i := applied - 1; applyErr != nil && i >= 0; i-- {
+		change := changes[i]
+		if err := h.undoLimitChange(ctx, remover, change, prior); err != nil {
+			res.Applied = append(res.Applied, change)
+			continue
+		}
+		h.restoreRegistryEntry(change.Key, prior)
+	}
+	if err := h.saveRegistry(); err != nil {
+		res.OK = false
+		res.Error = "backend_error"
+		if applyErr == nil {
+			applyErr = err
+		}
+	}
+	return res, applyErr
+}
+
+// applyLimitChange applies one planned change to the backend and registry.
+func (h *handler) applyLimitChange(ctx context.Context, remover backend.LimitRemover, change ratelimiter.LimitChange) error {
+	if change.Action == ratelimiter.LimitChangeDelete {
+		_, err := h.deleteLimit(ctx, remover, change.Key)
+		return err
+	}
+	def := *change.After
+	state := h.registry.NextState(def)
+	if h.backend != nil {
+		if err := h.backend.ApplyDefinition(ctx, def); err != nil {
+			return err
+		}
+	}
+	h.registry.Put(state)
+	return nil
+}
+
+// undoLimitChange reverts an applied change in the backend: a created limit
+// is removed and any other limit gets its prior state back, verbatim when the
+// backend can load states and as its prior target definition otherwise.
+func (h *handler) undoLimitChange(ctx context.Context, remover backend.LimitRemover, change ratelimiter.LimitChange, prior map[ratelimiter.LimitKey]ratelimiter.LimitState) error {
+	if h.backend == nil {
+		return nil
+	}
+	state, existed := prior[change.Key]
+	if !existed {
+		if remover == nil {
+			return errRemoveUnsupported
+		}
+		_, err := remover.RemoveDefinition(ctx, change.Key)
+		return err
+	}
+	if applier, ok := h.backend.(backend.StateApplier); ok {
+		return applier.ApplyState(state)
+	}
+	return h.backend.ApplyDefinition(ctx, *change.Before)
+}
+
+// restoreRegistryEntry puts key's prior registry state back, or removes key
+// when it did not exist before.
+func (h *handler) restoreRegistryEntry(key ratelimiter.LimitKey, prior map[ratelimiter.LimitKey]ratelimiter.LimitState) {
+	if state, ok := prior[key]; ok {
+		h.registry.Put(state)
+		return
+	}
+	h.registry.Delete(key)
+}
+
+// decodeApplyLimits parses and validates a full limit set.
+func decodeApplyLimits(r *http.Request) (ratelimiter.ApplyLimitsRequest, error) {
+	var req ratelimiter.ApplyLimitsRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		return ratelimiter.ApplyLimitsRequest{}, err
+	}
+	limits, err := normalizeLimitSet(req.Limits)
+	if err != nil {
+		return ratelimiter.ApplyLimitsRequest{}, err
+	}
+	req.Limits = limits
+	return req, nil
+}
+
+// normalizeLimitSet normalizes and validates every definition. Any invalid or
+// duplicate definition rejects the whole set before anything is applied.
+func normalizeLimitSet(defs []ratelimiter.LimitDefinition) ([]ratelimiter.LimitDefinition, error) {
+	seen := make(map[ratelimiter.LimitKey]bool, len(defs))
+	out := make([]ratelimiter.LimitDefinition, 0, len(defs))
+	for _, def := range defs {
+		def = normalizeLimitDefinition(def)
+		if err := validateLimitDefinition(def); err != nil {
+			return nil, fmt.Errorf("limit %q: %w", def.Key, err)
+		}
+		if seen[def.Key] {
+			return nil, fmt.Errorf("limit %q: duplicate key", def.Key)
+		}
+		seen[def.Key] = true
+		out = append(out, def)
+	}
+	return out, nil
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"cogni/internal/backend"
+	"cogni/pkg/ratelimiter"
+)
+
+func (h *handler) handleAdminDeleteLimit(w http.ResponseWriter, r *http.Request, key ratelimiter.LimitKey) {
+	defer h.registry.LockEdits()()
+	if _, ok := h.registry.Get(key); !ok {
+		writeError(w, http.StatusNotFound, "not_found")
+		return
+	}
+	remover, ok := h.backend.(backend.LimitRemover)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, "not_implemented")
+		return
+	}
+	status, err := h.deleteLimit(r.Context(), remover, key)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "backend_error")
+		return
+	}
+	if err := h.saveRegistry(); err != nil {
+		writeError(w, http.StatusInternalServerError, "backend_error")
+		return
+	}
+	writeDeleteLimitResponse(w, http.StatusOK, ratelimiter.DeleteLimitResponse{OK: true, Status: status})
+}
+
+// deleteLimit marks key draining in the registry before the backend starts
+// draining, so a backend that finishes the drain concurrently removes the
+// registry entry after it was written. It reports draining or deleted.
+func (h *handler) deleteLimit(ctx context.Context, remover backend.LimitRemover, key ratelimiter.LimitKey) (ratelimiter.LimitStatus, error) {
+	state, ok := h.registry.Get(key)
+	if !ok {
+		return ratelimiter.LimitStatusDeleted, nil
+	}
+	state.Status = ratelimiter.LimitStatusDraining
+	state.PendingDecreaseTo = 0
+	h.registry.Put(state)
+	removed, err := remover.RemoveDefinition(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	if !removed {
+		return ratelimiter.LimitStatusDraining, nil
+	}
+	h.registry.Delete(key)
+	return ratelimiter.LimitStatusDeleted, nil
+}
+
+// saveRegistry persists the registry when a path is configured.
+func (h *handler) saveRegistry() error {
+	if h.registryPath == "" {
+		return nil
+	}
+	return h.registry.Save(h.registryPath)
+}
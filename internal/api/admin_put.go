@@ -19,6 +19,7 @@ func (h *handler) handleAdminPutLimit(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid_request")
 		return
 	}
+	defer h.registry.LockEdits()()
 	state := h.registry.NextState(def)
 	if h.backend != nil {
 		if err := h.backend.ApplyDefinition(r.Context(), def); err != nil {
@@ -43,6 +44,14 @@ func decodeLimitDefinition(r *http.Request) (ratelimiter.LimitDefinition, error)
 	if err := decoder.Decode(&def); err != nil {
 		return ratelimiter.LimitDefinition{}, err
 	}
+	def = normalizeLimitDefinition(def)
+	if err := validateLimitDefinition(def); err != nil {
+		return ratelimiter.LimitDefinition{}, err
+	}
+	return def, nil
+}
+
+func normalizeLimitDefinition(def ratelimiter.LimitDefinition) ratelimiter.LimitDefinition {
 	def.Key = ratelimiter.LimitKey(strings.TrimSpace(string(def.Key)))
 	def.Unit = strings.TrimSpace(def.Unit)
 	def.Description = strings.TrimSpace(def.Description)
@@ -51,10 +60,7 @@ func decodeLimitDefinition(r *http.Request) (ratelimiter.LimitDefinition, error)
 	if def.Overage == "" {
 		def.Overage = ratelimiter.OverageDebt
 	}
-	if err := validateLimitDefinition(def); err != nil {
-		return ratelimiter.LimitDefinition{}, err
-	}
-	return def, nil
+	return def
 }
 
 func validateLimitDefinition(def ratelimiter.LimitDefinition) error {
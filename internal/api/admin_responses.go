@@ -1,6 +1,9 @@
 package api
 
-import "cogni/pkg/ratelimiter"
+import (
+	"cogni/internal/backend"
+	"cogni/pkg/ratelimiter"
+)
 
 type limitResponse struct {
 	Limit ratelimiter.LimitState `json:"limit"`
@@ -9,3 +12,11 @@ type limitResponse struct {
 type limitsResponse struct {
 	Limits []ratelimiter.LimitState `json:"limits"`
 }
+
+type usageResponse = ratelimiter.LimitUsageResponse
+
+type leaseHoldResponse = ratelimiter.LimitHold
+
+type leasesResponse struct {
+	Leases []backend.LeaseInfo `json:"leases"`
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"cogni/internal/backend"
+	"cogni/pkg/ratelimiter"
+)
+
+// usageSuffix selects the usage view of a limit under /v1/admin/limits/{key}.
+const usageSuffix = "/usage"
+
+// usageLeaseReporter is what a backend needs to serve the usage endpoint.
+type usageLeaseReporter interface {
+	backend.UsageReporter
+	backend.LeaseReporter
+}
+
+// usageKey returns the limit key of a usage path. Keys may contain slashes,
+// so a registered key that itself ends in /usage takes precedence.
+func (h *handler) usageKey(path string) (ratelimiter.LimitKey, bool) {
+	if !strings.HasSuffix(path, usageSuffix) {
+		return "", false
+	}
+	if _, ok := h.registry.Get(ratelimiterKey(path)); ok {
+		return "", false
+	}
+	return ratelimiterKey(strings.TrimSuffix(path, usageSuffix)), true
+}
+
+func (h *handler) handleAdminLimitUsage(w http.ResponseWriter, r *http.Request, key ratelimiter.LimitKey) {
+	reporter, ok := h.backend.(usageLeaseReporter)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, "not_implemented")
+		return
+	}
+	if _, ok := h.registry.Get(key); !ok {
+		writeError(w, http.StatusNotFound, "not_found")
+		return
+	}
+	usage, err := reporter.Usage(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "backend_error")
+		return
+	}
+	leases, err := reporter.Leases(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "backend_error")
+		return
+	}
+	for _, entry := range usage {
+		if entry.Key != key {
+			continue
+		}
+		res := usageResponse{Usage: entry, Holds: holdsForKey(leases, key)}
+		if h.adaptive != nil {
+			adjustment, ok, err := h.adaptive.Adjustment(r.Context(), key)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, "backend_error")
+				return
+			}
+			if ok {
+				res.Adjustment = &adjustment
+			}
+		}
+		writeUsageResponse(w, http.StatusOK, res)
+		return
+	}
+	writeError(w, http.StatusNotFound, "not_found")
+}
+
+func (h *handler) handleAdminLeases(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	reporter, ok := h.backend.(backend.LeaseReporter)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, "not_implemented")
+		return
+	}
+	leases, err := reporter.Leases(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "backend_error")
+		return
+	}
+	if key := strings.TrimSpace(r.URL.Query().Get("key")); key != "" {
+		leases = leasesForKey(leases, ratelimiterKey(key))
+	}
+	writeLeasesResponse(w, http.StatusOK, leasesResponse{Leases: leases})
+}
+
+// holdsForKey flattens the holds leases keep on one limit.
+func holdsForKey(leases []backend.LeaseInfo, key ratelimiter.LimitKey) []leaseHoldResponse {
+	holds := []leaseHoldResponse{}
+	for _, lease := range leases {
+		for _, hold := range lease.Holds {
+			if hold.Key == key {
+				holds = append(holds, leaseHoldResponse{LeaseID: lease.LeaseID, ReservedAtUnixMs: lease.ReservedAtUnixMs, LeaseHold: hold})
+			}
+		}
+	}
+	return holds
+}
+
+// leasesForKey keeps the leases holding capacity on key.
+func leasesForKey(leases []backend.LeaseInfo, key ratelimiter.LimitKey) []backend.LeaseInfo {
+	out := []backend.LeaseInfo{}
+	for _, lease := range leases {
+		for _, hold := range lease.Holds {
+			if hold.Key == key {
+				out = append(out, lease)
+				break
+			}
+		}
+	}
+	return out
+}
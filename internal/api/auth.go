@@ -0,0 +1,192 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"cogni/pkg/ratelimiter"
+)
+
+// Scope grants access to a class of endpoints.
+type Scope string
+
+const (
+	// ScopeAdmin may call every endpoint, including /v1/admin and /metrics.
+	ScopeAdmin Scope = "admin"
+	// ScopeClient may reserve and complete.
+	ScopeClient Scope = "client"
+)
+
+// Principal is an authenticated caller. A client principal with a Tenant may
+// only use tenant-scoped keys of that tenant, plus global keys.
+type Principal struct {
+	Name   string
+	Scope  Scope
+	Tenant string
+}
+
+// Authenticator identifies the caller of a request.
+type Authenticator interface {
+	Authenticate(r *http.Request) (Principal, bool)
+}
+
+// Credential binds a bearer token or a verified client certificate common
+// name to a principal.
+type Credential struct {
+	Principal
+	Token        string
+	ClientCertCN string
+}
+
+// staticAuthenticator resolves callers against a fixed credential list.
+type staticAuthenticator struct {
+	tokens map[[sha256.Size]byte]Principal
+	certs  map[string]Principal
+}
+
+// NewStaticAuthenticator validates credentials and indexes them by token
+// digest and certificate common name.
+func NewStaticAuthenticator(creds []Credential) (Authenticator, error) {
+	auth := staticAuthenticator{tokens: map[[sha256.Size]byte]Principal{}, certs: map[string]Principal{}}
+	for _, cred := range creds {
+		switch {
+		case cred.Name == "":
+			return nil, errors.New("credential name is required")
+		case cred.Scope != ScopeAdmin && cred.Scope != ScopeClient:
+			return nil, fmt.Errorf("credential %s: scope must be admin or client", cred.Name)
+		case cred.Scope == ScopeAdmin && cred.Tenant != "":
+			return nil, fmt.Errorf("credential %s: tenant is only valid for client scope", cred.Name)
+		case cred.Token == "" && cred.ClientCertCN == "":
+			return nil, fmt.Errorf("credential %s: token or client certificate CN is required", cred.Name)
+		}
+		if cred.Token != "" {
+			digest := sha256.Sum256([]byte(cred.Token))
+			if _, ok := auth.tokens[digest]; ok {
+				return nil, fmt.Errorf("credential %s: duplicate token", cred.Name)
+			}
+			auth.tokens[digest] = cred.Principal
+		}
+		if cred.ClientCertCN != "" {
+			if _, ok := auth.certs[cred.ClientCertCN]; ok {
+				return nil, fmt.Errorf("credential %s: duplicate client certificate CN", cred.Name)
+			}
+			auth.certs[cred.ClientCertCN] = cred.Principal
+		}
+	}
+	return auth, nil
+}
+
+// Authenticate accepts a bearer token, then a verified client certificate.
+// Tokens are compared by SHA-256 digest so lookups do not leak prefixes.
+func (a staticAuthenticator) Authenticate(r *http.Request) (Principal, bool) {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		principal, ok := a.tokens[sha256.Sum256([]byte(strings.TrimSpace(token)))]
+		return principal, ok
+	}
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.PeerCertificates) > 0 {
+		principal, ok := a.certs[r.TLS.PeerCertificates[0].Subject.CommonName]
+		return principal, ok
+	}
+	return Principal{}, false
+}
+
+type principalKey struct{}
+
+// authorize rejects callers without the scope.
+func (h *handler) authorize(scope Scope, next http.HandlerFunc) http.HandlerFunc {
+	if h.auth == nil {
+		return next
+	}
+	return RequireScope(h.auth, scope, next).ServeHTTP
+}
+
+// RequireScope wraps next so only authenticated callers with the scope reach
+// it; admins pass every check. A nil auth allows every request.
+func RequireScope(auth Authenticator, scope Scope, next http.Handler) http.Handler {
+	if auth == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := auth.Authenticate(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="ratelimiterd"`)
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		if principal.Scope != ScopeAdmin && principal.Scope != scope {
+			writeError(w, http.StatusForbidden, "forbidden")
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, principal)))
+	})
+}
+
+// forbiddenKey returns the first key a tenant-scoped caller may not use.
+func forbiddenKey(ctx context.Context, keys []ratelimiter.LimitKey) (ratelimiter.LimitKey, bool) {
+	owner := principalTenant(ctx)
+	if owner == "" {
+		return "", false
+	}
+	for _, key := range keys {
+		if tenant, ok := ratelimiter.KeyTenant(key); ok && tenant != owner {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// missingTenantKey returns the daily budget key a tenant-scoped caller must
+// include when it reserves provider capacity, so no tenant can spend shared
+// LLM limits outside its own budget.
+func missingTenantKey(ctx context.Context, keys []ratelimiter.LimitKey) (ratelimiter.LimitKey, bool) {
+	tenant := principalTenant(ctx)
+	if tenant == "" {
+		return "", false
+	}
+	required := ratelimiter.TenantDailyKey(tenant)
+	llm := false
+	for _, key := range keys {
+		if key == required {
+			return "", false
+		}
+		llm = llm || ratelimiter.IsLLMKey(key)
+	}
+	if !llm {
+		return "", false
+	}
+	return required, true
+}
+
+// principalTenant returns the tenant of the authenticated caller, or "" for
+// unscoped callers and servers without auth.
+func principalTenant(ctx context.Context) string {
+	principal, _ := ctx.Value(principalKey{}).(Principal)
+	return principal.Tenant
+}
+
+// forbiddenKeyError formats the denial for a key outside the caller's tenant.
+func forbiddenKeyError(key ratelimiter.LimitKey) string {
+	return "forbidden_limit_key:" + string(key)
+}
+
+// requirementKeys lists the keys a reserve request touches.
+func requirementKeys(reqs []ratelimiter.Requirement) []ratelimiter.LimitKey {
+	keys := make([]ratelimiter.LimitKey, len(reqs))
+	for i, req := range reqs {
+		keys[i] = req.Key
+	}
+	return keys
+}
+
+// actualKeys lists the keys a complete request touches.
+func actualKeys(actuals []ratelimiter.Actual) []ratelimiter.LimitKey {
+	keys := make([]ratelimiter.LimitKey, len(actuals))
+	for i, actual := range actuals {
+		keys[i] = actual.Key
+	}
+	return keys
+}
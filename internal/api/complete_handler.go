@@ -20,14 +20,24 @@ func (h *handler) handleComplete(w http.ResponseWriter, r *http.Request) {
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
 	if err := decoder.Decode(&req); err != nil {
+		h.metrics.ObserveComplete(invalidComplete, nil)
 		writeError(w, http.StatusBadRequest, "invalid_request")
 		return
 	}
 	if req.LeaseID == "" {
+		h.metrics.ObserveComplete(invalidComplete, nil)
 		writeError(w, http.StatusBadRequest, "invalid_request")
 		return
 	}
+	if key, ok := forbiddenKey(r.Context(), actualKeys(req.Actuals)); ok {
+		res := ratelimiter.CompleteResponse{Ok: false, Error: forbiddenKeyError(key)}
+		h.metrics.ObserveComplete(res, nil)
+		writeCompleteResponse(w, http.StatusOK, res)
+		return
+	}
+	req.Tenant = principalTenant(r.Context())
 	res, err := h.backend.Complete(r.Context(), req)
+	h.metrics.ObserveComplete(res, err)
 	if err != nil {
 		writeCompleteResponse(w, http.StatusOK, ratelimiter.CompleteResponse{Ok: false, Error: "backend_error"})
 		return
@@ -55,14 +65,24 @@ func (h *handler) handleBatchComplete(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid_request")
 		return
 	}
+	h.metrics.ObserveBatch("complete_batch", len(req.Requests))
 
 	results := make([]ratelimiter.BatchCompleteResult, 0, len(req.Requests))
 	for _, item := range req.Requests {
 		if item.LeaseID == "" {
+			h.metrics.ObserveComplete(invalidComplete, nil)
 			results = append(results, ratelimiter.BatchCompleteResult{Ok: false, Error: invalidRequestError})
 			continue
 		}
+		if key, ok := forbiddenKey(r.Context(), actualKeys(item.Actuals)); ok {
+			res := ratelimiter.CompleteResponse{Ok: false, Error: forbiddenKeyError(key)}
+			h.metrics.ObserveComplete(res, nil)
+			results = append(results, ratelimiter.BatchCompleteResult{Ok: false, Error: res.Error})
+			continue
+		}
+		item.Tenant = principalTenant(r.Context())
 		res, err := h.backend.Complete(r.Context(), item)
+		h.metrics.ObserveComplete(res, err)
 		if err != nil {
 			results = append(results, ratelimiter.BatchCompleteResult{Ok: false, Error: "backend_error"})
 			continue
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"cogni/pkg/ratelimiter"
+)
+
+func (h *handler) handleFeedback(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if h.adaptive == nil {
+		writeError(w, http.StatusNotImplemented, "not_implemented")
+		return
+	}
+	var req ratelimiter.FeedbackRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+	keys := make([]ratelimiter.LimitKey, len(req.Feedback))
+	for i, fb := range req.Feedback {
+		if strings.TrimSpace(string(fb.Key)) == "" || fb.RetryAfterMs < 0 {
+			writeError(w, http.StatusBadRequest, "invalid_request")
+			return
+		}
+		keys[i] = fb.Key
+	}
+	if key, ok := forbiddenKey(r.Context(), keys); ok {
+		writeError(w, http.StatusForbidden, forbiddenKeyError(key))
+		return
+	}
+	adjustments, err := h.adaptive.Observe(r.Context(), req.Feedback)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "backend_error")
+		return
+	}
+	writeFeedbackResponse(w, http.StatusOK, ratelimiter.FeedbackResponse{OK: true, Adjustments: adjustments})
+}
+
+func (h *handler) handleAdminAdjustments(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if h.adaptive == nil {
+		writeError(w, http.StatusNotImplemented, "not_implemented")
+		return
+	}
+	adjustments, err := h.adaptive.Adjustments(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "backend_error")
+		return
+	}
+	writeAdjustmentsResponse(w, http.StatusOK, ratelimiter.AdjustmentsResponse{Adjustments: adjustments})
+}
+
+// handleAdminResetAdjustment drops an adjustment, restoring the limit's
+// defined capacity.
+func (h *handler) handleAdminResetAdjustment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if h.adaptive == nil {
+		writeError(w, http.StatusNotImplemented, "not_implemented")
+		return
+	}
+	key := strings.TrimSpace(strings.TrimPrefix(r.URL.Path, "/v1/admin/adjustments/"))
+	found, err := h.adaptive.Reset(r.Context(), ratelimiterKey(key))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "backend_error")
+		return
+	}
+	if !found {
+		writeError(w, http.StatusNotFound, "not_found")
+		return
+	}
+	writeAdminPutResponse(w, http.StatusOK, adminPutResponse{OK: true, Status: string(ratelimiter.LimitStatusActive)})
+}
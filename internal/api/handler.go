@@ -5,7 +5,9 @@ import (
 	"strings"
 	"time"
 
+	"cogni/internal/adaptive"
 	"cogni/internal/backend"
+	"cogni/internal/metrics"
 	"cogni/internal/registry"
 )
 
@@ -15,6 +17,13 @@ type Config struct {
 	Backend      backend.Backend
 	RegistryPath string
 	Now          func() time.Time
+	// Metrics records request outcomes and latency; nil disables metrics.
+	Metrics *metrics.Limiter
+	// Auth authenticates callers; nil leaves the API open.
+	Auth Authenticator
+	// Adaptive adjusts capacities from provider feedback; nil disables
+	// the feedback and adjustment endpoints.
+	Adaptive *adaptive.Controller
 }
 
 // NewHandler builds an HTTP handler for the rate limiter API.
@@ -24,14 +33,22 @@ func NewHandler(cfg Config) http.Handler {
 		backend:      cfg.Backend,
 		registryPath: cfg.RegistryPath,
 		nowFn:        cfg.Now,
+		metrics:      cfg.Metrics,
+		auth:         cfg.Auth,
+		adaptive:     cfg.Adaptive,
 	}
 	mux := http.NewServeMux()
-	mux.HandleFunc("/v1/admin/limits", h.handleAdminLimits)
-	mux.HandleFunc("/v1/admin/limits/", h.handleAdminLimitByKey)
-	mux.HandleFunc("/v1/reserve", h.handleReserve)
-	mux.HandleFunc("/v1/reserve/batch", h.handleBatchReserve)
-	mux.HandleFunc("/v1/complete", h.handleComplete)
-	mux.HandleFunc("/v1/complete/batch", h.handleBatchComplete)
+	mux.HandleFunc("/v1/admin/limits", h.authorize(ScopeAdmin, h.handleAdminLimits))
+	mux.HandleFunc("/v1/admin/limits:apply", h.authorize(ScopeAdmin, h.handleAdminApplyLimits))
+	mux.HandleFunc("/v1/admin/limits/", h.authorize(ScopeAdmin, h.handleAdminLimitByKey))
+	mux.HandleFunc("/v1/admin/leases", h.authorize(ScopeAdmin, h.handleAdminLeases))
+	mux.HandleFunc("/v1/admin/adjustments", h.authorize(ScopeAdmin, h.handleAdminAdjustments))
+	mux.HandleFunc("/v1/admin/adjustments/", h.authorize(ScopeAdmin, h.handleAdminResetAdjustment))
+	mux.HandleFunc("/v1/feedback", h.authorize(ScopeClient, h.handleFeedback))
+	mux.HandleFunc("/v1/reserve", h.timed("reserve", h.authorize(ScopeClient, h.handleReserve)))
+	mux.HandleFunc("/v1/reserve/batch", h.timed("reserve_batch", h.authorize(ScopeClient, h.handleBatchReserve)))
+	mux.HandleFunc("/v1/complete", h.timed("complete", h.authorize(ScopeClient, h.handleComplete)))
+	mux.HandleFunc("/v1/complete/batch", h.timed("complete_batch", h.authorize(ScopeClient, h.handleBatchComplete)))
 	return mux
 }
 
@@ -40,6 +57,21 @@ type handler struct {
 	backend      backend.Backend
 	registryPath string
 	nowFn        func() time.Time
+	metrics      *metrics.Limiter
+	auth         Authenticator
+	adaptive     *adaptive.Controller
+}
+
+// timed records the latency of every request to an endpoint.
+func (h *handler) timed(endpoint string, next http.HandlerFunc) http.HandlerFunc {
+	if h.metrics == nil {
+		return next
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		next(w, r)
+		h.metrics.ObserveRequest(endpoint, time.Since(start))
+	}
 }
 
 func (h *handler) handleAdminLimits(w http.ResponseWriter, r *http.Request) {
@@ -62,7 +94,7 @@ func (h *handler) handleAdminLimitByKey(w http.ResponseWriter, r *http.Request)
 		writeError(w, http.StatusInternalServerError, "backend_error")
 		return
 	}
-	if r.Method != http.MethodGet {
+	if r.Method != http.MethodGet && r.Method != http.MethodDelete {
 		w.WriteHeader(http.StatusMethodNotAllowed)
 		return
 	}
@@ -72,6 +104,14 @@ func (h *handler) handleAdminLimitByKey(w http.ResponseWriter, r *http.Request)
 		writeError(w, http.StatusNotFound, "not_found")
 		return
 	}
+	if r.Method == http.MethodDelete {
+		h.handleAdminDeleteLimit(w, r, ratelimiterKey(key))
+		return
+	}
+	if usageKey, ok := h.usageKey(key); ok {
+		h.handleAdminLimitUsage(w, r, usageKey)
+		return
+	}
 	state, ok := h.registry.Get(ratelimiterKey(key))
 	if !ok {
 		writeError(w, http.StatusNotFound, "not_found")
@@ -0,0 +1,244 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cogni/internal/backend/memory"
+	"cogni/internal/registry"
+	"cogni/internal/testutil"
+	"cogni/pkg/ratelimiter"
+)
+
+func TestHTTP_AdminDeleteDrainsInFlightLeases(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		clock := testutil.NewFakeClock(time.Unix(0, 0))
+		reg := registry.New()
+		path := filepath.Join(t.TempDir(), "limits.json")
+		backend := memory.New(clock)
+		backend.AttachRegistry(reg, path)
+		srv := httptest.NewServer(NewHandler(Config{Registry: reg, Backend: backend, RegistryPath: path, Now: clock.Now}))
+		defer srv.Close()
+
+		def := ratelimiter.LimitDefinition{Key: "org/conc", Kind: ratelimiter.KindConcurrency, Capacity: 2, TimeoutSeconds: 30}
+		if resp, _ := doRequestJSON(t, http.MethodPut, srv.URL+"/v1/admin/limits", mustMarshal(t, def)); resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200, got %d", resp.StatusCode)
+		}
+		reserve := mustMarshalReserve(t, ratelimiter.ReserveRequest{LeaseID: "L1", Requirements: []ratelimiter.Requirement{{Key: def.Key, Amount: 1}}})
+		if resp, _ := doRequestJSON(t, http.MethodPost, srv.URL+"/v1/reserve", reserve); resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200, got %d", resp.StatusCode)
+		}
+
+		resp, body := doRequestJSON(t, http.MethodDelete, srv.URL+"/v1/admin/limits/org/conc", nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+		}
+		var deleted ratelimiter.DeleteLimitResponse
+		if err := json.Unmarshal(body, &deleted); err != nil {
+			t.Fatalf("parse delete: %v", err)
+		}
+		if deleted.Status != ratelimiter.LimitStatusDraining {
+			t.Fatalf("expected draining while a lease is in flight, got %+v", deleted)
+		}
+
+		second := mustMarshalReserve(t, ratelimiter.ReserveRequest{LeaseID: "L2", Requirements: []ratelimiter.Requirement{{Key: def.Key, Amount: 1}}})
+		_, body = doRequestJSON(t, http.MethodPost, srv.URL+"/v1/reserve", second)
+		var denied ratelimiter.ReserveResponse
+		if err := json.Unmarshal(body, &denied); err != nil {
+			t.Fatalf("parse reserve: %v", err)
+		}
+		if denied.Allowed || !strings.HasPrefix(denied.Error, "limit_draining:") {
+			t.Fatalf("expected draining denial, got %+v", denied)
+		}
+
+		complete, err := json.Marshal(ratelimiter.CompleteRequest{LeaseID: "L1"})
+		if err != nil {
+			t.Fatalf("marshal complete: %v", err)
+		}
+		if resp, _ := doRequestJSON(t, http.MethodPost, srv.URL+"/v1/complete", complete); resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200, got %d", resp.StatusCode)
+		}
+		if _, ok := reg.Get(def.Key); ok {
+			t.Fatalf("expected limit removed once its last lease completed")
+		}
+		loaded := registry.New()
+		if err := loaded.Load(path); err != nil {
+			t.Fatalf("load registry: %v", err)
+		}
+		if len(loaded.List()) != 0 {
+			t.Fatalf("expected saved registry to drop the limit, got %+v", loaded.List())
+		}
+	})
+}
+
+func TestHTTP_AdminApplyLimitsDiffAndDryRun(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		clock := testutil.NewFakeClock(time.Unix(0, 0))
+		reg := registry.New()
+		backend := memory.New(clock)
+		srv := httptest.NewServer(NewHandler(Config{Registry: reg, Backend: backend, Now: clock.Now}))
+		defer srv.Close()
+
+		for _, def := range []ratelimiter.LimitDefinition{
+			{Key: "keep", Kind: ratelimiter.KindRolling, Capacity: 10, WindowSeconds: 60},
+			{Key: "grow", Kind: ratelimiter.KindRolling, Capacity: 10, WindowSeconds: 60},
+			{Key: "shrink", Kind: ratelimiter.KindRolling, Capacity: 10, WindowSeconds: 60},
+			{Key: "stale", Kind: ratelimiter.KindRolling, Capacity: 10, WindowSeconds: 60},
+		} {
+			if resp, _ := doRequestJSON(t, http.MethodPut, srv.URL+"/v1/admin/limits", mustMarshal(t, def)); resp.StatusCode != http.StatusOK {
+				t.Fatalf("expected 200, got %d", resp.StatusCode)
+			}
+		}
+
+		apply := ratelimiter.ApplyLimitsRequest{DryRun: true, Limits: []ratelimiter.LimitDefinition{
+			{Key: "keep", Kind: ratelimiter.KindRolling, Capacity: 10, WindowSeconds: 60},
+			{Key: "grow", Kind: ratelimiter.KindRolling, Capacity: 20, WindowSeconds: 60},
+			{Key: "shrink", Kind: ratelimiter.KindRolling, Capacity: 5, WindowSeconds: 60},
+			{Key: "new", Kind: ratelimiter.KindConcurrency, Capacity: 1, TimeoutSeconds: 30},
+		}}
+		res := postApplyLimits(t, srv.URL, apply)
+		want := map[ratelimiter.LimitKey]ratelimiter.LimitChangeAction{
+			"grow":   ratelimiter.LimitChangeUpdate,
+			"new":    ratelimiter.LimitChangeCreate,
+			"shrink": ratelimiter.LimitChangeDecrease,
+			"stale":  ratelimiter.LimitChangeDelete,
+		}
+		if len(res.Changes) != len(want) || res.Unchanged != 1 {
+			t.Fatalf("unexpected plan: %+v", res)
+		}
+		for _, change := range res.Changes {
+			if want[change.Key] != change.Action {
+				t.Fatalf("expected %s for %s, got %s", want[change.Key], change.Key, change.Action)
+			}
+		}
+		if _, ok := reg.Get("new"); ok {
+			t.Fatalf("expected dry run to leave the registry untouched")
+		}
+
+		apply.DryRun = false
+		postApplyLimits(t, srv.URL, apply)
+		if _, ok := reg.Get("stale"); ok {
+			t.Fatalf("expected idle stale limit deleted")
+		}
+		if state, ok := reg.Get("shrink"); !ok || state.Status != ratelimiter.LimitStatusDecreasing || state.PendingDecreaseTo != 5 {
+			t.Fatalf("expected shrink decreasing to 5, got %+v", state)
+		}
+		if state, ok := reg.Get("new"); !ok || state.Definition.Overage != ratelimiter.OverageDebt {
+			t.Fatalf("expected new limit created with default overage, got %+v", state)
+		}
+
+		invalid := []byte(`{"limits":[{"key":"a","kind":"rolling","capacity":1,"window_seconds":60},{"key":"a","kind":"rolling","capacity":2,"window_seconds":60}]}`)
+		if resp, _ := doRequestJSON(t, http.MethodPost, srv.URL+"/v1/admin/limits:apply", invalid); resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("expected 400 for duplicate keys, got %d", resp.StatusCode)
+		}
+	})
+}
+
+type failingApplyBackend struct {
+	*memory.MemoryBackend
+	failKey ratelimiter.LimitKey
+}
+
+func (b failingApplyBackend) ApplyDefinition(ctx context.Context, def ratelimiter.LimitDefinition) error {
+	if def.Key == b.failKey {
+		return errors.New("backend unavailable")
+	}
+	return b.MemoryBackend.ApplyDefinition(ctx, def)
+}
+
+func TestHTTP_AdminApplyLimitsRollsBackOnFailure(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		clock := testutil.NewFakeClock(time.Unix(0, 0))
+		reg := registry.New()
+		path := filepath.Join(t.TempDir(), "limits.json")
+		mem := memory.New(clock)
+		srv := httptest.NewServer(NewHandler(Config{Registry: reg, Backend: failingApplyBackend{MemoryBackend: mem, failKey: "d-fail"}, RegistryPath: path, Now: clock.Now}))
+		defer srv.Close()
+
+		for _, def := range []ratelimiter.LimitDefinition{
+			{Key: "a-grow", Kind: ratelimiter.KindRolling, Capacity: 10, WindowSeconds: 60},
+			{Key: "c-stale", Kind: ratelimiter.KindRolling, Capacity: 10, WindowSeconds: 60},
+		} {
+			if resp, _ := doRequestJSON(t, http.MethodPut, srv.URL+"/v1/admin/limits", mustMarshal(t, def)); resp.StatusCode != http.StatusOK {
+				t.Fatalf("expected 200, got %d", resp.StatusCode)
+			}
+		}
+		before := reg.List()
+
+		payload, err := json.Marshal(ratelimiter.ApplyLimitsRequest{Limits: []ratelimiter.LimitDefinition{
+			{Key: "a-grow", Kind: ratelimiter.KindRolling, Capacity: 20, WindowSeconds: 60},
+			{Key: "b-new", Kind: ratelimiter.KindRolling, Capacity: 5, WindowSeconds: 60},
+			{Key: "d-fail", Kind: ratelimiter.KindRolling, Capacity: 5, WindowSeconds: 60},
+		}})
+		if err != nil {
+			t.Fatalf("marshal apply: %v", err)
+		}
+		resp, body := doRequestJSON(t, http.MethodPost, srv.URL+"/v1/admin/limits:apply", payload)
+		if resp.StatusCode != http.StatusInternalServerError {
+			t.Fatalf("expected 500, got %d: %s", resp.StatusCode, body)
+		}
+		var res ratelimiter.ApplyLimitsResponse
+		if err := json.Unmarshal(body, &res); err != nil {
+			t.Fatalf("parse apply: %v", err)
+		}
+		if res.OK || res.Error != "backend_error" || res.FailedKey != "d-fail" || len(res.Applied) != 0 {
+			t.Fatalf("expected a rolled back failure, got %+v", res)
+		}
+
+		if after := reg.List(); !reflect.DeepEqual(after, before) {
+			t.Fatalf("expected registry restored to %+v, got %+v", before, after)
+		}
+		loaded := registry.New()
+		if err := loaded.Load(path); err != nil {
+			t.Fatalf("load registry: %v", err)
+		}
+		if !reflect.DeepEqual(loaded.List(), before) {
+			t.Fatalf("expected saved registry to match memory, got %+v", loaded.List())
+		}
+		usage, err := mem.Usage(context.Background())
+		if err != nil {
+			t.Fatalf("usage: %v", err)
+		}
+		capacities := map[ratelimiter.LimitKey]uint64{}
+		for _, entry := range usage {
+			capacities[entry.Key] = entry.Capacity
+		}
+		if len(capacities) != 2 || capacities["a-grow"] != 10 || capacities["c-stale"] != 10 {
+			t.Fatalf("expected backend restored, got %+v", capacities)
+		}
+	})
+}
+
+func postApplyLimits(t *testing.T, baseURL string, req ratelimiter.ApplyLimitsRequest) ratelimiter.ApplyLimitsResponse {
+	t.Helper()
+	payload, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("marshal apply: %v", err)
+	}
+	resp, body := doRequestJSON(t, http.MethodPost, baseURL+"/v1/admin/limits:apply", payload)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+	}
+	var res ratelimiter.ApplyLimitsResponse
+	if err := json.Unmarshal(body, &res); err != nil {
+		t.Fatalf("parse apply: %v", err)
+	}
+	return res
+}
+
+func mustMarshalReserve(t *testing.T, req ratelimiter.ReserveRequest) []byte {
+	t.Helper()
+	payload, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("marshal reserve: %v", err)
+	}
+	return payload
+}
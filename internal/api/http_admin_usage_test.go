@@ -0,0 +1,83 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cogni/internal/backend/memory"
+	"cogni/internal/registry"
+	"cogni/internal/testutil"
+	"cogni/pkg/ratelimiter"
+)
+
+func TestHTTP_AdminLimitUsageAndLeases(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		clock := testutil.NewFakeClock(time.Unix(0, 0))
+		reg := registry.New()
+		backend := memory.New(clock)
+		for _, def := range []ratelimiter.LimitDefinition{
+			{Key: "org/tpm", Kind: ratelimiter.KindRolling, Capacity: 100, WindowSeconds: 60},
+			{Key: "org/conc", Kind: ratelimiter.KindConcurrency, Capacity: 2, TimeoutSeconds: 30},
+		} {
+			if err := backend.ApplyDefinition(testutil.Context(t, time.Second), def); err != nil {
+				t.Fatalf("apply definition: %v", err)
+			}
+			reg.Put(reg.NextState(def))
+		}
+		srv := httptest.NewServer(NewHandler(Config{Registry: reg, Backend: backend, Now: clock.Now}))
+		defer srv.Close()
+
+		reserve, err := json.Marshal(ratelimiter.ReserveRequest{LeaseID: "L1", Requirements: []ratelimiter.Requirement{{Key: "org/tpm", Amount: 40}, {Key: "org/conc", Amount: 1}}})
+		if err != nil {
+			t.Fatalf("marshal reserve: %v", err)
+		}
+		if resp, _ := doRequestJSON(t, http.MethodPost, srv.URL+"/v1/reserve", reserve); resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200, got %d", resp.StatusCode)
+		}
+
+		resp, body := doRequestJSON(t, http.MethodGet, srv.URL+"/v1/admin/limits/org/tpm/usage", nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+		}
+		var usage usageResponse
+		if err := json.Unmarshal(body, &usage); err != nil {
+			t.Fatalf("parse usage: %v", err)
+		}
+		if usage.Usage.Used != 40 || usage.Usage.Available != 60 || usage.Usage.InFlight != 40 || usage.Usage.Status != ratelimiter.LimitStatusActive {
+			t.Fatalf("unexpected usage: %+v", usage.Usage)
+		}
+		if len(usage.Holds) != 1 || usage.Holds[0].LeaseID != "L1" || usage.Holds[0].ExpiresAtUnixMs != 60_000 {
+			t.Fatalf("unexpected holds: %+v", usage.Holds)
+		}
+
+		resp, body = doRequestJSON(t, http.MethodGet, srv.URL+"/v1/admin/leases?key=org/conc", nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+		}
+		var leases leasesResponse
+		if err := json.Unmarshal(body, &leases); err != nil {
+			t.Fatalf("parse leases: %v", err)
+		}
+		if len(leases.Leases) != 1 || len(leases.Leases[0].Holds) != 2 {
+			t.Fatalf("unexpected leases: %+v", leases.Leases)
+		}
+
+		if resp, _ := doRequestJSON(t, http.MethodGet, srv.URL+"/v1/admin/limits/missing/usage", nil); resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("expected 404 for unknown key, got %d", resp.StatusCode)
+		}
+	})
+}
+
+func TestHTTP_AdminUsageUnsupportedBackend(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		srv := httptest.NewServer(NewHandler(Config{Registry: registry.New(), Backend: stubBackend{}}))
+		defer srv.Close()
+
+		if resp, _ := doRequestJSON(t, http.MethodGet, srv.URL+"/v1/admin/leases", nil); resp.StatusCode != http.StatusNotImplemented {
+			t.Fatalf("expected 501, got %d", resp.StatusCode)
+		}
+	})
+}
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cogni/internal/backend/memory"
+	"cogni/internal/registry"
+	"cogni/internal/testutil"
+	"cogni/pkg/ratelimiter"
+)
+
+func TestHTTP_AuthScopesAndTenants(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		clock := testutil.NewFakeClock(time.Unix(0, 0))
+		reg := registry.New()
+		backend := memory.New(clock)
+		for _, key := range []ratelimiter.LimitKey{"global:llm:p:m:rpm", "tenant:acme:llm:daily_tokens", "tenant:other:llm:daily_tokens"} {
+			def := ratelimiter.LimitDefinition{Key: key, Kind: ratelimiter.KindRolling, Capacity: 100, WindowSeconds: 60}
+			if err := backend.ApplyDefinition(testutil.Context(t, time.Second), def); err != nil {
+				t.Fatalf("apply definition: %v", err)
+			}
+			reg.Put(reg.NextState(def))
+		}
+		auth, err := NewStaticAuthenticator([]Credential{
+			{Principal: Principal{Name: "ops", Scope: ScopeAdmin}, Token: "admin-token"},
+			{Principal: Principal{Name: "acme", Scope: ScopeClient, Tenant: "acme"}, Token: "acme-token"},
+		})
+		if err != nil {
+			t.Fatalf("build authenticator: %v", err)
+		}
+		srv := httptest.NewServer(NewHandler(Config{Registry: reg, Backend: backend, Now: clock.Now, Auth: auth}))
+		defer srv.Close()
+
+		if resp, _ := doAuthRequest(t, http.MethodGet, srv.URL+"/v1/admin/limits", "", nil); resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("expected 401 without a token, got %d", resp.StatusCode)
+		}
+		if resp, _ := doAuthRequest(t, http.MethodGet, srv.URL+"/v1/admin/limits", "wrong", nil); resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("expected 401 for an unknown token, got %d", resp.StatusCode)
+		}
+		if resp, _ := doAuthRequest(t, http.MethodGet, srv.URL+"/v1/admin/limits", "acme-token", nil); resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("expected 403 for a client token on admin, got %d", resp.StatusCode)
+		}
+		if resp, _ := doAuthRequest(t, http.MethodGet, srv.URL+"/v1/admin/limits", "admin-token", nil); resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200 for the admin token, got %d", resp.StatusCode)
+		}
+
+		own := ratelimiter.ReserveRequest{LeaseID: "L1", Requirements: []ratelimiter.Requirement{
+			{Key: "global:llm:p:m:rpm", Amount: 1},
+			{Key: "tenant:acme:llm:daily_tokens", Amount: 10},
+		}}
+		if res := reserveWithToken(t, srv.URL, "acme-token", own); !res.Allowed {
+			t.Fatalf("expected own tenant and global keys allowed, got %+v", res)
+		}
+		other := ratelimiter.ReserveRequest{LeaseID: "L2", Requirements: []ratelimiter.Requirement{
+			{Key: "tenant:other:llm:daily_tokens", Amount: 10},
+		}}
+		if res := reserveWithToken(t, srv.URL, "acme-token", other); res.Allowed || res.Error != "forbidden_limit_key:tenant:other:llm:daily_tokens" {
+			t.Fatalf("expected another tenant's key forbidden, got %+v", res)
+		}
+		other.LeaseID = "L3"
+		if res := reserveWithToken(t, srv.URL, "admin-token", other); !res.Allowed {
+			t.Fatalf("expected admin to reserve any key, got %+v", res)
+		}
+	})
+}
+
+func TestHTTP_AuthCompleteRejectsOtherTenantsLease(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		srv, backend := newTenantAuthServer(t)
+		defer srv.Close()
+
+		own := ratelimiter.ReserveRequest{LeaseID: "L1", Requirements: []ratelimiter.Requirement{
+			{Key: "global:llm:p:m:rpm", Amount: 1},
+			{Key: "tenant:acme:llm:daily_tokens", Amount: 10},
+		}}
+		if res := reserveWithToken(t, srv.URL, "acme-token", own); !res.Allowed {
+			t.Fatalf("expected reserve allowed, got %+v", res)
+		}
+
+		complete := ratelimiter.CompleteRequest{LeaseID: "L1", Actuals: []ratelimiter.Actual{{Key: "global:llm:p:m:rpm", ActualAmount: 1}}}
+		payload, err := json.Marshal(complete)
+		if err != nil {
+			t.Fatalf("marshal complete: %v", err)
+		}
+		_, body := doAuthRequest(t, http.MethodPost, srv.URL+"/v1/complete", "other-token", payload)
+		var res ratelimiter.CompleteResponse
+		if err := json.Unmarshal([]byte(body), &res); err != nil {
+			t.Fatalf("parse complete: %v", err)
+		}
+		if res.Ok || res.Error != "forbidden_lease" {
+			t.Fatalf("expected forbidden_lease, got %+v", res)
+		}
+		batch, err := json.Marshal(ratelimiter.BatchCompleteRequest{Requests: []ratelimiter.CompleteRequest{complete}})
+		if err != nil {
+			t.Fatalf("marshal batch: %v", err)
+		}
+		_, body = doAuthRequest(t, http.MethodPost, srv.URL+"/v1/complete/batch", "other-token", batch)
+		var batchRes ratelimiter.BatchCompleteResponse
+		if err := json.Unmarshal([]byte(body), &batchRes); err != nil {
+			t.Fatalf("parse batch complete: %v", err)
+		}
+		if len(batchRes.Results) != 1 || batchRes.Results[0].Ok || batchRes.Results[0].Error != "forbidden_lease" {
+			t.Fatalf("expected forbidden_lease in batch, got %+v", batchRes)
+		}
+		leases, err := backend.Leases(testutil.Context(t, time.Second))
+		if err != nil {
+			t.Fatalf("leases: %v", err)
+		}
+		if len(leases) != 1 {
+			t.Fatalf("expected the lease to stay held, got %+v", leases)
+		}
+
+		_, body = doAuthRequest(t, http.MethodPost, srv.URL+"/v1/complete", "acme-token", payload)
+		res = ratelimiter.CompleteResponse{}
+		if err := json.Unmarshal([]byte(body), &res); err != nil {
+			t.Fatalf("parse complete: %v", err)
+		}
+		if !res.Ok {
+			t.Fatalf("expected the owner to complete, got %+v", res)
+		}
+	})
+}
+
+func TestHTTP_AuthRequiresTenantDailyKeyForLLMReserve(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		srv, _ := newTenantAuthServer(t)
+		defer srv.Close()
+
+		req := ratelimiter.ReserveRequest{LeaseID: "L1", Requirements: []ratelimiter.Requirement{
+			{Key: "global:llm:p:m:rpm", Amount: 1},
+		}}
+		if res := reserveWithToken(t, srv.URL, "acme-token", req); res.Allowed || res.Error != "missing_limit_key:tenant:acme:llm:daily_tokens" {
+			t.Fatalf("expected missing daily key denial, got %+v", res)
+		}
+		if res := reserveWithToken(t, srv.URL, "admin-token", req); !res.Allowed {
+			t.Fatalf("expected unscoped admin reserve allowed, got %+v", res)
+		}
+	})
+}
+
+func TestStaticAuthenticatorRejectsInvalidCredentials(t *testing.T) {
+	cases := []Credential{
+		{Principal: Principal{Name: "", Scope: ScopeAdmin}, Token: "t"},
+		{Principal: Principal{Name: "a", Scope: "root"}, Token: "t"},
+		{Principal: Principal{Name: "a", Scope: ScopeAdmin, Tenant: "acme"}, Token: "t"},
+		{Principal: Principal{Name: "a", Scope: ScopeClient}},
+	}
+	for _, cred := range cases {
+		if _, err := NewStaticAuthenticator([]Credential{cred}); err == nil {
+			t.Fatalf("expected error for %+v", cred)
+		}
+	}
+}
+
+func newTenantAuthServer(t *testing.T) (*httptest.Server, *memory.MemoryBackend) {
+	t.Helper()
+	clock := testutil.NewFakeClock(time.Unix(0, 0))
+	reg := registry.New()
+	backend := memory.New(clock)
+	for _, key := range []ratelimiter.LimitKey{"global:llm:p:m:rpm", "tenant:acme:llm:daily_tokens", "tenant:other:llm:daily_tokens"} {
+		def := ratelimiter.LimitDefinition{Key: key, Kind: ratelimiter.KindRolling, Capacity: 100, WindowSeconds: 60}
+		if err := backend.ApplyDefinition(testutil.Context(t, time.Second), def); err != nil {
+			t.Fatalf("apply definition: %v", err)
+		}
+		reg.Put(reg.NextState(def))
+	}
+	auth, err := NewStaticAuthenticator([]Credential{
+		{Principal: Principal{Name: "ops", Scope: ScopeAdmin}, Token: "admin-token"},
+		{Principal: Principal{Name: "acme", Scope: ScopeClient, Tenant: "acme"}, Token: "acme-token"},
+		{Principal: Principal{Name: "other", Scope: ScopeClient, Tenant: "other"}, Token: "other-token"},
+	})
+	if err != nil {
+		t.Fatalf("build authenticator: %v", err)
+	}
+	return httptest.NewServer(NewHandler(Config{Registry: reg, Backend: backend, Now: clock.Now, Auth: auth})), backend
+}
+
+func reserveWithToken(t *testing.T, baseURL, token string, req ratelimiter.ReserveRequest) ratelimiter.ReserveResponse {
+	t.Helper()
+	resp, body := doAuthRequest(t, http.MethodPost, baseURL+"/v1/reserve", token, mustMarshalReserve(t, req))
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+	}
+	var res ratelimiter.ReserveResponse
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatalf("parse reserve: %v", err)
+	}
+	return res
+}
+
+func doAuthRequest(t *testing.T, method, url, token string, payload []byte) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(testutil.Context(t, 2*time.Second), method, url, bytes.NewReader(payload))
+	if err != nil {
+		t.Fatalf("build request: %v", err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("do request: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read response: %v", err)
+	}
+	return resp, string(body)
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cogni/internal/adaptive"
+	"cogni/internal/backend/memory"
+	"cogni/internal/registry"
+	"cogni/internal/testutil"
+	"cogni/pkg/ratelimiter"
+)
+
+func TestHTTP_FeedbackBacksOffAndShowsInUsage(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		clock := testutil.NewFakeClock(time.Unix(0, 0))
+		reg := registry.New()
+		backend := memory.New(clock)
+		def := ratelimiter.LimitDefinition{Key: "org/rpm", Kind: ratelimiter.KindRolling, Capacity: 100, WindowSeconds: 60}
+		if err := backend.ApplyDefinition(testutil.Context(t, time.Second), def); err != nil {
+			t.Fatalf("apply definition: %v", err)
+		}
+		reg.Put(reg.NextState(def))
+		controller := adaptive.New(backend, adaptive.DefaultConfig(), clock.Now)
+		srv := httptest.NewServer(NewHandler(Config{Registry: reg, Backend: backend, Now: clock.Now, Adaptive: controller}))
+		defer srv.Close()
+
+		feedback, err := json.Marshal(ratelimiter.FeedbackRequest{Feedback: []ratelimiter.LimitFeedback{{Key: "org/rpm", Throttled: true, RetryAfterMs: 1000}}})
+		if err != nil {
+			t.Fatalf("marshal feedback: %v", err)
+		}
+		resp, body := doRequestJSON(t, http.MethodPost, srv.URL+"/v1/feedback", feedback)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+		}
+		var fbRes ratelimiter.FeedbackResponse
+		if err := json.Unmarshal(body, &fbRes); err != nil {
+			t.Fatalf("parse feedback response: %v", err)
+		}
+		if len(fbRes.Adjustments) != 1 || fbRes.Adjustments[0].EffectiveCapacity != 50 {
+			t.Fatalf("unexpected adjustments: %+v", fbRes.Adjustments)
+		}
+
+		resp, body = doRequestJSON(t, http.MethodGet, srv.URL+"/v1/admin/limits/org/rpm/usage", nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+		}
+		var usage usageResponse
+		if err := json.Unmarshal(body, &usage); err != nil {
+			t.Fatalf("parse usage: %v", err)
+		}
+		if usage.Usage.Capacity != 100 || usage.Usage.EffectiveCapacity != 50 || usage.Usage.Available != 50 {
+			t.Fatalf("unexpected usage: %+v", usage.Usage)
+		}
+		if usage.Adjustment == nil || usage.Adjustment.Throttles != 1 {
+			t.Fatalf("expected adjustment in usage, got %+v", usage.Adjustment)
+		}
+
+		reserve, err := json.Marshal(ratelimiter.ReserveRequest{LeaseID: "L1", Requirements: []ratelimiter.Requirement{{Key: "org/rpm", Amount: 60}}})
+		if err != nil {
+			t.Fatalf("marshal reserve: %v", err)
+		}
+		resp, body = doRequestJSON(t, http.MethodPost, srv.URL+"/v1/reserve", reserve)
+		var reserveRes ratelimiter.ReserveResponse
+		if err := json.Unmarshal(body, &reserveRes); err != nil {
+			t.Fatalf("parse reserve: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK || reserveRes.Allowed {
+			t.Fatalf("expected reserve above effective capacity to be denied, got %d %+v", resp.StatusCode, reserveRes)
+		}
+
+		if resp, body := doRequestJSON(t, http.MethodDelete, srv.URL+"/v1/admin/adjustments/org/rpm", nil); resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200 reset, got %d: %s", resp.StatusCode, body)
+		}
+		resp, body = doRequestJSON(t, http.MethodGet, srv.URL+"/v1/admin/adjustments", nil)
+		var list ratelimiter.AdjustmentsResponse
+		if err := json.Unmarshal(body, &list); err != nil {
+			t.Fatalf("parse adjustments: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK || len(list.Adjustments) != 0 {
+			t.Fatalf("expected no adjustments after reset, got %d %+v", resp.StatusCode, list)
+		}
+	})
+}
+
+func TestHTTP_FeedbackWithoutAdaptive(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		srv := httptest.NewServer(NewHandler(Config{Registry: registry.New(), Backend: memory.New(nil)}))
+		defer srv.Close()
+
+		body := []byte(`{"feedback":[{"key":"org/rpm","throttled":true}]}`)
+		if resp, _ := doRequestJSON(t, http.MethodPost, srv.URL+"/v1/feedback", body); resp.StatusCode != http.StatusNotImplemented {
+			t.Fatalf("expected 501, got %d", resp.StatusCode)
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"cogni/internal/backend/memory"
+	"cogni/internal/metrics"
+	"cogni/internal/registry"
+	"cogni/internal/testutil"
+	"cogni/pkg/ratelimiter"
+)
+
+func TestHTTP_MetricsCountBatchItemsByOutcome(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		clock := testutil.NewFakeClock(time.Unix(0, 0))
+		reg := registry.New()
+		backend := memory.New(clock)
+		def := ratelimiter.LimitDefinition{Key: "k1", Kind: ratelimiter.KindRolling, Capacity: 1, WindowSeconds: 60}
+		if err := backend.ApplyDefinition(testutil.Context(t, time.Second), def); err != nil {
+			t.Fatalf("apply definition: %v", err)
+		}
+		reg.Put(reg.NextState(def))
+
+		metricsRegistry := metrics.NewRegistry()
+		srv := httptest.NewServer(NewHandler(Config{Registry: reg, Backend: backend, Now: clock.Now, Metrics: metrics.NewLimiter(metricsRegistry)}))
+		defer srv.Close()
+
+		batch := ratelimiter.BatchReserveRequest{Requests: []ratelimiter.ReserveRequest{
+			{LeaseID: "A", Requirements: []ratelimiter.Requirement{{Key: "k1", Amount: 1}}},
+			{LeaseID: "", Requirements: []ratelimiter.Requirement{{Key: "k1", Amount: 1}}},
+			{LeaseID: "B", Requirements: []ratelimiter.Requirement{{Key: "k1", Amount: 1}}},
+			{LeaseID: "C", Requirements: []ratelimiter.Requirement{{Key: "missing", Amount: 1}}},
+		}}
+		payload, err := json.Marshal(batch)
+		if err != nil {
+			t.Fatalf("marshal batch: %v", err)
+		}
+		if resp, _ := doRequestJSON(t, http.MethodPost, srv.URL+"/v1/reserve/batch", payload); resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200, got %d", resp.StatusCode)
+		}
+		complete, err := json.Marshal(ratelimiter.CompleteRequest{LeaseID: "A"})
+		if err != nil {
+			t.Fatalf("marshal complete: %v", err)
+		}
+		if resp, _ := doRequestJSON(t, http.MethodPost, srv.URL+"/v1/complete", complete); resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200, got %d", resp.StatusCode)
+		}
+
+		var text strings.Builder
+		metricsRegistry.WriteText(&text)
+		for _, want := range []string{
+			`ratelimiterd_reserve_requests_total{outcome="allowed"} 1`,
+			`ratelimiterd_reserve_requests_total{outcome="denied"} 1`,
+			`ratelimiterd_reserve_requests_total{outcome="invalid_request"} 1`,
+			`ratelimiterd_reserve_requests_total{outcome="unknown_limit_key"} 1`,
+			`ratelimiterd_complete_requests_total{outcome="ok"} 1`,
+			`ratelimiterd_batch_size_count{endpoint="reserve_batch"} 1`,
+			`ratelimiterd_request_duration_seconds_count{endpoint="reserve_batch"} 1`,
+			`ratelimiterd_request_duration_seconds_count{endpoint="complete"} 1`,
+		} {
+			if !strings.Contains(text.String(), want) {
+				t.Fatalf("expected %q in:\n%s", want, text.String())
+			}
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cogni/internal/backend"
+	"cogni/internal/backend/memory"
+	"cogni/internal/registry"
+	"cogni/internal/testutil"
+	"cogni/pkg/ratelimiter"
+)
+
+func TestHTTP_ReservePriority(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		clock := testutil.NewFakeClock(time.Unix(0, 0))
+		reg := registry.New()
+		backend := memory.New(clock)
+		def := ratelimiter.LimitDefinition{
+			Key:           "k1",
+			Kind:          ratelimiter.KindRolling,
+			Capacity:      10,
+			WindowSeconds: 60,
+			Unit:          "requests",
+			Overage:       ratelimiter.OverageDebt,
+		}
+		if err := backend.ApplyDefinition(testutil.Context(t, time.Second), def); err != nil {
+			t.Fatalf("apply definition: %v", err)
+		}
+		reg.Put(reg.NextState(def))
+
+		srv := httptest.NewServer(NewHandler(Config{Registry: reg, Backend: backend, Now: clock.Now}))
+		defer srv.Close()
+
+		resp, _ := doRequestJSON(t, http.MethodPost, srv.URL+"/v1/reserve",
+			[]byte(`{"lease_id":"A","job_id":"j","requirements":[{"key":"k1","amount":1}],"priority":"urgent"}`))
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("expected 400 for unknown priority, got %d", resp.StatusCode)
+		}
+
+		for _, lease := range []string{"L1", "L2"} {
+			resp, body := doRequestJSON(t, http.MethodPost, srv.URL+"/v1/reserve",
+				[]byte(`{"lease_id":"`+lease+`","job_id":"j","requirements":[{"key":"k1","amount":5}],"priority":"low"}`))
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+			}
+			parsed := decodeReserveResponse(t, body)
+			if allowed := lease == "L1"; parsed.Allowed != allowed {
+				t.Fatalf("lease %s: expected allowed=%v, got %+v", lease, allowed, parsed)
+			}
+		}
+		resp, body := doRequestJSON(t, http.MethodPost, srv.URL+"/v1/reserve",
+			[]byte(`{"lease_id":"H1","job_id":"j","requirements":[{"key":"k1","amount":5}],"priority":"high"}`))
+		if resp.StatusCode != http.StatusOK || !decodeReserveResponse(t, body).Allowed {
+			t.Fatalf("expected high reservation allowed, got %d: %s", resp.StatusCode, body)
+		}
+	})
+}
+
+func TestHTTP_ReserveRejectsUnsupportedPriority(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		reg := registry.New()
+		mem := memory.New(nil)
+		def := ratelimiter.LimitDefinition{
+			Key:           "k1",
+			Kind:          ratelimiter.KindRolling,
+			Capacity:      10,
+			WindowSeconds: 60,
+			Unit:          "requests",
+			Overage:       ratelimiter.OverageDebt,
+		}
+		if err := mem.ApplyDefinition(testutil.Context(t, time.Second), def); err != nil {
+			t.Fatalf("apply definition: %v", err)
+		}
+		reg.Put(reg.NextState(def))
+
+		srv := httptest.NewServer(NewHandler(Config{Registry: reg, Backend: plainBackend{mem}}))
+		defer srv.Close()
+
+		resp, body := doRequestJSON(t, http.MethodPost, srv.URL+"/v1/reserve",
+			[]byte(`{"lease_id":"L1","job_id":"j","requirements":[{"key":"k1","amount":1}],"priority":"low"}`))
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+		}
+		if parsed := decodeReserveResponse(t, body); parsed.Allowed || parsed.Error != "unsupported_priority:low" {
+			t.Fatalf("expected unsupported_priority denial, got %+v", parsed)
+		}
+		resp, body = doRequestJSON(t, http.MethodPost, srv.URL+"/v1/reserve",
+			[]byte(`{"lease_id":"L2","job_id":"j","requirements":[{"key":"k1","amount":1}],"priority":"normal"}`))
+		if resp.StatusCode != http.StatusOK || !decodeReserveResponse(t, body).Allowed {
+			t.Fatalf("expected normal reservation allowed, got %d: %s", resp.StatusCode, body)
+		}
+	})
+}
+
+// plainBackend hides every optional capability of the wrapped backend.
+type plainBackend struct {
+	backend.Backend
+}
+
+func decodeReserveResponse(t *testing.T, body []byte) ratelimiter.ReserveResponse {
+	t.Helper()
+	var parsed ratelimiter.ReserveResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		t.Fatalf("parse response: %v", err)
+	}
+	return parsed
+}
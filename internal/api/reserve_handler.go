@@ -21,20 +21,25 @@ func (h *handler) handleReserve(w http.ResponseWriter, r *http.Request) {
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
 	if err := decoder.Decode(&req); err != nil {
+		h.metrics.ObserveReserve(invalidReserve, nil)
 		writeError(w, http.StatusBadRequest, "invalid_request")
 		return
 	}
-	result := h.validateReserve(req)
+	result := h.validateReserve(r.Context(), req)
 	switch result.status {
 	case validationInvalid:
+		h.metrics.ObserveReserve(invalidReserve, nil)
 		writeError(w, http.StatusBadRequest, "invalid_request")
 		return
 	case validationDenied:
+		h.metrics.ObserveReserve(result.response, nil)
 		writeReserveResponse(w, http.StatusOK, result.response)
 		return
 	}
 
+	req.Tenant = principalTenant(r.Context())
 	res, err := h.backend.Reserve(r.Context(), req, h.now())
+	h.metrics.ObserveReserve(res, err)
 	if err != nil {
 		writeReserveResponse(w, http.StatusOK, ratelimiter.ReserveResponse{Allowed: false, Error: "backend_error"})
 		return
@@ -62,16 +67,19 @@ func (h *handler) handleBatchReserve(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid_request")
 		return
 	}
+	h.metrics.ObserveBatch("reserve_batch", len(req.Requests))
 
 	results := make([]ratelimiter.BatchReserveResult, 0, len(req.Requests))
 	now := h.now()
 	for _, item := range req.Requests {
-		validation := h.validateReserve(item)
+		validation := h.validateReserve(r.Context(), item)
 		switch validation.status {
 		case validationInvalid:
+			h.metrics.ObserveReserve(invalidReserve, nil)
 			results = append(results, ratelimiter.BatchReserveResult{Allowed: false, Error: invalidRequestError})
 			continue
 		case validationDenied:
+			h.metrics.ObserveReserve(validation.response, nil)
 			results = append(results, ratelimiter.BatchReserveResult{
 				Allowed:        validation.response.Allowed,
 				RetryAfterMs:   validation.response.RetryAfterMs,
@@ -81,7 +89,9 @@ func (h *handler) handleBatchReserve(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 
+		item.Tenant = principalTenant(r.Context())
 		res, err := h.backend.Reserve(r.Context(), item, now)
+		h.metrics.ObserveReserve(res, err)
 		if err != nil {
 			results = append(results, ratelimiter.BatchReserveResult{Allowed: false, Error: "backend_error"})
 			continue
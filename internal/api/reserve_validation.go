@@ -1,6 +1,11 @@
 package api
 
-import "cogni/pkg/ratelimiter"
+import (
+	"context"
+
+	"cogni/internal/backend"
+	"cogni/pkg/ratelimiter"
+)
 
 const (
 	maxRequirementsPerReserve = 32
@@ -9,6 +14,13 @@ const (
 	decreaseRetryMs           = 10000
 )
 
+// invalidReserve and invalidComplete stand in for rejected requests when
+// counting outcomes.
+var (
+	invalidReserve  = ratelimiter.ReserveResponse{Error: invalidRequestError}
+	invalidComplete = ratelimiter.CompleteResponse{Error: invalidRequestError}
+)
+
 type validationStatus int
 
 const (
@@ -22,13 +34,38 @@ type reserveValidationResult struct {
 	response ratelimiter.ReserveResponse
 }
 
-func (h *handler) validateReserve(req ratelimiter.ReserveRequest) reserveValidationResult {
+func (h *handler) validateReserve(ctx context.Context, req ratelimiter.ReserveRequest) reserveValidationResult {
 	if req.LeaseID == "" {
 		return reserveValidationResult{status: validationInvalid}
 	}
 	if len(req.Requirements) == 0 || len(req.Requirements) > maxRequirementsPerReserve {
 		return reserveValidationResult{status: validationInvalid}
 	}
+	if !req.Priority.Valid() {
+		return reserveValidationResult{status: validationInvalid}
+	}
+	if priority := req.Priority.Normalize(); priority != ratelimiter.PriorityNormal {
+		// Fail loudly rather than let a backend silently treat the
+		// priority as normal.
+		if _, ok := h.backend.(backend.PriorityAdmitter); !ok {
+			return reserveValidationResult{
+				status:   validationDenied,
+				response: ratelimiter.ReserveResponse{Allowed: false, Error: "unsupported_priority:" + string(priority)},
+			}
+		}
+	}
+	if key, ok := forbiddenKey(ctx, requirementKeys(req.Requirements)); ok {
+		return reserveValidationResult{
+			status:   validationDenied,
+			response: ratelimiter.ReserveResponse{Allowed: false, Error: forbiddenKeyError(key)},
+		}
+	}
+	if key, ok := missingTenantKey(ctx, requirementKeys(req.Requirements)); ok {
+		return reserveValidationResult{
+			status:   validationDenied,
+			response: ratelimiter.ReserveResponse{Allowed: false, Error: "missing_limit_key:" + string(key)},
+		}
+	}
 	for _, r := range req.Requirements {
 		if r.Key == "" || r.Amount == 0 {
 			return reserveValidationResult{status: validationInvalid}
@@ -53,6 +90,16 @@ func (h *handler) validateReserve(req ratelimiter.ReserveRequest) reserveValidat
 				},
 			}
 		}
+		if state.Status == ratelimiter.LimitStatusDraining {
+			return reserveValidationResult{
+				status: validationDenied,
+				response: ratelimiter.ReserveResponse{
+					Allowed:      false,
+					RetryAfterMs: decreaseRetryMs,
+					Error:        "limit_draining:" + string(r.Key),
+				},
+			}
+		}
 	}
 	return reserveValidationResult{status: validationOK}
 }
@@ -27,6 +27,22 @@ func writeLimitsResponse(w http.ResponseWriter, status int, payload limitsRespon
 	writeBytes(w, status, mustJSONLimits(payload))
 }
 
+func writeUsageResponse(w http.ResponseWriter, status int, payload usageResponse) {
+	writeBytes(w, status, mustJSONUsage(payload))
+}
+
+func writeLeasesResponse(w http.ResponseWriter, status int, payload leasesResponse) {
+	writeBytes(w, status, mustJSONLeases(payload))
+}
+
+func writeDeleteLimitResponse(w http.ResponseWriter, status int, payload ratelimiter.DeleteLimitResponse) {
+	writeBytes(w, status, mustJSONDeleteLimit(payload))
+}
+
+func writeApplyLimitsResponse(w http.ResponseWriter, status int, payload ratelimiter.ApplyLimitsResponse) {
+	writeBytes(w, status, mustJSONApplyLimits(payload))
+}
+
 func writeAdminPutResponse(w http.ResponseWriter, status int, payload adminPutResponse) {
 	writeBytes(w, status, mustJSONAdminPut(payload))
 }
@@ -47,6 +63,14 @@ func writeBatchCompleteResponse(w http.ResponseWriter, status int, payload ratel
 	writeBytes(w, status, mustJSONBatchComplete(payload))
 }
 
+func writeFeedbackResponse(w http.ResponseWriter, status int, payload ratelimiter.FeedbackResponse) {
+	writeBytes(w, status, mustJSONFeedback(payload))
+}
+
+func writeAdjustmentsResponse(w http.ResponseWriter, status int, payload ratelimiter.AdjustmentsResponse) {
+	writeBytes(w, status, mustJSONAdjustments(payload))
+}
+
 func writeBytes(w http.ResponseWriter, status int, payload []byte) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -68,6 +92,26 @@ func mustJSONLimits(payload limitsResponse) []byte {
 	return data
 }
 
+func mustJSONUsage(payload usageResponse) []byte {
+	data, _ := json.Marshal(payload)
+	return data
+}
+
+func mustJSONLeases(payload leasesResponse) []byte {
+	data, _ := json.Marshal(payload)
+	return data
+}
+
+func mustJSONDeleteLimit(payload ratelimiter.DeleteLimitResponse) []byte {
+	data, _ := json.Marshal(payload)
+	return data
+}
+
+func mustJSONApplyLimits(payload ratelimiter.ApplyLimitsResponse) []byte {
+	data, _ := json.Marshal(payload)
+	return data
+}
+
 func mustJSONAdminPut(payload adminPutResponse) []byte {
 	data, _ := json.Marshal(payload)
 	return data
@@ -92,3 +136,13 @@ func mustJSONBatchComplete(payload ratelimiter.BatchCompleteResponse) []byte {
 	data, _ := json.Marshal(payload)
 	return data
 }
+
+func mustJSONFeedback(payload ratelimiter.FeedbackResponse) []byte {
+	data, _ := json.Marshal(payload)
+	return data
+}
+
+func mustJSONAdjustments(payload ratelimiter.AdjustmentsResponse) []byte {
+	data, _ := json.Marshal(payload)
+	return data
+}
@@ -13,3 +13,55 @@ type Backend interface {
 	Reserve(ctx context.Context, req ratelimiter.ReserveRequest, now time.Time) (ratelimiter.ReserveResponse, error)
 	Complete(ctx context.Context, req ratelimiter.CompleteRequest) (ratelimiter.CompleteResponse, error)
 }
+
+// LimitUsage is a point-in-time view of one limit's consumption.
+type LimitUsage = ratelimiter.LimitUsage
+
+// LeaseInfo describes a lease that has reserved capacity but not completed.
+type LeaseInfo = ratelimiter.LeaseInfo
+
+// LeaseHold is the capacity a lease holds on one limit.
+type LeaseHold = ratelimiter.LeaseHold
+
+// UsageReporter is implemented by backends that can report per-limit usage.
+type UsageReporter interface {
+	Usage(ctx context.Context) ([]LimitUsage, error)
+}
+
+// LeaseReporter is implemented by backends that can list the leases currently
+// holding capacity.
+type LeaseReporter interface {
+	Leases(ctx context.Context) ([]LeaseInfo, error)
+}
+
+// LimitRemover is implemented by backends that can delete limits. Removal
+// drains first: the limit is marked draining, new reservations are denied, and
+// the limit is dropped once no uncompleted lease holds it.
+type LimitRemover interface {
+	// RemoveDefinition starts draining key and reports whether it was
+	// removed immediately.
+	RemoveDefinition(ctx context.Context, key ratelimiter.LimitKey) (bool, error)
+}
+
+// StateApplier is implemented by backends that can load a limit state
+// verbatim, including a pending decrease or drain.
+type StateApplier interface {
+	ApplyState(state ratelimiter.LimitState) error
+}
+
+// CapacityAdjuster is implemented by backends that can enforce a temporary
+// effective capacity below a limit's defined capacity, without the
+// decreasing flow. Adaptive limits use it to back off on provider throttling.
+type CapacityAdjuster interface {
+	// DefinedCapacity returns key's configured capacity.
+	DefinedCapacity(ctx context.Context, key ratelimiter.LimitKey) (uint64, bool, error)
+	// SetEffectiveCapacity caps new reservations on key; zero clears the cap.
+	SetEffectiveCapacity(ctx context.Context, key ratelimiter.LimitKey, capacity uint64) error
+}
+
+// PriorityAdmitter is implemented by backends that admit reservations by
+// priority, keeping headroom free for higher priorities. Backends without it
+// admit every priority alike.
+type PriorityAdmitter interface {
+	SetPriorityHeadroom(low, normal float64) error
+}
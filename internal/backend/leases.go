@@ -0,0 +1,66 @@
+package backend
+
+import (
+	"sort"
+
+	"cogni/pkg/ratelimiter"
+)
+
+// ActiveLease builds the view of a lease from its reserved amounts, keeping
+// only holds that have not lapsed at nowUnixMs. It reports false when no hold
+// remains.
+func ActiveLease(leaseID string, reservedAtUnixMs int64, amounts map[ratelimiter.LimitKey]uint64, defs func(ratelimiter.LimitKey) (ratelimiter.LimitDefinition, bool), nowUnixMs int64) (LeaseInfo, bool) {
+	lease := LeaseInfo{LeaseID: leaseID, ReservedAtUnixMs: reservedAtUnixMs}
+	for key, amount := range amounts {
+		def, ok := defs(key)
+		if !ok {
+			continue
+		}
+		expiresAt := holdExpiry(def, reservedAtUnixMs)
+		if expiresAt <= nowUnixMs {
+			continue
+		}
+		lease.Holds = append(lease.Holds, LeaseHold{Key: key, Kind: def.Kind, Amount: amount, ExpiresAtUnixMs: expiresAt})
+	}
+	if len(lease.Holds) == 0 {
+		return LeaseInfo{}, false
+	}
+	sort.Slice(lease.Holds, func(i, j int) bool { return lease.Holds[i].Key < lease.Holds[j].Key })
+	return lease, true
+}
+
+// SortLeases orders leases by reservation time, then lease ID.
+func SortLeases(leases []LeaseInfo) {
+	sort.Slice(leases, func(i, j int) bool {
+		if leases[i].ReservedAtUnixMs != leases[j].ReservedAtUnixMs {
+			return leases[i].ReservedAtUnixMs < leases[j].ReservedAtUnixMs
+		}
+		return leases[i].LeaseID < leases[j].LeaseID
+	})
+}
+
+// InFlightByKey sums the holds of leases per limit, counting one slot per
+// concurrency hold.
+func InFlightByKey(leases []LeaseInfo) map[ratelimiter.LimitKey]uint64 {
+	inFlight := map[ratelimiter.LimitKey]uint64{}
+	for _, lease := range leases {
+		for _, hold := range lease.Holds {
+			amount := hold.Amount
+			if hold.Kind == ratelimiter.KindConcurrency {
+				amount = 1
+			}
+			inFlight[hold.Key] += amount
+		}
+	}
+	return inFlight
+}
+
+// holdExpiry returns when a hold lapses: after the rolling window, or after
+// the concurrency timeout.
+func holdExpiry(def ratelimiter.LimitDefinition, reservedAtUnixMs int64) int64 {
+	seconds := def.WindowSeconds
+	if def.Kind == ratelimiter.KindConcurrency {
+		seconds = def.TimeoutSeconds
+	}
+	return reservedAtUnixMs + int64(seconds)*1000
+}
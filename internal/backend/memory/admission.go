@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"fmt"
+
+	"cogni/pkg/ratelimiter"
+)
+
+// Default headroom fractions: low-priority reservations stop at 80% of a
+// limit so normal and high work can still be admitted.
+const (
+	DefaultLowHeadroom    = 0.2
+	DefaultNormalHeadroom = 0.0
+)
+
+// SetPriorityHeadroom sets the fraction of each limit that low- and
+// normal-priority reservations must leave free. High priority always uses
+// the full capacity. Fractions must be in [0, 1) with low >= normal.
+func (m *MemoryBackend) SetPriorityHeadroom(low, normal float64) error {
+	if err := ValidateHeadroom(low, normal); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.headroom = map[ratelimiter.Priority]float64{
+		ratelimiter.PriorityLow:    low,
+		ratelimiter.PriorityNormal: normal,
+	}
+	return nil
+}
+
+// ValidateHeadroom checks headroom fractions for SetPriorityHeadroom.
+func ValidateHeadroom(low, normal float64) error {
+	if low < 0 || low >= 1 {
+		return fmt.Errorf("low headroom must be in [0, 1), got %v", low)
+	}
+	if normal < 0 || normal >= 1 {
+		return fmt.Errorf("normal headroom must be in [0, 1), got %v", normal)
+	}
+	if low < normal {
+		return fmt.Errorf("low headroom %v must not be below normal headroom %v", low, normal)
+	}
+	return nil
+}
+
+// admitsLocked reports whether amount more units fit on key for a
+// reservation of the given priority: the enforced capacity minus the
+// priority's headroom. An idle limit admits anything within its full
+// capacity, so a single large low-priority request is never locked out.
+func (m *MemoryBackend) admitsLocked(key ratelimiter.LimitKey, limitCap, used, amount uint64, priority ratelimiter.Priority) bool {
+	capacity := m.enforcedCapLocked(key, limitCap)
+	if used == 0 {
+		return amount <= capacity
+	}
+	reserved := uint64(float64(capacity) * m.headroom[priority.Normalize()])
+	return used+amount <= capacity-reserved
+}
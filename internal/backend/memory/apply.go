@@ -36,15 +36,19 @@ func (m *MemoryBackend) ApplyDefinition(_ context.Context, def ratelimiter.Limit
 	return nil
 }
 
-// ApplyState loads a persisted limit state into memory.
+// ApplyState loads a persisted limit state into memory. A draining limit
+// that no lease holds is removed right away.
 func (m *MemoryBackend) ApplyState(state ratelimiter.LimitState) error {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-
 	m.defs[state.Definition.Key] = state.Definition
 	m.states[state.Definition.Key] = state
 	m.ensureLimitStoresLocked(state.Definition)
 	m.updateCapacityLocked(state.Definition)
+	m.mu.Unlock()
+
+	if state.Status == ratelimiter.LimitStatusDraining {
+		m.finishDrains([]ratelimiter.Requirement{{Key: state.Definition.Key}})
+	}
 	return nil
 }
 
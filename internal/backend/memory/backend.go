@@ -2,6 +2,7 @@ package memory
 
 import (
 	"sync"
+	"time"
 
 	"cogni/internal/registry"
 	"cogni/pkg/ratelimiter"
@@ -18,7 +19,14 @@ type MemoryBackend struct {
 	roll         map[ratelimiter.LimitKey]*rollingLimit
 	conc         map[ratelimiter.LimitKey]*concLimit
 	debt         map[ratelimiter.LimitKey]uint64
-	leases       map[string]LeaseState
+	effective    map[ratelimiter.LimitKey]uint64
+	headroom     map[ratelimiter.Priority]float64
+	// tenantWeights enables tenant fair share when non-nil; waiting holds
+	// recently denied tenants per key. See EnableTenantFairShare.
+	tenantWeights map[string]float64
+	waiting       map[ratelimiter.LimitKey]map[string]time.Time
+	leases        map[string]LeaseState
+	persist       *persistence
 }
 
 // New creates a MemoryBackend with the provided clock.
@@ -27,12 +35,17 @@ func New(clock Clock) *MemoryBackend {
 		clock = realClock{}
 	}
 	return &MemoryBackend{
-		clock:  clock,
-		defs:   map[ratelimiter.LimitKey]ratelimiter.LimitDefinition{},
-		states: map[ratelimiter.LimitKey]ratelimiter.LimitState{},
-		roll:   map[ratelimiter.LimitKey]*rollingLimit{},
-		conc:   map[ratelimiter.LimitKey]*concLimit{},
-		debt:   map[ratelimiter.LimitKey]uint64{},
+		clock:     clock,
+		defs:      map[ratelimiter.LimitKey]ratelimiter.LimitDefinition{},
+		states:    map[ratelimiter.LimitKey]ratelimiter.LimitState{},
+		roll:      map[ratelimiter.LimitKey]*rollingLimit{},
+		conc:      map[ratelimiter.LimitKey]*concLimit{},
+		debt:      map[ratelimiter.LimitKey]uint64{},
+		effective: map[ratelimiter.LimitKey]uint64{},
+		headroom: map[ratelimiter.Priority]float64{
+			ratelimiter.PriorityLow:    DefaultLowHeadroom,
+			ratelimiter.PriorityNormal: DefaultNormalHeadroom,
+		},
 		leases: map[string]LeaseState{},
 	}
 }
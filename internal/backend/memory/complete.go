@@ -8,26 +8,27 @@ import (
 
 // Complete reconciles reservations with actual usage.
 func (m *MemoryBackend) Complete(_ context.Context, req ratelimiter.CompleteRequest) (ratelimiter.CompleteResponse, error) {
+	res, state, err := m.complete(req)
+	if err == nil {
+		m.finishDrains(state.Requirements)
+	}
+	return res, err
+}
+
+// complete reconciles a lease under the lock and returns its metadata.
+func (m *MemoryBackend) complete(req ratelimiter.CompleteRequest) (ratelimiter.CompleteResponse, LeaseState, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	state, ok := m.leases[req.LeaseID]
 	if !ok {
-		return ratelimiter.CompleteResponse{Ok: true}, nil
+		return ratelimiter.CompleteResponse{Ok: true}, LeaseState{}, nil
 	}
-
-	for _, r := range state.Requirements {
-		def, ok := m.defs[r.Key]
-		if !ok {
-			continue
-		}
-		if def.Kind == ratelimiter.KindConcurrency {
-			if limit, ok := m.conc[r.Key]; ok {
-				delete(limit.holds, req.LeaseID)
-			}
-		}
+	if req.Tenant != "" && state.Tenant != req.Tenant {
+		return ratelimiter.CompleteResponse{Ok: false, Error: forbiddenLeaseError}, LeaseState{}, nil
 	}
 
+	record := journalRecord{Op: opComplete, LeaseID: req.LeaseID}
 	for _, actual := range req.Actuals {
 		def, ok := m.defs[actual.Key]
 		if !ok || def.Kind != ratelimiter.KindRolling {
@@ -35,14 +36,25 @@ func (m *MemoryBackend) Complete(_ context.Context, req ratelimiter.CompleteRequ
 		}
 		reserved := state.ReservedAmounts[actual.Key]
 		if actual.ActualAmount < reserved {
-			reduceRollingReservation(m.roll[actual.Key], req.LeaseID, actual.ActualAmount)
+			if record.Reduce == nil {
+				record.Reduce = map[ratelimiter.LimitKey]uint64{}
+			}
+			if prev, ok := record.Reduce[actual.Key]; !ok || actual.ActualAmount < prev {
+				record.Reduce[actual.Key] = actual.ActualAmount
+			}
 			continue
 		}
 		if actual.ActualAmount > reserved && def.Overage == ratelimiter.OverageDebt {
-			m.debt[actual.Key] += actual.ActualAmount - reserved
+			if record.Debt == nil {
+				record.Debt = map[ratelimiter.LimitKey]uint64{}
+			}
+			record.Debt[actual.Key] += actual.ActualAmount - reserved
 		}
 	}
-
-	delete(m.leases, req.LeaseID)
-	return ratelimiter.CompleteResponse{Ok: true}, nil
+	if err := m.journalLocked(record); err != nil {
+		return ratelimiter.CompleteResponse{}, LeaseState{}, err
+	}
+	m.applyCompleteLocked(record)
+	m.compactLocked()
+	return ratelimiter.CompleteResponse{Ok: true}, state, nil
 }
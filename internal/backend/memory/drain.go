@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+
+	"cogni/internal/backend"
+	"cogni/pkg/ratelimiter"
+)
+
+// RemoveDefinition marks a limit draining and removes it once no uncompleted
+// lease holds it. Unknown keys count as removed.
+func (m *MemoryBackend) RemoveDefinition(_ context.Context, key ratelimiter.LimitKey) (bool, error) {
+	m.mu.Lock()
+	state, ok := m.states[key]
+	if !ok {
+		m.mu.Unlock()
+		return true, nil
+	}
+	state.Status = ratelimiter.LimitStatusDraining
+	state.PendingDecreaseTo = 0
+	m.states[key] = state
+	removed := m.finishDrainLocked(key)
+	m.mu.Unlock()
+	return removed, nil
+}
+
+// finishDrainLocked removes a draining limit with no in-flight holds.
+func (m *MemoryBackend) finishDrainLocked(key ratelimiter.LimitKey) bool {
+	state, ok := m.states[key]
+	if !ok || state.Status != ratelimiter.LimitStatusDraining {
+		return false
+	}
+	m.cleanupLocked(key)
+	if backend.InFlightByKey(m.leasesLocked())[key] > 0 {
+		return false
+	}
+	delete(m.defs, key)
+	delete(m.states, key)
+	delete(m.roll, key)
+	delete(m.conc, key)
+	delete(m.debt, key)
+	delete(m.effective, key)
+	return true
+}
+
+// finishDrains removes the draining limits among reqs, or every draining
+// limit when reqs is nil, that no lease holds any more, and drops them from
+// the attached registry. It runs when leases complete, before reservations,
+// on usage reads and when states are loaded, so a drain also finishes once
+// its last lease expires or after a restart.
+func (m *MemoryBackend) finishDrains(reqs []ratelimiter.Requirement) {
+	m.mu.Lock()
+	if reqs == nil {
+		for key, state := range m.states {
+			if state.Status == ratelimiter.LimitStatusDraining {
+				reqs = append(reqs, ratelimiter.Requirement{Key: key})
+			}
+		}
+	}
+	var removed []ratelimiter.LimitKey
+	for _, r := range reqs {
+		if m.finishDrainLocked(r.Key) {
+			removed = append(removed, r.Key)
+		}
+	}
+	reg, registryPath := m.registry, m.registryPath
+	m.mu.Unlock()
+
+	if len(removed) == 0 || reg == nil {
+		return
+	}
+	for _, key := range removed {
+		reg.Delete(key)
+	}
+	if registryPath != "" {
+		_ = reg.Save(registryPath)
+	}
+}
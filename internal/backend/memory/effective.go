@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"context"
+
+	"cogni/pkg/ratelimiter"
+)
+
+// DefinedCapacity returns key's configured capacity.
+func (m *MemoryBackend) DefinedCapacity(_ context.Context, key ratelimiter.LimitKey) (uint64, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	def, ok := m.defs[key]
+	if !ok {
+		return 0, false, nil
+	}
+	return def.Capacity, true, nil
+}
+
+// SetEffectiveCapacity caps new reservations on key below its defined
+// capacity; zero clears the cap. Existing reservations are kept, and the cap
+// is not journaled: adaptive adjustments restart from the definition.
+func (m *MemoryBackend) SetEffectiveCapacity(_ context.Context, key ratelimiter.LimitKey, capacity uint64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if capacity == 0 {
+		delete(m.effective, key)
+		return nil
+	}
+	if _, ok := m.defs[key]; !ok {
+		return nil
+	}
+	m.effective[key] = capacity
+	return nil
+}
+
+// enforcedCapLocked returns the capacity reservations on key are checked
+// against: limitCap, or a lower effective capacity.
+func (m *MemoryBackend) enforcedCapLocked(key ratelimiter.LimitKey, limitCap uint64) uint64 {
+	if capacity, ok := m.effective[key]; ok && capacity < limitCap {
+		return capacity
+	}
+	return limitCap
+}
@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"fmt"
+	"time"
+
+	"cogni/pkg/ratelimiter"
+)
+
+// waitingTTL is how long a denied tenant keeps its claim to a share of a
+// limit, long enough to cover a client's retry backoff.
+const waitingTTL = 10 * time.Second
+
+// EnableTenantFairShare shares every limit across the tenants using it.
+// While more than one tenant holds or recently waited for a limit, a tenant
+// already holding more than its weighted share of the capacity is denied
+// further reservations on it, so one tenant's batch cannot starve another's
+// interactive work. Tenants not listed in weights count as 1.
+func (m *MemoryBackend) EnableTenantFairShare(weights map[string]float64) error {
+	if err := ValidateTenantWeights(weights); err != nil {
+		return err
+	}
+	copied := make(map[string]float64, len(weights))
+	for tenant, weight := range weights {
+		copied[tenant] = weight
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tenantWeights = copied
+	m.waiting = map[ratelimiter.LimitKey]map[string]time.Time{}
+	return nil
+}
+
+// ValidateTenantWeights checks weights for EnableTenantFairShare.
+func ValidateTenantWeights(weights map[string]float64) error {
+	for tenant, weight := range weights {
+		if tenant == "" {
+			return fmt.Errorf("tenant weight needs a tenant name")
+		}
+		if weight <= 0 {
+			return fmt.Errorf("tenant %s weight must be positive, got %v", tenant, weight)
+		}
+	}
+	return nil
+}
+
+// shareTenant is the tenant a lease counts against for fair share: the
+// authenticated caller's tenant, or else the tenant of a tenant-scoped key
+// it reserves, such as its daily budget.
+func shareTenant(tenant string, reqs []ratelimiter.Requirement) string {
+	if tenant != "" {
+		return tenant
+	}
+	for _, r := range reqs {
+		if owner, ok := ratelimiter.KeyTenant(r.Key); ok {
+			return owner
+		}
+	}
+	return ""
+}
+
+// fairShareAdmitsLocked reports whether tenant may take amount more units of
+// key within its weighted share of capacity. A tenant holding nothing is
+// always admitted, like an idle limit, so it can claim its share.
+func (m *MemoryBackend) fairShareAdmitsLocked(key ratelimiter.LimitKey, limitCap uint64, tenant string, amount uint64, now time.Time) bool {
+	if m.tenantWeights == nil || tenant == "" {
+		return true
+	}
+	usage := m.tenantUsageLocked(key)
+	held := usage[tenant]
+	if held == 0 {
+		return true
+	}
+	total := m.tenantWeight(tenant)
+	for other, used := range usage {
+		if other != tenant && used > 0 {
+			total += m.tenantWeight(other)
+		}
+	}
+	for other, until := range m.waiting[key] {
+		if !now.Before(until) {
+			delete(m.waiting[key], other)
+			continue
+		}
+		if other != tenant && usage[other] == 0 {
+			total += m.tenantWeight(other)
+		}
+	}
+	share := float64(m.enforcedCapLocked(key, limitCap)) * m.tenantWeight(tenant) / total
+	return float64(held+amount) <= share
+}
+
+// noteWaitingLocked records that tenant was denied key, so tenants already
+// holding it are held to their share while it retries.
+func (m *MemoryBackend) noteWaitingLocked(key ratelimiter.LimitKey, tenant string, now time.Time) {
+	if m.tenantWeights == nil || tenant == "" {
+		return
+	}
+	if m.waiting[key] == nil {
+		m.waiting[key] = map[string]time.Time{}
+	}
+	m.waiting[key][tenant] = now.Add(waitingTTL)
+}
+
+// tenantUsageLocked splits a limit's current usage by tenant.
+func (m *MemoryBackend) tenantUsageLocked(key ratelimiter.LimitKey) map[string]uint64 {
+	if limit, ok := m.roll[key]; ok {
+		return limit.byTenant
+	}
+	usage := map[string]uint64{}
+	if limit, ok := m.conc[key]; ok {
+		for leaseID := range limit.holds {
+			if lease, ok := m.leases[leaseID]; ok {
+				if tenant := shareTenant(lease.Tenant, lease.Requirements); tenant != "" {
+					usage[tenant]++
+				}
+			}
+		}
+	}
+	return usage
+}
+
+// tenantWeight returns a tenant's configured weight, defaulting to 1.
+func (m *MemoryBackend) tenantWeight(tenant string) float64 {
+	if weight, ok := m.tenantWeights[tenant]; ok {
+		return weight
+	}
+	return 1
+}
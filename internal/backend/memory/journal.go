@@ -0,0 +1,188 @@
+package memory
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"cogni/pkg/ratelimiter"
+)
+
+const (
+	opReserve  = "reserve"
+	opComplete = "complete"
+)
+
+// journalRecord is one state change. Records carry their effects rather than
+// the request, so replay does not depend on capacity at replay time.
+type journalRecord struct {
+	Seq     uint64                          `json:"seq"`
+	Op      string                          `json:"op"`
+	Lease   *leaseRecord                    `json:"lease,omitempty"`
+	LeaseID string                          `json:"lease_id,omitempty"`
+	Reduce  map[ratelimiter.LimitKey]uint64 `json:"reduce,omitempty"`
+	Debt    map[ratelimiter.LimitKey]uint64 `json:"debt,omitempty"`
+}
+
+// leaseRecord is a granted reservation and the holds it took.
+type leaseRecord struct {
+	LeaseID          string                    `json:"lease_id"`
+	ReservedAtUnixMs int64                     `json:"reserved_at_unix_ms"`
+	Requirements     []ratelimiter.Requirement `json:"requirements"`
+	Holds            []holdRecord              `json:"holds"`
+	Tenant           string                    `json:"tenant,omitempty"`
+}
+
+// holdRecord is a rolling reservation or concurrency hold with its expiry.
+type holdRecord struct {
+	Key             ratelimiter.LimitKey `json:"key"`
+	LeaseID         string               `json:"lease_id,omitempty"`
+	Amount          uint64               `json:"amount,omitempty"`
+	ExpiresAtUnixMs int64                `json:"expires_at_unix_ms"`
+	Tenant          string               `json:"tenant,omitempty"`
+}
+
+// newLeaseRecord builds the record of a reservation granted at now.
+func (m *MemoryBackend) newLeaseRecord(req ratelimiter.ReserveRequest, now time.Time) leaseRecord {
+	record := leaseRecord{LeaseID: req.LeaseID, ReservedAtUnixMs: now.UnixMilli(), Requirements: req.Requirements, Tenant: req.Tenant}
+	for _, r := range req.Requirements {
+		def := m.defs[r.Key]
+		seconds := def.WindowSeconds
+		if def.Kind == ratelimiter.KindConcurrency {
+			seconds = def.TimeoutSeconds
+		}
+		record.Holds = append(record.Holds, holdRecord{
+			Key:             r.Key,
+			Amount:          r.Amount,
+			ExpiresAtUnixMs: now.Add(time.Duration(seconds) * time.Second).UnixMilli(),
+		})
+	}
+	return record
+}
+
+// applyReserveLocked takes the holds of a granted reservation.
+func (m *MemoryBackend) applyReserveLocked(record leaseRecord) {
+	tenant := shareTenant(record.Tenant, record.Requirements)
+	for _, hold := range record.Holds {
+		expiresAt := time.UnixMilli(hold.ExpiresAtUnixMs)
+		switch m.defs[hold.Key].Kind {
+		case ratelimiter.KindRolling:
+			if limit, ok := m.roll[hold.Key]; ok {
+				addRollingReservation(limit, record.LeaseID, tenant, hold.Amount, expiresAt)
+			}
+		case ratelimiter.KindConcurrency:
+			if limit, ok := m.conc[hold.Key]; ok {
+				addConcurrencyHold(limit, record.LeaseID, expiresAt)
+			}
+		}
+	}
+	m.leases[record.LeaseID] = LeaseState{
+		LeaseID:         record.LeaseID,
+		ReservedAtUnix:  record.ReservedAtUnixMs,
+		Requirements:    record.Requirements,
+		ReservedAmounts: indexByKey(record.Requirements),
+		Tenant:          record.Tenant,
+	}
+}
+
+// applyCompleteLocked releases concurrency holds, shrinks underused rolling
+// reservations and records debt for a completed lease.
+func (m *MemoryBackend) applyCompleteLocked(record journalRecord) {
+	state, ok := m.leases[record.LeaseID]
+	if !ok {
+		return
+	}
+	for _, r := range state.Requirements {
+		if m.defs[r.Key].Kind != ratelimiter.KindConcurrency {
+			continue
+		}
+		if limit, ok := m.conc[r.Key]; ok {
+			delete(limit.holds, record.LeaseID)
+		}
+	}
+	for key, amount := range record.Reduce {
+		if limit, ok := m.roll[key]; ok {
+			reduceRollingReservation(limit, record.LeaseID, amount)
+		}
+	}
+	for key, amount := range record.Debt {
+		m.debt[key] += amount
+	}
+	delete(m.leases, record.LeaseID)
+}
+
+// journalLocked appends and syncs a record before its change is applied.
+// Without persistence it does nothing.
+func (m *MemoryBackend) journalLocked(record journalRecord) error {
+	if m.persist == nil {
+		return nil
+	}
+	record.Seq = m.persist.seq + 1
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if _, err := m.persist.journal.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	if err := m.persist.journal.Sync(); err != nil {
+		return err
+	}
+	m.persist.seq = record.Seq
+	m.persist.sinceSnapshot++
+	return nil
+}
+
+// compactLocked snapshots once enough records have accumulated. A failed
+// compaction leaves the journal intact, so it is retried on the next record.
+func (m *MemoryBackend) compactLocked() {
+	if m.persist == nil || m.persist.sinceSnapshot < m.persist.snapshotEvery {
+		return
+	}
+	_ = m.snapshotLocked()
+}
+
+// replayJournalLocked applies records newer than afterSeq and returns the last
+// sequence number. A torn final line from a crash mid-append is truncated.
+func (m *MemoryBackend) replayJournalLocked(path string, afterSeq uint64) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return afterSeq, nil
+	}
+	if err != nil {
+		return afterSeq, err
+	}
+	seq := afterSeq
+	reader := bufio.NewReader(bytes.NewReader(data))
+	offset := 0
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) == 0 {
+			break
+		}
+		var record journalRecord
+		if err != nil || json.Unmarshal(line, &record) != nil {
+			if offset+len(line) < len(data) {
+				return seq, fmt.Errorf("corrupt journal record at byte %d", offset)
+			}
+			return seq, os.Truncate(path, int64(offset))
+		}
+		offset += len(line)
+		if record.Seq <= seq {
+			continue
+		}
+		switch record.Op {
+		case opReserve:
+			if record.Lease != nil {
+				m.applyReserveLocked(*record.Lease)
+			}
+		case opComplete:
+			m.applyCompleteLocked(record)
+		}
+		seq = record.Seq
+	}
+	return seq, nil
+}
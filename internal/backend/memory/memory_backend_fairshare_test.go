@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"cogni/internal/testutil"
+	"cogni/pkg/ratelimiter"
+)
+
+func reserveTenant(t *testing.T, backend *MemoryBackend, leaseID, tenant string, reqs []ratelimiter.Requirement, now time.Time) ratelimiter.ReserveResponse {
+	t.Helper()
+	ctx := testutil.Context(t, time.Second)
+	res, err := backend.Reserve(ctx, ratelimiter.ReserveRequest{LeaseID: leaseID, Tenant: tenant, Requirements: reqs}, now)
+	if err != nil {
+		t.Fatalf("reserve: %v", err)
+	}
+	return res
+}
+
+func TestMemory_FairShare_HoldsBusyTenantToItsShare(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		clock := testutil.NewFakeClock(time.Unix(0, 0))
+		backend := newMemoryBackendForTest(clock)
+		if err := backend.EnableTenantFairShare(nil); err != nil {
+			t.Fatalf("enable fair share: %v", err)
+		}
+		daily := ratelimiter.TenantDailyKey("inter")
+		applyDefs(t, backend, rollingDef("tpm", 100, 60), rollingDef(string(daily), 1000, 86400))
+		inter := []ratelimiter.Requirement{{Key: "tpm", Amount: 20}, {Key: daily, Amount: 20}}
+
+		if res := reserveTenant(t, backend, "B1", "batch", req("tpm", 60), clock.Now()); !res.Allowed {
+			t.Fatalf("expected a lone tenant to use the limit, got %+v", res)
+		}
+		if res := reserveTenant(t, backend, "B2", "batch", req("tpm", 30), clock.Now()); !res.Allowed {
+			t.Fatalf("expected a lone tenant to use the limit, got %+v", res)
+		}
+		if res := reserveTenant(t, backend, "I1", "", inter, clock.Now()); res.Allowed {
+			t.Fatalf("expected the full limit to deny the second tenant")
+		}
+		if res := reserveTenant(t, backend, "B3", "batch", req("tpm", 5), clock.Now()); res.Allowed {
+			t.Fatalf("expected the tenant over its share to be denied while another waits")
+		}
+
+		complete(t, backend, "B1", []ratelimiter.Actual{{Key: "tpm", ActualAmount: 10}})
+		if res := reserveTenant(t, backend, "I1", "", inter, clock.Now()); !res.Allowed {
+			t.Fatalf("expected the waiting tenant admitted, got %+v", res)
+		}
+		if res := reserveTenant(t, backend, "B4", "batch", req("tpm", 20), clock.Now()); res.Allowed {
+			t.Fatalf("expected the busy tenant held to half the limit")
+		}
+		if res := reserveTenant(t, backend, "B5", "batch", req("tpm", 10), clock.Now()); !res.Allowed {
+			t.Fatalf("expected the busy tenant admitted up to its share, got %+v", res)
+		}
+	})
+}
+
+func TestMemory_FairShare_WeightsAndWaitingExpiry(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		clock := testutil.NewFakeClock(time.Unix(0, 0))
+		backend := newMemoryBackendForTest(clock)
+		if err := backend.EnableTenantFairShare(map[string]float64{"inter": 3}); err != nil {
+			t.Fatalf("enable fair share: %v", err)
+		}
+		applyDefs(t, backend, concDef("conc", 4, 600))
+
+		for _, lease := range []string{"B1", "B2", "B3", "B4"} {
+			if res := reserveTenant(t, backend, lease, "batch", req("conc", 1), clock.Now()); !res.Allowed {
+				t.Fatalf("lease %s: expected a lone tenant to use the limit, got %+v", lease, res)
+			}
+		}
+		if res := reserveTenant(t, backend, "I1", "inter", req("conc", 1), clock.Now()); res.Allowed {
+			t.Fatalf("expected the full limit to deny the second tenant")
+		}
+		complete(t, backend, "B1", nil)
+		if res := reserveTenant(t, backend, "B5", "batch", req("conc", 1), clock.Now()); res.Allowed {
+			t.Fatalf("expected the light tenant held to a quarter while the heavy one waits")
+		}
+
+		clock.Advance(waitingTTL)
+		if res := reserveTenant(t, backend, "B6", "batch", req("conc", 1), clock.Now()); !res.Allowed {
+			t.Fatalf("expected the share released once the waiting tenant stopped retrying, got %+v", res)
+		}
+	})
+}
+
+func TestMemory_FairShare_RejectsNonPositiveWeight(t *testing.T) {
+	backend := New(nil)
+	if err := backend.EnableTenantFairShare(map[string]float64{"batch": 0}); err == nil {
+		t.Fatalf("expected a zero weight rejected")
+	}
+}
@@ -0,0 +1,165 @@
+package memory
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cogni/internal/registry"
+	"cogni/internal/testutil"
+	"cogni/pkg/ratelimiter"
+)
+
+func TestMemory_PersistenceRecoversMidWindow(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		dir := t.TempDir()
+		clock := testutil.NewFakeClock(time.Unix(0, 0))
+		debtDef := rollingDef("tpm", 100, 60)
+		debtDef.Overage = ratelimiter.OverageDebt
+		defs := []ratelimiter.LimitDefinition{debtDef, concDef("conc", 1, 30)}
+
+		first := newMemoryBackendForTest(clock)
+		applyDefs(t, first, defs...)
+		if err := first.EnablePersistence(PersistConfig{Dir: dir, SnapshotEvery: 2}); err != nil {
+			t.Fatalf("enable persistence: %v", err)
+		}
+		allowReserve(t, first, "L1", req("tpm", 50), clock.Now())
+		complete(t, first, "L1", []ratelimiter.Actual{{Key: "tpm", ActualAmount: 30}})
+		allowReserve(t, first, "L2", multiReq(req("tpm", 40), req("conc", 1)), clock.Now())
+		complete(t, first, "L2", []ratelimiter.Actual{{Key: "tpm", ActualAmount: 45}})
+		allowReserve(t, first, "L3", multiReq(req("tpm", 20), req("conc", 1)), clock.Now())
+		// Simulate a crash: the first backend is abandoned without Close.
+
+		clock.Advance(10 * time.Second)
+		second := newMemoryBackendForTest(clock)
+		applyDefs(t, second, defs...)
+		if err := second.EnablePersistence(PersistConfig{Dir: dir, SnapshotEvery: 2}); err != nil {
+			t.Fatalf("restore persistence: %v", err)
+		}
+		if debt := second.DebtForKey("tpm"); debt != 5 {
+			t.Fatalf("expected debt 5 after restart, got %d", debt)
+		}
+		if res := reserve(t, second, "L4", req("tpm", 11), clock.Now()); res.Allowed {
+			t.Fatalf("expected restored window usage to deny reserve, got %+v", res)
+		}
+		if res := reserve(t, second, "L5", req("conc", 1), clock.Now()); res.Allowed {
+			t.Fatalf("expected restored concurrency hold to deny reserve, got %+v", res)
+		}
+		allowReserve(t, second, "L6", req("tpm", 10), clock.Now())
+		complete(t, second, "L3", nil)
+		allowReserve(t, second, "L7", req("conc", 1), clock.Now())
+		if err := second.Close(); err != nil {
+			t.Fatalf("close: %v", err)
+		}
+
+		clock.Advance(51 * time.Second)
+		third := newMemoryBackendForTest(clock)
+		applyDefs(t, third, defs...)
+		if err := third.EnablePersistence(PersistConfig{Dir: dir}); err != nil {
+			t.Fatalf("restore persistence: %v", err)
+		}
+		if res := reserve(t, third, "L8", req("tpm", 91), clock.Now()); res.Allowed {
+			t.Fatalf("expected reservation made after restart to survive close, got %+v", res)
+		}
+		allowReserve(t, third, "L9", req("tpm", 90), clock.Now())
+	})
+}
+
+func TestMemory_PersistenceDropsTornJournalRecord(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		dir := t.TempDir()
+		clock := testutil.NewFakeClock(time.Unix(0, 0))
+
+		first := newMemoryBackendForTest(clock)
+		applyDefs(t, first, rollingDef("tpm", 100, 60))
+		if err := first.EnablePersistence(PersistConfig{Dir: dir}); err != nil {
+			t.Fatalf("enable persistence: %v", err)
+		}
+		allowReserve(t, first, "L1", req("tpm", 60), clock.Now())
+
+		journal, err := os.OpenFile(filepath.Join(dir, journalFile), os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			t.Fatalf("open journal: %v", err)
+		}
+		if _, err := journal.WriteString(`{"seq":2,"op":"reserve","lease":{"lease_id":"L2"`); err != nil {
+			t.Fatalf("write torn record: %v", err)
+		}
+		_ = journal.Close()
+
+		second := newMemoryBackendForTest(clock)
+		applyDefs(t, second, rollingDef("tpm", 100, 60))
+		if err := second.EnablePersistence(PersistConfig{Dir: dir}); err != nil {
+			t.Fatalf("restore persistence: %v", err)
+		}
+		if res := reserve(t, second, "L3", req("tpm", 41), clock.Now()); res.Allowed {
+			t.Fatalf("expected journaled reservation restored, got %+v", res)
+		}
+		allowReserve(t, second, "L4", req("tpm", 40), clock.Now())
+	})
+}
+
+func TestMemory_DrainFinishesAfterLeaseExpiry(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		clock := testutil.NewFakeClock(time.Unix(0, 0))
+		reg := registry.New()
+		backend := newMemoryBackendForTest(clock)
+		backend.AttachRegistry(reg, "")
+		def := concDef("conc", 1, 30)
+		applyDefs(t, backend, def, rollingDef("other", 10, 60))
+		reg.Put(ratelimiter.LimitState{Definition: def, Status: ratelimiter.LimitStatusActive})
+		allowReserve(t, backend, "L1", req("conc", 1), clock.Now())
+
+		removed, err := backend.RemoveDefinition(testutil.Context(t, time.Second), "conc")
+		if err != nil || removed {
+			t.Fatalf("expected conc draining behind L1, got removed=%t err=%v", removed, err)
+		}
+		// L1 is never completed; once its hold times out the drain finishes.
+		clock.Advance(31 * time.Second)
+		allowReserve(t, backend, "L2", req("other", 1), clock.Now())
+		if usage, err := backend.Usage(testutil.Context(t, time.Second)); err != nil || len(usage) != 1 || usage[0].Key != "other" {
+			t.Fatalf("expected conc removed after its lease expired, got %+v (%v)", usage, err)
+		}
+		if _, ok := reg.Get("conc"); ok {
+			t.Fatalf("expected registry entry dropped")
+		}
+	})
+}
+
+func TestMemory_DrainingStateFinishesAfterRestart(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		path := filepath.Join(t.TempDir(), "limits.json")
+		clock := testutil.NewFakeClock(time.Unix(0, 0))
+		def := concDef("conc", 1, 30)
+		saved := registry.New()
+		saved.Put(ratelimiter.LimitState{Definition: def, Status: ratelimiter.LimitStatusDraining})
+		if err := saved.Save(path); err != nil {
+			t.Fatalf("save registry: %v", err)
+		}
+
+		reg := registry.New()
+		if err := reg.Load(path); err != nil {
+			t.Fatalf("load registry: %v", err)
+		}
+		backend := newMemoryBackendForTest(clock)
+		backend.AttachRegistry(reg, path)
+		for _, state := range reg.List() {
+			if err := backend.ApplyState(state); err != nil {
+				t.Fatalf("apply state: %v", err)
+			}
+		}
+		if _, ok := reg.Get("conc"); ok {
+			t.Fatalf("expected the reloaded drain to finish")
+		}
+		reloaded := registry.New()
+		if err := reloaded.Load(path); err != nil {
+			t.Fatalf("reload registry: %v", err)
+		}
+		if len(reloaded.List()) != 0 {
+			t.Fatalf("expected saved registry to drop the limit, got %+v", reloaded.List())
+		}
+		if res := reserve(t, backend, "L1", req("conc", 1), clock.Now()); res.Allowed || res.Error != "unknown_limit_key:conc" {
+			t.Fatalf("expected conc gone, got %+v", res)
+		}
+	})
+}
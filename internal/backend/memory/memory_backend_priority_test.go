@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"cogni/internal/testutil"
+	"cogni/pkg/ratelimiter"
+)
+
+func reservePriority(t *testing.T, backend *MemoryBackend, leaseID string, reqs []ratelimiter.Requirement, priority ratelimiter.Priority, now time.Time) ratelimiter.ReserveResponse {
+	t.Helper()
+	ctx := testutil.Context(t, time.Second)
+	res, err := backend.Reserve(ctx, ratelimiter.ReserveRequest{LeaseID: leaseID, Requirements: reqs, Priority: priority}, now)
+	if err != nil {
+		t.Fatalf("reserve: %v", err)
+	}
+	return res
+}
+
+func TestMemory_Priority_LowLeavesHeadroom(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		clock := testutil.NewFakeClock(time.Unix(0, 0))
+		backend := newMemoryBackendForTest(clock)
+		applyDefs(t, backend, rollingDef("tpm", 100, 60))
+
+		if res := reservePriority(t, backend, "L1", req("tpm", 70), ratelimiter.PriorityLow, clock.Now()); !res.Allowed {
+			t.Fatalf("expected first low reservation to be allowed, got %+v", res)
+		}
+		if res := reservePriority(t, backend, "L2", req("tpm", 20), ratelimiter.PriorityLow, clock.Now()); res.Allowed {
+			t.Fatalf("expected low reservation past headroom to be denied")
+		}
+		if res := reservePriority(t, backend, "N1", req("tpm", 20), "", clock.Now()); !res.Allowed {
+			t.Fatalf("expected normal reservation to be allowed, got %+v", res)
+		}
+		if res := reservePriority(t, backend, "H1", req("tpm", 10), ratelimiter.PriorityHigh, clock.Now()); !res.Allowed {
+			t.Fatalf("expected high reservation to use full capacity, got %+v", res)
+		}
+	})
+}
+
+func TestMemory_Priority_IdleLimitAdmitsLargeLowReservation(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		clock := testutil.NewFakeClock(time.Unix(0, 0))
+		backend := newMemoryBackendForTest(clock)
+		applyDefs(t, backend, rollingDef("tpm", 100, 60))
+
+		if res := reservePriority(t, backend, "L1", req("tpm", 95), ratelimiter.PriorityLow, clock.Now()); !res.Allowed {
+			t.Fatalf("expected idle limit to admit low reservation, got %+v", res)
+		}
+	})
+}
+
+func TestMemory_Priority_NormalHeadroomAppliesToConcurrency(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		clock := testutil.NewFakeClock(time.Unix(0, 0))
+		backend := newMemoryBackendForTest(clock)
+		if err := backend.SetPriorityHeadroom(0.5, 0.5); err != nil {
+			t.Fatalf("set headroom: %v", err)
+		}
+		applyDefs(t, backend, concDef("conc", 4, 300))
+
+		allowReserve(t, backend, "N1", req("conc", 1), clock.Now())
+		allowReserve(t, backend, "N2", req("conc", 1), clock.Now())
+		if res := reserve(t, backend, "N3", req("conc", 1), clock.Now()); res.Allowed {
+			t.Fatalf("expected normal reservation past headroom to be denied")
+		}
+		if res := reservePriority(t, backend, "H1", req("conc", 1), ratelimiter.PriorityHigh, clock.Now()); !res.Allowed {
+			t.Fatalf("expected high reservation to be allowed, got %+v", res)
+		}
+	})
+}
+
+func TestMemory_Priority_RejectsInvalidHeadroom(t *testing.T) {
+	backend := New(nil)
+	if err := backend.SetPriorityHeadroom(0.1, 0.2); err == nil {
+		t.Fatalf("expected low below normal to be rejected")
+	}
+	if err := backend.SetPriorityHeadroom(1, 0); err == nil {
+		t.Fatalf("expected full headroom to be rejected")
+	}
+}
@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cogni/internal/testutil"
+	"cogni/pkg/ratelimiter"
+)
+
+func TestMemory_UsageReportsPerLimitCounters(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		clock := testutil.NewFakeClock(time.Unix(0, 0))
+		backend := newMemoryBackendForTest(clock)
+		debtDef := rollingDef("tpm", 100, 60)
+		debtDef.Overage = ratelimiter.OverageDebt
+		applyDefs(t, backend, debtDef, concDef("conc", 2, 30))
+
+		allowReserve(t, backend, "L1", multiReq(req("tpm", 40), req("conc", 1)), clock.Now())
+		allowReserve(t, backend, "L2", multiReq(req("tpm", 10), req("conc", 1)), clock.Now())
+		complete(t, backend, "L2", []ratelimiter.Actual{{Key: "tpm", ActualAmount: 15}})
+
+		usage, err := backend.Usage(context.Background())
+		if err != nil {
+			t.Fatalf("usage: %v", err)
+		}
+		if len(usage) != 2 || usage[0].Key != "conc" || usage[1].Key != "tpm" {
+			t.Fatalf("expected usage ordered by key, got %+v", usage)
+		}
+		conc, tpm := usage[0], usage[1]
+		if conc.Capacity != 2 || conc.Used != 1 || conc.InFlight != 1 {
+			t.Fatalf("unexpected concurrency usage: %+v", conc)
+		}
+		if tpm.Capacity != 100 || tpm.Used != 50 || tpm.InFlight != 40 || tpm.Debt != 5 {
+			t.Fatalf("unexpected rolling usage: %+v", tpm)
+		}
+
+		clock.Advance(61 * time.Second)
+		usage, err = backend.Usage(context.Background())
+		if err != nil {
+			t.Fatalf("usage: %v", err)
+		}
+		if usage[1].Used != 0 {
+			t.Fatalf("expected expired reservations released, got %+v", usage[1])
+		}
+	})
+}
+
+func TestMemory_LeasesReportUnexpiredHolds(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		clock := testutil.NewFakeClock(time.Unix(0, 0))
+		backend := newMemoryBackendForTest(clock)
+		applyDefs(t, backend, rollingDef("tpm", 100, 60), concDef("conc", 2, 30))
+
+		allowReserve(t, backend, "L1", multiReq(req("tpm", 40), req("conc", 1)), clock.Now())
+
+		leases, err := backend.Leases(context.Background())
+		if err != nil {
+			t.Fatalf("leases: %v", err)
+		}
+		if len(leases) != 1 || leases[0].LeaseID != "L1" || len(leases[0].Holds) != 2 {
+			t.Fatalf("unexpected leases: %+v", leases)
+		}
+		conc, tpm := leases[0].Holds[0], leases[0].Holds[1]
+		if conc.Key != "conc" || conc.ExpiresAtUnixMs != 30_000 {
+			t.Fatalf("unexpected concurrency hold: %+v", conc)
+		}
+		if tpm.Key != "tpm" || tpm.Amount != 40 || tpm.ExpiresAtUnixMs != 60_000 {
+			t.Fatalf("unexpected rolling hold: %+v", tpm)
+		}
+
+		clock.Advance(31 * time.Second)
+		leases, err = backend.Leases(context.Background())
+		if err != nil {
+			t.Fatalf("leases: %v", err)
+		}
+		if len(leases) != 1 || len(leases[0].Holds) != 1 || leases[0].Holds[0].Key != "tpm" {
+			t.Fatalf("expected lapsed concurrency hold omitted, got %+v", leases)
+		}
+		usage, err := backend.Usage(context.Background())
+		if err != nil {
+			t.Fatalf("usage: %v", err)
+		}
+		if usage[0].InFlight != 0 || usage[0].Available != 2 || usage[1].InFlight != 40 || usage[1].Available != 60 {
+			t.Fatalf("expected in-flight to match unexpired holds, got %+v", usage)
+		}
+
+		clock.Advance(30 * time.Second)
+		leases, err = backend.Leases(context.Background())
+		if err != nil {
+			t.Fatalf("leases: %v", err)
+		}
+		if len(leases) != 0 {
+			t.Fatalf("expected no leases after every hold lapsed, got %+v", leases)
+		}
+	})
+}
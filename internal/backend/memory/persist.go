@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	journalFile  = "journal.jsonl"
+	snapshotFile = "snapshot.json"
+	// defaultSnapshotEvery bounds journal growth between compactions.
+	defaultSnapshotEvery = 1000
+)
+
+// PersistConfig enables crash-safe persistence of reservations, concurrency
+// holds, lease metadata and debt. Limit definitions stay in the registry.
+type PersistConfig struct {
+	// Dir holds journal.jsonl and snapshot.json.
+	Dir string
+	// SnapshotEvery compacts the journal into a snapshot after this many
+	// records; zero uses 1000.
+	SnapshotEvery int
+}
+
+// persistence is the open journal of a MemoryBackend.
+type persistence struct {
+	dir           string
+	snapshotEvery int
+	journal       *os.File
+	seq           uint64
+	sinceSnapshot int
+}
+
+// EnablePersistence restores state from dir and journals every later change.
+// Limit states must be applied first so restored holds find their limits;
+// draining limits are removed as their states are applied, so holds on them
+// are dropped.
+func (m *MemoryBackend) EnablePersistence(cfg PersistConfig) error {
+	if cfg.Dir == "" {
+		return errors.New("persistence dir is required")
+	}
+	if cfg.SnapshotEvery <= 0 {
+		cfg.SnapshotEvery = defaultSnapshotEvery
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.persist != nil {
+		return errors.New("persistence already enabled")
+	}
+	seq, err := m.restoreSnapshotLocked(filepath.Join(cfg.Dir, snapshotFile))
+	if err != nil {
+		return fmt.Errorf("restore snapshot: %w", err)
+	}
+	seq, err = m.replayJournalLocked(filepath.Join(cfg.Dir, journalFile), seq)
+	if err != nil {
+		return fmt.Errorf("replay journal: %w", err)
+	}
+	journal, err := os.OpenFile(filepath.Join(cfg.Dir, journalFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	m.persist = &persistence{dir: cfg.Dir, snapshotEvery: cfg.SnapshotEvery, journal: journal, seq: seq}
+	return m.snapshotLocked()
+}
+
+// Snapshot compacts the journal into a fresh snapshot.
+func (m *MemoryBackend) Snapshot() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.persist == nil {
+		return nil
+	}
+	return m.snapshotLocked()
+}
+
+// Close writes a final snapshot and closes the journal.
+func (m *MemoryBackend) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.persist == nil {
+		return nil
+	}
+	err := m.snapshotLocked()
+	if closeErr := m.persist.journal.Close(); err == nil {
+		err = closeErr
+	}
+	m.persist = nil
+	return err
+}
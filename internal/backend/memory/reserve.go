@@ -7,10 +7,16 @@ import (
 	"cogni/pkg/ratelimiter"
 )
 
-const invalidRequestError = "invalid_request"
+const (
+	invalidRequestError = "invalid_request"
+	forbiddenLeaseError = "forbidden_lease"
+)
 
 // Reserve reserves capacity for the requested requirements.
 func (m *MemoryBackend) Reserve(_ context.Context, req ratelimiter.ReserveRequest, at time.Time) (ratelimiter.ReserveResponse, error) {
+	if len(req.Requirements) > 0 {
+		m.finishDrains(req.Requirements)
+	}
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -18,7 +24,7 @@ func (m *MemoryBackend) Reserve(_ context.Context, req ratelimiter.ReserveReques
 		return ratelimiter.ReserveResponse{Allowed: false, Error: invalidRequestError}, nil
 	}
 	if state, ok := m.leases[req.LeaseID]; ok {
-		if requirementsEqual(state.Requirements, req.Requirements) {
+		if state.Tenant == req.Tenant && requirementsEqual(state.Requirements, req.Requirements) {
 			return ratelimiter.ReserveResponse{
 				Allowed:          true,
 				ReservedAtUnixMs: state.ReservedAtUnix,
@@ -36,6 +42,13 @@ func (m *MemoryBackend) Reserve(_ context.Context, req ratelimiter.ReserveReques
 				Error:        "limit_decreasing:" + string(r.Key),
 			}, nil
 		}
+		if ok && state.Status == ratelimiter.LimitStatusDraining {
+			return ratelimiter.ReserveResponse{
+				Allowed:      false,
+				RetryAfterMs: decreaseRetryMs,
+				Error:        "limit_draining:" + string(r.Key),
+			}, nil
+		}
 	}
 	for _, r := range req.Requirements {
 		if _, ok := m.defs[r.Key]; !ok {
@@ -54,40 +67,36 @@ func (m *MemoryBackend) Reserve(_ context.Context, req ratelimiter.ReserveReques
 		}
 	}
 
+	tenant := shareTenant(req.Tenant, req.Requirements)
 	maxRetry := 0
 	for _, r := range req.Requirements {
 		def := m.defs[r.Key]
+		admitted := true
 		switch def.Kind {
 		case ratelimiter.KindRolling:
-			if m.roll[r.Key].used+r.Amount > m.roll[r.Key].cap {
-				maxRetry = maxInt(maxRetry, retryAfter(def))
-			}
+			limit := m.roll[r.Key]
+			admitted = m.admitsLocked(r.Key, limit.cap, limit.used, r.Amount, req.Priority) &&
+				m.fairShareAdmitsLocked(r.Key, limit.cap, tenant, r.Amount, now)
 		case ratelimiter.KindConcurrency:
-			if uint64(len(m.conc[r.Key].holds)+1) > m.conc[r.Key].cap {
-				maxRetry = maxInt(maxRetry, retryAfter(def))
-			}
+			limit := m.conc[r.Key]
+			admitted = m.admitsLocked(r.Key, limit.cap, uint64(len(limit.holds)), 1, req.Priority) &&
+				m.fairShareAdmitsLocked(r.Key, limit.cap, tenant, 1, now)
+		}
+		if !admitted {
+			maxRetry = maxInt(maxRetry, retryAfter(def))
+			m.noteWaitingLocked(r.Key, tenant, now)
 		}
 	}
 	if maxRetry > 0 {
 		return ratelimiter.ReserveResponse{Allowed: false, RetryAfterMs: maxRetry}, nil
 	}
 
-	for _, r := range req.Requirements {
-		def := m.defs[r.Key]
-		switch def.Kind {
-		case ratelimiter.KindRolling:
-			addRollingReservation(m.roll[r.Key], req.LeaseID, r.Amount, now.Add(time.Duration(def.WindowSeconds)*time.Second))
-		case ratelimiter.KindConcurrency:
-			addConcurrencyHold(m.conc[r.Key], req.LeaseID, now.Add(time.Duration(def.TimeoutSeconds)*time.Second))
-		}
-	}
-
-	m.leases[req.LeaseID] = LeaseState{
-		LeaseID:         req.LeaseID,
-		ReservedAtUnix:  now.UnixMilli(),
-		Requirements:    req.Requirements,
-		ReservedAmounts: indexByKey(req.Requirements),
+	record := m.newLeaseRecord(req, now)
+	if err := m.journalLocked(journalRecord{Op: opReserve, Lease: &record}); err != nil {
+		return ratelimiter.ReserveResponse{}, err
 	}
+	m.applyReserveLocked(record)
+	m.compactLocked()
 
 	return ratelimiter.ReserveResponse{Allowed: true, ReservedAtUnixMs: now.UnixMilli()}, nil
 }
@@ -10,10 +10,13 @@ type rollingLimit struct {
 	used uint64
 	heap reservationHeap
 	byID map[string]*reservation
+	// byTenant splits used by the tenant that reserved it, for fair share.
+	byTenant map[string]uint64
 }
 
 type reservation struct {
 	id        string
+	tenant    string
 	amount    uint64
 	expiresAt time.Time
 	heapIndex int
@@ -50,10 +53,11 @@ func (h *reservationHeap) Pop() interface{} {
 
 func newRollingLimit(capacity uint64) *rollingLimit {
 	return &rollingLimit{
-		cap:  capacity,
-		used: 0,
-		heap: reservationHeap{},
-		byID: map[string]*reservation{},
+		cap:      capacity,
+		used:     0,
+		heap:     reservationHeap{},
+		byID:     map[string]*reservation{},
+		byTenant: map[string]uint64{},
 	}
 }
 
@@ -65,21 +69,37 @@ func cleanupRolling(limit *rollingLimit, now time.Time) {
 		}
 		heap.Pop(&limit.heap)
 		delete(limit.byID, res.id)
-		if limit.used >= res.amount {
-			limit.used -= res.amount
-		} else {
-			limit.used = 0
-		}
+		limit.release(res.tenant, res.amount)
 	}
 }
 
-func addRollingReservation(limit *rollingLimit, leaseID string, amount uint64, expiresAt time.Time) {
-	res := &reservation{id: leaseID, amount: amount, expiresAt: expiresAt}
+func addRollingReservation(limit *rollingLimit, leaseID, tenant string, amount uint64, expiresAt time.Time) {
+	res := &reservation{id: leaseID, tenant: tenant, amount: amount, expiresAt: expiresAt}
 	limit.byID[leaseID] = res
 	limit.used += amount
+	if tenant != "" {
+		limit.byTenant[tenant] += amount
+	}
 	heap.Push(&limit.heap, res)
 }
 
+// release returns amount of tenant's usage to the limit.
+func (limit *rollingLimit) release(tenant string, amount uint64) {
+	if limit.used >= amount {
+		limit.used -= amount
+	} else {
+		limit.used = 0
+	}
+	if tenant == "" {
+		return
+	}
+	if held := limit.byTenant[tenant]; held > amount {
+		limit.byTenant[tenant] = held - amount
+	} else {
+		delete(limit.byTenant, tenant)
+	}
+}
+
 func reduceRollingReservation(limit *rollingLimit, leaseID string, newAmount uint64) {
 	res, ok := limit.byID[leaseID]
 	if !ok {
@@ -88,11 +108,6 @@ func reduceRollingReservation(limit *rollingLimit, leaseID string, newAmount uin
 	if newAmount >= res.amount {
 		return
 	}
-	diff := res.amount - newAmount
-	if limit.used >= diff {
-		limit.used -= diff
-	} else {
-		limit.used = 0
-	}
+	limit.release(res.tenant, res.amount-newAmount)
 	res.amount = newAmount
 }
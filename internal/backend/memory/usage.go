@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"cogni/internal/backend"
+	"cogni/pkg/ratelimiter"
+)
+
+// Usage reports capacity, usage, available capacity, in-flight reservations,
+// debt and decrease status per limit, ordered by key. Expired reservations
+// are released first.
+func (m *MemoryBackend) Usage(_ context.Context) ([]backend.LimitUsage, error) {
+	m.finishDrains(nil)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	inFlight := backend.InFlightByKey(m.leasesLocked())
+	usage := make([]backend.LimitUsage, 0, len(m.defs))
+	for key, def := range m.defs {
+		m.cleanupLocked(key)
+		state := m.states[key]
+		entry := backend.LimitUsage{
+			Key:               key,
+			Kind:              def.Kind,
+			Available:         m.availableCapacityLocked(key, def),
+			InFlight:          inFlight[key],
+			Debt:              m.debt[key],
+			Status:            state.Status,
+			PendingDecreaseTo: state.PendingDecreaseTo,
+		}
+		switch def.Kind {
+		case ratelimiter.KindRolling:
+			if limit, ok := m.roll[key]; ok {
+				entry.Capacity = limit.cap
+				entry.Used = limit.used
+			}
+		case ratelimiter.KindConcurrency:
+			if limit, ok := m.conc[key]; ok {
+				entry.Capacity = limit.cap
+				entry.Used = uint64(len(limit.holds))
+			}
+		}
+		if capacity := m.enforcedCapLocked(key, entry.Capacity); capacity < entry.Capacity {
+			entry.EffectiveCapacity = capacity
+			entry.Available = 0
+			if entry.Used < capacity {
+				entry.Available = capacity - entry.Used
+			}
+		}
+		usage = append(usage, entry)
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Key < usage[j].Key })
+	return usage, nil
+}
+
+// Leases reports uncompleted leases whose holds have not lapsed, ordered by
+// reservation time.
+func (m *MemoryBackend) Leases(_ context.Context) ([]backend.LeaseInfo, error) {
+	m.finishDrains(nil)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.leasesLocked(), nil
+}
+
+// leasesLocked builds the active lease views at the current clock time.
+func (m *MemoryBackend) leasesLocked() []backend.LeaseInfo {
+	now := m.clock.Now().UnixMilli()
+	lookup := func(key ratelimiter.LimitKey) (ratelimiter.LimitDefinition, bool) {
+		def, ok := m.defs[key]
+		return def, ok
+	}
+	leases := make([]backend.LeaseInfo, 0, len(m.leases))
+	for _, lease := range m.leases {
+		if info, ok := backend.ActiveLease(lease.LeaseID, lease.ReservedAtUnix, lease.ReservedAmounts, lookup, now); ok {
+			leases = append(leases, info)
+		}
+	}
+	backend.SortLeases(leases)
+	return leases
+}
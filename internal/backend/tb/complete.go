@@ -19,6 +19,10 @@ func (b *Backend) Complete(ctx context.Context, req ratelimiter.CompleteRequest)
 
 	b.mu.Lock()
 	state, ok := b.leases[req.LeaseID]
+	if ok && req.Tenant != "" && state.Tenant != req.Tenant {
+		b.mu.Unlock()
+		return ratelimiter.CompleteResponse{Ok: false, Error: forbiddenLeaseError}, nil
+	}
 	if ok {
 		delete(b.leases, req.LeaseID)
 	}
@@ -12,7 +12,8 @@ import (
 
 const decreaseCheckInterval = 200 * time.Millisecond
 
-// decreaseLoop periodically attempts to apply capacity decreases.
+// decreaseLoop periodically attempts to apply capacity decreases and finish
+// drains.
 func (b *Backend) decreaseLoop(ctx context.Context) {
 	ticker := time.NewTicker(decreaseCheckInterval)
 	defer ticker.Stop()
@@ -22,6 +23,7 @@ func (b *Backend) decreaseLoop(ctx context.Context) {
 			return
 		case <-ticker.C:
 			b.applyPendingDecreases(ctx)
+			b.applyPendingDrains(ctx)
 		}
 	}
 }
@@ -0,0 +1,98 @@
+package tb
+
+import (
+	"context"
+	"fmt"
+
+	"cogni/internal/backend"
+	"cogni/internal/tbutil"
+	"cogni/pkg/ratelimiter"
+	tbtypes "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// RemoveDefinition marks a limit draining and removes it once no uncompleted
+// lease holds it; the decrease loop retries until then. Unknown keys count as
+// removed.
+func (b *Backend) RemoveDefinition(ctx context.Context, key ratelimiter.LimitKey) (bool, error) {
+	b.mu.Lock()
+	state, ok := b.states[key]
+	if !ok {
+		b.mu.Unlock()
+		return true, nil
+	}
+	state.Status = ratelimiter.LimitStatusDraining
+	state.PendingDecreaseTo = 0
+	b.states[key] = state
+	b.mu.Unlock()
+	return b.tryFinishDrain(ctx, key)
+}
+
+// tryFinishDrain returns a drained limit's capacity to the operator account
+// and forgets the limit. TigerBeetle accounts cannot be deleted, so emptying
+// the balance lets a later create start from zero.
+func (b *Backend) tryFinishDrain(ctx context.Context, key ratelimiter.LimitKey) (bool, error) {
+	b.mu.Lock()
+	state, ok := b.states[key]
+	inFlight := backend.InFlightByKey(b.leasesLocked(b.nowFn()))[key]
+	b.mu.Unlock()
+	if !ok || state.Status != ratelimiter.LimitStatusDraining || inFlight > 0 {
+		return false, nil
+	}
+
+	account, err := b.lookupAccount(ctx, tbutil.LimitAccountID(key))
+	if err != nil {
+		return false, err
+	}
+	if tbutil.Uint128ToUint64(account.DebitsPending) > 0 {
+		return false, nil
+	}
+	if balance := accountBalance(account); balance > 0 {
+		transfer := tbtypes.Transfer{
+			// Credits only grow, so the ID is unique per drain of a key.
+			ID:              tbutil.ID128(fmt.Sprintf("xfer:drain:%s:%d", key, tbutil.Uint128ToUint64(account.CreditsPosted))),
+			DebitAccountID:  tbutil.LimitAccountID(key),
+			CreditAccountID: tbutil.OperatorAccountID(),
+			Ledger:          ledgerLimits,
+			Code:            codeLimit,
+			Amount:          tbutil.Uint128FromUint64(balance),
+		}
+		result, err := b.submitTransfers(ctx, []tbtypes.Transfer{transfer})
+		if err != nil {
+			return false, err
+		}
+		if err := firstTransferError(result.Errors); err != nil {
+			return false, err
+		}
+	}
+
+	b.mu.Lock()
+	if current, ok := b.states[key]; ok && current.Status == ratelimiter.LimitStatusDraining {
+		delete(b.states, key)
+	}
+	b.mu.Unlock()
+	return true, nil
+}
+
+// applyPendingDrains removes draining limits whose leases have finished and
+// drops them from the registry.
+func (b *Backend) applyPendingDrains(ctx context.Context) {
+	b.mu.Lock()
+	var keys []ratelimiter.LimitKey
+	for key, state := range b.states {
+		if state.Status == ratelimiter.LimitStatusDraining {
+			keys = append(keys, key)
+		}
+	}
+	b.mu.Unlock()
+
+	removed := false
+	for _, key := range keys {
+		if ok, err := b.tryFinishDrain(ctx, key); err == nil && ok && b.registry != nil {
+			b.registry.Delete(key)
+			removed = true
+		}
+	}
+	if removed && b.registryPath != "" {
+		_ = b.registry.Save(b.registryPath)
+	}
+}
@@ -8,6 +8,8 @@ type LeaseState struct {
 	ReservedAtUnix  int64
 	Requirements    []ratelimiter.Requirement
 	ReservedAmounts map[ratelimiter.LimitKey]uint64
+	// Tenant is the tenant that reserved the lease; empty when unscoped.
+	Tenant string
 }
 
 // indexByKey maps requirements to amounts.
@@ -10,7 +10,10 @@ import (
 	tbtypes "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
 )
 
-const invalidRequestError = "invalid_request"
+const (
+	invalidRequestError = "invalid_request"
+	forbiddenLeaseError = "forbidden_lease"
+)
 
 // Reserve attempts to create a linked chain of pending transfers.
 func (b *Backend) Reserve(ctx context.Context, req ratelimiter.ReserveRequest, now time.Time) (ratelimiter.ReserveResponse, error) {
@@ -24,7 +27,7 @@ func (b *Backend) Reserve(ctx context.Context, req ratelimiter.ReserveRequest, n
 	b.mu.Lock()
 	if state, ok := b.leases[req.LeaseID]; ok {
 		b.mu.Unlock()
-		if requirementsEqual(state.Requirements, req.Requirements) {
+		if state.Tenant == req.Tenant && requirementsEqual(state.Requirements, req.Requirements) {
 			return ratelimiter.ReserveResponse{Allowed: true, ReservedAtUnixMs: state.ReservedAtUnix}, nil
 		}
 		return ratelimiter.ReserveResponse{Allowed: false, Error: invalidRequestError}, nil
@@ -48,6 +51,14 @@ func (b *Backend) Reserve(ctx context.Context, req ratelimiter.ReserveRequest, n
 				Error:        "limit_decreasing:" + string(r.Key),
 			}, nil
 		}
+		if state.Status == ratelimiter.LimitStatusDraining {
+			b.mu.Unlock()
+			return ratelimiter.ReserveResponse{
+				Allowed:      false,
+				RetryAfterMs: b.retryPolicy.Decreasing.FixedMs,
+				Error:        "limit_draining:" + string(r.Key),
+			}, nil
+		}
 		defs[i] = state.Definition
 	}
 	b.mu.Unlock()
@@ -92,6 +103,7 @@ func (b *Backend) Reserve(ctx context.Context, req ratelimiter.ReserveRequest, n
 		ReservedAtUnix:  now.UnixMilli(),
 		Requirements:    req.Requirements,
 		ReservedAmounts: indexByKey(req.Requirements),
+		Tenant:          req.Tenant,
 	}
 	b.mu.Lock()
 	b.leases[req.LeaseID] = lease
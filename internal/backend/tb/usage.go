@@ -0,0 +1,101 @@
+package tb
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"cogni/internal/backend"
+	"cogni/internal/tbutil"
+	"cogni/pkg/ratelimiter"
+	tbtypes "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// Usage reports capacity, usage, available capacity, in-flight reservations,
+// debt and decrease status per limit, ordered by key. Capacity is the limit
+// account balance and usage its pending debits, looked up in a single
+// TigerBeetle request.
+func (b *Backend) Usage(ctx context.Context) ([]backend.LimitUsage, error) {
+	b.mu.Lock()
+	usage := make([]backend.LimitUsage, 0, len(b.states))
+	for key, state := range b.states {
+		usage = append(usage, backend.LimitUsage{
+			Key:               key,
+			Kind:              state.Definition.Kind,
+			Status:            state.Status,
+			PendingDecreaseTo: state.PendingDecreaseTo,
+		})
+	}
+	inFlight := backend.InFlightByKey(b.leasesLocked(b.nowFn()))
+	b.mu.Unlock()
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Key < usage[j].Key })
+
+	ids := make([]tbtypes.Uint128, 0, 2*len(usage))
+	for _, entry := range usage {
+		ids = append(ids, tbutil.LimitAccountID(entry.Key), tbutil.DebtAccountID(entry.Key))
+	}
+	accounts, err := b.lookupAccounts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[tbtypes.Uint128]tbtypes.Account, len(accounts))
+	for _, account := range accounts {
+		byID[account.ID] = account
+	}
+	for i := range usage {
+		entry := &usage[i]
+		entry.InFlight = inFlight[entry.Key]
+		if account, ok := byID[tbutil.LimitAccountID(entry.Key)]; ok {
+			entry.Capacity = accountBalance(account)
+			entry.Used = tbutil.Uint128ToUint64(account.DebitsPending)
+			entry.Available = accountAvailable(account)
+		}
+		if account, ok := byID[tbutil.DebtAccountID(entry.Key)]; ok {
+			entry.Debt = tbutil.Uint128ToUint64(account.DebitsPosted)
+		}
+	}
+	return usage, nil
+}
+
+// Leases reports uncompleted leases whose holds have not lapsed, ordered by
+// reservation time.
+func (b *Backend) Leases(_ context.Context) ([]backend.LeaseInfo, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.leasesLocked(b.nowFn()), nil
+}
+
+// leasesLocked builds the active lease views at now.
+func (b *Backend) leasesLocked(now time.Time) []backend.LeaseInfo {
+	lookup := func(key ratelimiter.LimitKey) (ratelimiter.LimitDefinition, bool) {
+		state, ok := b.states[key]
+		return state.Definition, ok
+	}
+	leases := make([]backend.LeaseInfo, 0, len(b.leases))
+	for _, lease := range b.leases {
+		if info, ok := backend.ActiveLease(lease.LeaseID, lease.ReservedAtUnix, lease.ReservedAmounts, lookup, now.UnixMilli()); ok {
+			leases = append(leases, info)
+		}
+	}
+	backend.SortLeases(leases)
+	return leases
+}
+
+// SubmitterQueueDepth returns the number of work items waiting for the
+// transfer submitter.
+func (b *Backend) SubmitterQueueDepth() int {
+	return len(b.submitter.In)
+}
+
+// lookupAccounts fetches accounts by ID; missing accounts are omitted.
+func (b *Backend) lookupAccounts(ctx context.Context, ids []tbtypes.Uint128) ([]tbtypes.Account, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	client, err := b.pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer b.pool.Release(client)
+	return tbutil.LookupAccounts(ctx, client, ids)
+}
@@ -0,0 +1,121 @@
+package bisect
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cogni/internal/runner"
+	"cogni/internal/spec"
+	"cogni/internal/vcs"
+)
+
+// Run binary-searches good..bad for the first commit where the target fails.
+// Both endpoints are checked first so a misidentified range fails fast.
+func Run(ctx context.Context, cfg spec.Config, params Params) (Result, error) {
+	if strings.TrimSpace(params.TaskID) == "" {
+		return Result{}, fmt.Errorf("task id is required")
+	}
+	if params.Evaluate == nil {
+		params.Evaluate = runner.RunAndWrite
+	}
+	// Every verdict, recorded or fresh, comes from the same agent.
+	params.AgentID = targetAgent(cfg, params)
+	commits, err := vcs.ResolveRange(ctx, params.RepoRoot, vcs.RangeSpec{Start: params.Good, End: params.Bad})
+	if err != nil {
+		return Result{}, err
+	}
+	if len(commits.Commits) == 0 {
+		return Result{}, fmt.Errorf("no commits between %s and %s", params.Good, params.Bad)
+	}
+	result := Result{Good: commits.Start, Bad: commits.End, Candidates: len(commits.Commits)}
+	check := func(commit string) (bool, error) {
+		step, err := checkCommit(ctx, cfg, params, commit)
+		if err != nil {
+			return false, err
+		}
+		result.Steps = append(result.Steps, step)
+		if params.OnStep != nil {
+			params.OnStep(step)
+		}
+		return step.Passing, nil
+	}
+
+	passing, err := check(commits.Start)
+	if err != nil {
+		return result, err
+	}
+	if !passing {
+		return result, fmt.Errorf("good ref %s does not pass", params.Good)
+	}
+	passing, err = check(commits.End)
+	if err != nil {
+		return result, err
+	}
+	if passing {
+		return result, fmt.Errorf("bad ref %s does not fail", params.Bad)
+	}
+
+	// Invariant: commits[low] passes (low == -1 is the good ref) and commits[high] fails.
+	low, high := -1, len(commits.Commits)-1
+	for high-low > 1 {
+		mid := (low + high) / 2
+		passing, err := check(commits.Commits[mid])
+		if err != nil {
+			return result, err
+		}
+		if passing {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	result.FirstBad = commits.Commits[high]
+	return result, nil
+}
+
+// checkCommit reuses a recorded verdict for commit or evaluates it.
+func checkCommit(ctx context.Context, cfg spec.Config, params Params, commit string) (Step, error) {
+	if step, ok, err := findExisting(params, commit); err != nil || ok {
+		return step, err
+	}
+	runParams := params.RunParams
+	runParams.RepoRoot = params.RepoRoot
+	runParams.OutputDir = params.OutputDir
+	runParams.Rev = commit
+	runParams.Selectors = []runner.TaskSelector{{TaskID: params.TaskID, AgentID: params.AgentID}}
+	results, _, err := params.Evaluate(ctx, cfg, runParams)
+	if err != nil {
+		return Step{}, fmt.Errorf("evaluate %s: %w", commit, err)
+	}
+	passing, ok := verdict(results, params.AgentID, params.TaskID, params.QuestionID)
+	if !ok {
+		return Step{}, fmt.Errorf("evaluate %s: no usable result for %s (errored, cancelled or skipped)", commit, target(params))
+	}
+	return Step{Commit: commit, Passing: passing, RunID: results.RunID}, nil
+}
+
+// targetAgent returns the agent whose verdicts bisect compares: the requested
+// agent, else the task's configured agent, else the default agent.
+func targetAgent(cfg spec.Config, params Params) string {
+	if params.AgentID != "" {
+		return params.AgentID
+	}
+	if params.RunParams.AgentOverride != "" {
+		return params.RunParams.AgentOverride
+	}
+	for _, task := range cfg.Tasks {
+		if task.ID == params.TaskID && task.Agent != "" {
+			return task.Agent
+		}
+	}
+	return cfg.DefaultAgent
+}
+
+// target describes what is being bisected for messages.
+func target(params Params) string {
+	if params.QuestionID != "" {
+		return fmt.Sprintf("task %s question %s", params.TaskID, params.QuestionID)
+	}
+	return fmt.Sprintf("task %s", params.TaskID)
+}
@@ -0,0 +1,215 @@
+package bisect
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cogni/internal/runner"
+	"cogni/internal/spec"
+	"cogni/internal/testutil"
+)
+
+// git runs a git command in dir and returns trimmed output.
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v: %s", args, err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// buildHistory commits one state file per entry and returns the commit hashes.
+func buildHistory(t *testing.T, states []string) (string, []string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	root := t.TempDir()
+	git(t, root, "init", "-q")
+	commits := make([]string, 0, len(states))
+	for i, state := range states {
+		if err := os.WriteFile(filepath.Join(root, "state"), []byte(state), 0o644); err != nil {
+			t.Fatalf("write state: %v", err)
+		}
+		git(t, root, "add", "-A")
+		git(t, root, "commit", "-q", "--allow-empty", "-m", fmt.Sprintf("commit %d", i))
+		commits = append(commits, git(t, root, "rev-parse", "HEAD"))
+	}
+	return root, commits
+}
+
+// stateEvaluator scores q1 correct when the committed state file says "good".
+func stateEvaluator(t *testing.T, root string, evaluated *[]string) Evaluator {
+	return func(_ context.Context, _ spec.Config, params runner.RunParams) (runner.Results, runner.OutputPaths, error) {
+		*evaluated = append(*evaluated, params.Rev)
+		if len(params.Selectors) != 1 || params.Selectors[0].TaskID != "core" {
+			t.Fatalf("unexpected selectors: %+v", params.Selectors)
+		}
+		correct := git(t, root, "show", params.Rev+":state") == "good"
+		return questionResults(params.Rev, "run-new", correct), runner.OutputPaths{}, nil
+	}
+}
+
+// questionResults builds results for task core with a single question q1.
+func questionResults(commit, runID string, correct bool) runner.Results {
+	status := "fail"
+	if correct {
+		status = "pass"
+	}
+	return runner.Results{
+		RunID: runID,
+		Repo:  runner.RepoMetadata{Commit: commit},
+		Tasks: []runner.TaskResult{{
+			TaskID: "core",
+			Status: status,
+			QuestionEval: &runner.QuestionEval{Questions: []runner.QuestionResult{
+				{ID: "q1", Correct: correct},
+			}},
+		}},
+	}
+}
+
+// TestBisectFindsFirstFailingCommit verifies the search reports the first
+// failing commit and reuses recorded results instead of re-evaluating.
+func TestBisectFindsFirstFailingCommit(t *testing.T) {
+	root, commits := buildHistory(t, []string{"good", "good", "good", "good", "bad", "bad", "bad", "bad"})
+	outputDir := filepath.Join(root, "out")
+	if _, err := runner.WriteRunOutputs(questionResults(commits[0], "run-old", true), outputDir); err != nil {
+		t.Fatalf("write outputs: %v", err)
+	}
+
+	var evaluated []string
+	var steps []Step
+	result, err := Run(testutil.Context(t, 0), spec.Config{}, Params{
+		RepoRoot:   root,
+		OutputDir:  outputDir,
+		Good:       commits[0],
+		Bad:        commits[len(commits)-1],
+		TaskID:     "core",
+		QuestionID: "q1",
+		Evaluate:   stateEvaluator(t, root, &evaluated),
+		OnStep:     func(step Step) { steps = append(steps, step) },
+	})
+	if err != nil {
+		t.Fatalf("bisect: %v", err)
+	}
+	if result.FirstBad != commits[4] {
+		t.Fatalf("expected first bad %s, got %s", commits[4], result.FirstBad)
+	}
+	if result.Candidates != len(commits)-1 {
+		t.Fatalf("unexpected candidates: %d", result.Candidates)
+	}
+	if len(steps) == 0 || !steps[0].Reused || steps[0].Commit != commits[0] {
+		t.Fatalf("expected good ref verdict to be reused, got %+v", steps)
+	}
+	for _, commit := range evaluated {
+		if commit == commits[0] {
+			t.Fatalf("expected recorded commit not to be evaluated again")
+		}
+	}
+	if len(evaluated) > 4 {
+		t.Fatalf("expected a logarithmic number of evaluations, got %d", len(evaluated))
+	}
+}
+
+// TestBisectRejectsPassingBadRef verifies a bad ref that passes is reported.
+func TestBisectRejectsPassingBadRef(t *testing.T) {
+	root, commits := buildHistory(t, []string{"good", "good"})
+	var evaluated []string
+	_, err := Run(testutil.Context(t, 0), spec.Config{}, Params{
+		RepoRoot:  root,
+		OutputDir: filepath.Join(root, "out"),
+		Good:      commits[0],
+		Bad:       commits[1],
+		TaskID:    "core",
+		Evaluate:  stateEvaluator(t, root, &evaluated),
+	})
+	if err == nil || !strings.Contains(err.Error(), "does not fail") {
+		t.Fatalf("expected bad ref error, got %v", err)
+	}
+}
+
+// TestFindExistingSkipsUnusableRuns verifies interrupted runs and errored,
+// cancelled or skipped targets are evaluated again instead of read as failures.
+func TestFindExistingSkipsUnusableRuns(t *testing.T) {
+	outputDir := t.TempDir()
+	interrupted := questionResults("abc", "run-1", false)
+	interrupted.Incomplete = true
+	if _, err := runner.WriteRunOutputs(interrupted, outputDir); err != nil {
+		t.Fatalf("write outputs: %v", err)
+	}
+	params := Params{OutputDir: outputDir, TaskID: "core", QuestionID: "q1"}
+	if _, ok, err := findExisting(params, "abc"); err != nil || ok {
+		t.Fatalf("expected incomplete run to be ignored, got ok=%t err=%v", ok, err)
+	}
+
+	cases := map[string]runner.QuestionResult{
+		"runtime error": {ID: "q1", RunError: "provider unavailable", FailureReason: runner.QuestionStatusRuntimeError},
+		"cancelled":     {ID: "q1", Cancelled: true},
+		"skipped":       {ID: "q1", Skipped: "budget_exceeded"},
+	}
+	for name, question := range cases {
+		results := questionResults("abc", "run-2", false)
+		results.Tasks[0].QuestionEval.Questions[0] = question
+		if _, ok := verdict(results, "", "core", "q1"); ok {
+			t.Fatalf("%s: expected no verdict for the question", name)
+		}
+	}
+	for _, status := range []string{"error", "cancelled", "skipped"} {
+		results := questionResults("abc", "run-2", false)
+		results.Tasks[0].Status = status
+		results.Tasks[0].QuestionEval = nil
+		if _, ok := verdict(results, "", "core", ""); ok {
+			t.Fatalf("%s: expected no verdict for the task", status)
+		}
+	}
+	budget := questionResults("abc", "run-2", false)
+	budget.Tasks[0].QuestionEval.Questions[0].FailureReason = runner.QuestionStatusBudgetExceeded
+	if passing, ok := verdict(budget, "", "core", "q1"); !ok || passing {
+		t.Fatalf("expected a budget failure to count as failing, got passing=%t ok=%t", passing, ok)
+	}
+}
+
+// TestFindExistingUsesTheBisectedAgent verifies a multi-agent run only lends
+// the verdict of the agent being bisected, falling back to the task's agent.
+func TestFindExistingUsesTheBisectedAgent(t *testing.T) {
+	outputDir := t.TempDir()
+	results := questionResults("abc", "run-1", true)
+	results.Agents = []runner.AgentInfo{{ID: "agent-a"}, {ID: "agent-b"}}
+	failing := questionResults("abc", "run-1", false).Tasks[0]
+	results.Tasks[0].AgentID = "agent-a"
+	failing.AgentID = "agent-b"
+	results.Tasks = append(results.Tasks, failing)
+	if _, err := runner.WriteRunOutputs(results, outputDir); err != nil {
+		t.Fatalf("write outputs: %v", err)
+	}
+
+	for agentID, want := range map[string]bool{"agent-a": true, "agent-b": false} {
+		step, ok, err := findExisting(Params{OutputDir: outputDir, TaskID: "core", QuestionID: "q1", AgentID: agentID}, "abc")
+		if err != nil || !ok || step.Passing != want {
+			t.Fatalf("%s: expected passing=%t, got %+v ok=%t err=%v", agentID, want, step, ok, err)
+		}
+	}
+	if _, ok, err := findExisting(Params{OutputDir: outputDir, TaskID: "core", AgentID: "agent-c"}, "abc"); err != nil || ok {
+		t.Fatalf("expected no verdict for an agent the run did not use, got ok=%t err=%v", ok, err)
+	}
+
+	cfg := spec.Config{
+		DefaultAgent: "agent-a",
+		Tasks:        []spec.TaskConfig{{ID: "core", Agent: "agent-b"}},
+	}
+	if got := targetAgent(cfg, Params{TaskID: "core"}); got != "agent-b" {
+		t.Fatalf("expected the task's agent, got %q", got)
+	}
+	if got := targetAgent(cfg, Params{TaskID: "other"}); got != "agent-a" {
+		t.Fatalf("expected the default agent, got %q", got)
+	}
+}
@@ -0,0 +1,2 @@
+// Package bisect finds the first commit where a task or question started failing.
+package bisect
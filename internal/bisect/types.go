@@ -0,0 +1,46 @@
+package bisect
+
+import (
+	"context"
+
+	"cogni/internal/runner"
+	"cogni/internal/spec"
+)
+
+// Evaluator runs the target at a commit and returns its results.
+type Evaluator func(ctx context.Context, cfg spec.Config, params runner.RunParams) (runner.Results, runner.OutputPaths, error)
+
+// Params configures a bisect search.
+type Params struct {
+	RepoRoot   string
+	OutputDir  string
+	Good       string
+	Bad        string
+	TaskID     string
+	QuestionID string
+	AgentID    string
+	// RunParams is the template for evaluations; Rev, RepoRoot, OutputDir and
+	// Selectors are filled in per commit.
+	RunParams runner.RunParams
+	Evaluate  Evaluator
+	// OnStep is called after each commit is checked.
+	OnStep func(Step)
+}
+
+// Step records the verdict for one checked commit.
+type Step struct {
+	Commit  string
+	Passing bool
+	Reused  bool
+	RunID   string
+}
+
+// Result reports the outcome of a bisect search.
+type Result struct {
+	Good     string
+	Bad      string
+	FirstBad string
+	// Candidates is the number of commits in good..bad.
+	Candidates int
+	Steps      []Step
+}
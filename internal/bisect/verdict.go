@@ -0,0 +1,65 @@
+package bisect
+
+import (
+	"path/filepath"
+
+	"cogni/internal/report"
+	"cogni/internal/runner"
+)
+
+// findExisting returns the verdict from the newest recorded run of commit that
+// covers the target with the bisected agent, so commits evaluated earlier are
+// not re-run. Interrupted runs are never reused.
+func findExisting(params Params, commit string) (Step, bool, error) {
+	runDirs, err := report.CommitRuns(params.OutputDir, commit)
+	if err != nil {
+		return Step{}, false, err
+	}
+	for _, runDir := range runDirs {
+		results, err := report.LoadResults(filepath.Join(runDir, "results.json"))
+		if err != nil || results.Incomplete {
+			continue
+		}
+		if passing, ok := verdict(results, params.AgentID, params.TaskID, params.QuestionID); ok {
+			return Step{Commit: commit, Passing: passing, Reused: true, RunID: results.RunID}, true, nil
+		}
+	}
+	return Step{}, false, nil
+}
+
+// verdict reports whether the task (or one of its questions) passed for
+// agentID in results. The second value is false when results do not cover the
+// target or the target errored, was cancelled or was skipped, since that says
+// nothing about the code.
+func verdict(results runner.Results, agentID, taskID, questionID string) (bool, bool) {
+	for _, task := range results.Tasks {
+		if task.TaskID != taskID || task.AgentID != agentID {
+			continue
+		}
+		if questionID == "" {
+			// A task that evaluated only some of its questions says nothing about the whole task.
+			if task.QuestionEval != nil && task.QuestionEval.QuestionsAvailable > 0 {
+				return false, false
+			}
+			switch task.Status {
+			case "error", "cancelled", "skipped":
+				return false, false
+			}
+			return task.Status == "pass", true
+		}
+		if task.QuestionEval == nil {
+			return false, false
+		}
+		for _, question := range task.QuestionEval.Questions {
+			if question.ID != questionID {
+				continue
+			}
+			switch runner.QuestionStatus(question) {
+			case runner.QuestionStatusRuntimeError, runner.QuestionStatusCancelled, runner.QuestionStatusSkipped:
+				return false, false
+			}
+			return question.Correct, true
+		}
+	}
+	return false, false
+}
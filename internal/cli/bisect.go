@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"cogni/internal/bisect"
+	"cogni/internal/config"
+	"cogni/internal/runner"
+)
+
+// runBisect is a test seam for the bisect search.
+var runBisect = bisect.Run
+
+// runBisectCommand builds the handler for the bisect command.
+func runBisectCommand(cmd *Command) func(args []string, stdout, stderr io.Writer) int {
+	return func(args []string, stdout, stderr io.Writer) int {
+		if wantsHelp(args) {
+			printCommandUsage(cmd, stdout)
+			return ExitOK
+		}
+		fs := flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
+		fs.SetOutput(stderr)
+		specPath := fs.String("spec", "", "Path to config file (default: search for .cogni/config.yml)")
+		good := fs.String("good", "", "Ref where the task or question passes")
+		bad := fs.String("bad", "HEAD", "Ref where the task or question fails")
+		taskID := fs.String("task", "", "Task id to evaluate")
+		questionID := fs.String("question", "", "Question id within the task (default: whole task status)")
+		agentID := fs.String("agent", "", "Agent id override")
+		outputDir := fs.String("output-dir", "", "Override output directory")
+		if err := fs.Parse(args); err != nil {
+			return ExitUsage
+		}
+		if strings.TrimSpace(*good) == "" || strings.TrimSpace(*taskID) == "" {
+			fmt.Fprintln(stderr, "Missing --good or --task")
+			return ExitUsage
+		}
+
+		resolvedSpec, err := resolveSpecPath(*specPath)
+		if err != nil {
+			fmt.Fprintf(stderr, "Failed to locate config: %v\n", err)
+			return ExitError
+		}
+		cfg, err := config.Load(resolvedSpec)
+		if err != nil {
+			fmt.Fprintf(stderr, "Failed to load config: %v\n", err)
+			return ExitError
+		}
+		repoRoot := config.RepoRootFromConfigPath(resolvedSpec)
+		resolvedOutput := *outputDir
+		if strings.TrimSpace(resolvedOutput) == "" {
+			resolvedOutput = cfg.Repo.OutputDir
+		}
+		if !filepath.IsAbs(resolvedOutput) {
+			resolvedOutput = filepath.Join(repoRoot, resolvedOutput)
+		}
+
+		result, err := runBisect(context.Background(), cfg, bisect.Params{
+			RepoRoot:   repoRoot,
+			OutputDir:  resolvedOutput,
+			Good:       *good,
+			Bad:        *bad,
+			TaskID:     *taskID,
+			QuestionID: *questionID,
+			AgentID:    *agentID,
+			RunParams:  runner.RunParams{AgentOverride: *agentID},
+			OnStep: func(step bisect.Step) {
+				printBisectStep(stdout, step)
+			},
+		})
+		if err != nil {
+			fmt.Fprintf(stderr, "Bisect failed: %v\n", err)
+			return ExitError
+		}
+		fmt.Fprintf(stdout, "Checked %d of %d commits\n", len(result.Steps), result.Candidates+1)
+		fmt.Fprintf(stdout, "First failing commit: %s\n", result.FirstBad)
+		return ExitOK
+	}
+}
+
+// printBisectStep prints the verdict for one checked commit.
+func printBisectStep(w io.Writer, step bisect.Step) {
+	verdict := "fail"
+	if step.Passing {
+		verdict = "pass"
+	}
+	source := "evaluated"
+	if step.Reused {
+		source = "reused"
+	}
+	fmt.Fprintf(w, "%s %s (%s run %s)\n", shortCommit(step.Commit), verdict, source, step.RunID)
+}
+
+// shortCommit abbreviates a commit hash for display.
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cogni/internal/bisect"
+	"cogni/internal/spec"
+)
+
+// TestBisectCommand verifies flag parsing and the reported first failing commit.
+func TestBisectCommand(t *testing.T) {
+	repoRoot := t.TempDir()
+	specPath := filepath.Join(repoRoot, ".cogni", "config.yml")
+	specBody := `version: 1
+repo:
+  output_dir: "./out"
+agents:
+  - id: default
+    type: builtin
+    provider: openrouter
+    model: test-model
+default_agent: default
+tasks:
+  - id: core
+    type: question_eval
+    agent: default
+    questions_file: "questions.yml"
+`
+	if err := os.MkdirAll(filepath.Dir(specPath), 0o755); err != nil {
+		t.Fatalf("create config dir: %v", err)
+	}
+	if err := os.WriteFile(specPath, []byte(specBody), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+	questionsBody := `version: 1
+questions:
+  - id: q1
+    question: "What is 1+1?"
+    answers: ["2"]
+    correct_answers: ["2"]
+`
+	if err := os.WriteFile(filepath.Join(repoRoot, "questions.yml"), []byte(questionsBody), 0o644); err != nil {
+		t.Fatalf("write questions file: %v", err)
+	}
+
+	var gotParams bisect.Params
+	origBisect := runBisect
+	runBisect = func(_ context.Context, _ spec.Config, params bisect.Params) (bisect.Result, error) {
+		gotParams = params
+		params.OnStep(bisect.Step{Commit: "aaaaaaaaaaaaaaaa", Passing: true, Reused: true, RunID: "run-1"})
+		params.OnStep(bisect.Step{Commit: "bbbbbbbbbbbbbbbb", Passing: false, RunID: "run-2"})
+		return bisect.Result{FirstBad: "bbbbbbbbbbbbbbbb", Candidates: 3, Steps: make([]bisect.Step, 2)}, nil
+	}
+	t.Cleanup(func() { runBisect = origBisect })
+
+	cmd := findCommand("bisect")
+	if cmd == nil {
+		t.Fatalf("bisect command not found")
+	}
+	var stdout, stderr bytes.Buffer
+	exitCode := cmd.Run([]string{"--spec", specPath, "--good", "v1", "--task", "core", "--question", "q1"}, &stdout, &stderr)
+	if exitCode != ExitOK {
+		t.Fatalf("unexpected exit: %d, stderr: %s", exitCode, stderr.String())
+	}
+	if gotParams.Good != "v1" || gotParams.Bad != "HEAD" || gotParams.TaskID != "core" || gotParams.QuestionID != "q1" {
+		t.Fatalf("unexpected params: %+v", gotParams)
+	}
+	if gotParams.OutputDir != filepath.Join(repoRoot, "out") {
+		t.Fatalf("unexpected output dir: %s", gotParams.OutputDir)
+	}
+	output := stdout.String()
+	for _, want := range []string{"aaaaaaaaaaaa pass (reused run run-1)", "bbbbbbbbbbbb fail (evaluated run run-2)", "First failing commit: bbbbbbbbbbbbbbbb"} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output, got %q", want, output)
+		}
+	}
+
+	if exitCode := cmd.Run([]string{"--spec", specPath, "--task", "core"}, &stdout, &stderr); exitCode != ExitUsage {
+		t.Fatalf("expected usage error without --good, got %d", exitCode)
+	}
+}
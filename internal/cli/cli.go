@@ -129,22 +129,36 @@ var commands = []*Command{
 		"cogni init --spec <path>",
 	}, runInit),
 	command("validate", "Validate .cogni config and schemas", []string{
-		"cogni validate [--spec <path>]",
+		"cogni validate [--spec <path>] [--questions]",
 	}, runValidate),
 	command("run", "Execute benchmark tasks", []string{
 		"cogni run [task-id|task-id@agent-id]...",
+		"cogni run [task-id#question-id|task-id[tag=name]]... [--limit N] [--sample N --seed S]",
 		"cogni run --verbose [task-id|task-id@agent-id]...",
 		"cogni run --verbose --no-color [task-id|task-id@agent-id]...",
+		"cogni run --agents <id,id,...> [task-id]...",
+		"cogni run --rev <commit> [task-id|task-id@agent-id]...",
+		"cogni run --resume <run-id>",
+		"cogni run --rerun-failed <run-id|commit> [--rerun-status runtime_error,parse_error,incorrect]",
 	}, runRun),
 	command("eval", "Evaluate a question spec", []string{
 		"cogni eval <questions_file> --agent <id>",
+		"cogni eval <questions_file> --agent <id> [--question id,...|--tag name,...] [--limit N] [--sample N --seed S]",
 		"cogni eval <questions_file> --agent <id> --verbose",
 		"cogni eval <questions_file> --agent <id> --no-color",
 	}, runEval),
+	command("questions", "Draft question specs from the repository", []string{
+		"cogni questions generate [--agent <id>] [--paths <path,...>] [--count <n>]",
+		"cogni questions generate --verify-agent <id> [--output <path>] [--force]",
+	}, runQuestions),
 	command("compare", "Compare runs between commits", []string{
 		"cogni compare --base <commit|run-id|ref> [--head <commit|run-id|ref>]",
 		"cogni compare --range <start>..<end>",
 	}, runCompare),
+	command("bisect", "Find the commit where a task or question started failing", []string{
+		"cogni bisect --good <ref> [--bad <ref>] --task <task-id> [--question <question-id>]",
+		"cogni bisect --good <ref> --task <task-id> --agent <id>",
+	}, runBisectCommand),
 	command("serve", "Serve a browser report from a DuckDB file", []string{
 		"cogni serve <db.duckdb>",
 		"cogni serve <db.duckdb> --addr <host:port>",
@@ -153,4 +167,13 @@ var commands = []*Command{
 	command("report", "Generate HTML reports", []string{
 		"cogni report --range <start>..<end>",
 	}, runReport),
+	command("limits", "Manage limits on a remote ratelimiterd", []string{
+		"cogni limits <subcommand> [--server <url>] [--token-env <name>] ...",
+		"cogni limits list [--json]",
+		"cogni limits get [--json] <key>",
+		"cogni limits put -f <limit.json>",
+		"cogni limits apply -f <limits.json> [--dry-run] [--json]",
+		"cogni limits diff -f <limits.json> [--json]",
+		"cogni limits usage [--json] <key>",
+	}, runLimits),
 }
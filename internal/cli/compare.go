@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"strings"
 
 	"cogni/internal/config"
 	"cogni/internal/report"
+	"cogni/internal/runner"
 	"cogni/internal/vcs"
 )
 
@@ -79,6 +81,9 @@ func runCompare(cmd *Command) func(args []string, stdout, stderr io.Writer) int
 			return ExitError
 		}
 
+		warnPartialRun(stderr, "Base", baseResults)
+		warnPartialRun(stderr, "Head", headResults)
+
 		passDelta := headResults.Summary.PassRate - baseResults.Summary.PassRate
 		tokenDelta := headResults.Summary.TokensTotal - baseResults.Summary.TokensTotal
 
@@ -89,6 +94,25 @@ func runCompare(cmd *Command) func(args []string, stdout, stderr io.Writer) int
 	}
 }
 
+// warnPartialRun notes when a compared run evaluated only some questions.
+func warnPartialRun(w io.Writer, label string, results runner.Results) {
+	selection := results.Selection
+	if selection == nil {
+		return
+	}
+	var parts []string
+	if len(selection.Selectors) > 0 {
+		parts = append(parts, "selectors "+strings.Join(selection.Selectors, " "))
+	}
+	if selection.Sample > 0 {
+		parts = append(parts, fmt.Sprintf("sample %d seed %d", selection.Sample, selection.Seed))
+	}
+	if selection.Limit > 0 {
+		parts = append(parts, fmt.Sprintf("limit %d", selection.Limit))
+	}
+	fmt.Fprintf(w, "Warning: %s run %s is partial (%s)\n", strings.ToLower(label), results.RunID, strings.Join(parts, ", "))
+}
+
 // resolveInputDir determines the output directory and repo root.
 func resolveInputDir(inputDir, specPath string) (string, string, error) {
 	if inputDir != "" {
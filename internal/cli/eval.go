@@ -1,7 +1,6 @@
 package cli
 
 import (
-	"context"
 	"flag"
 	"fmt"
 	"io"
@@ -24,6 +23,11 @@ var evalFlagsRequiringValue = map[string]bool{
 	"output-dir": true,
 	"log":        true,
 	"ui":         true,
+	"question":   true,
+	"tag":        true,
+	"limit":      true,
+	"sample":     true,
+	"seed":       true,
 }
 
 var evalFlagsWithoutValue = map[string]bool{
@@ -53,6 +57,11 @@ func runEval(cmd *Command) func(args []string, stdout, stderr io.Writer) int {
 		logPath := fs.String("log", "", "Write verbose logs to a file")
 		noColor := fs.Bool("no-color", false, "Disable ANSI colors in verbose logs")
 		uiMode := fs.String("ui", "auto", "UI mode: auto, live, plain")
+		questionIDs := fs.String("question", "", "Comma-separated question ids to evaluate")
+		tags := fs.String("tag", "", "Comma-separated tags; evaluate questions carrying any of them")
+		limit := fs.Int("limit", 0, "Evaluate at most N questions")
+		sample := fs.Int("sample", 0, "Evaluate N randomly sampled questions")
+		seed := fs.Int64("seed", 0, "Random seed for --sample")
 		if err := fs.Parse(normalizedArgs); err != nil {
 			return ExitUsage
 		}
@@ -109,6 +118,15 @@ func runEval(cmd *Command) func(args []string, stdout, stderr io.Writer) int {
 			return ExitError
 		}
 
+		var selectors []runner.TaskSelector
+		if ids, tagList := splitList(*questionIDs), splitList(*tags); len(ids) > 0 || len(tagList) > 0 {
+			if len(ids) > 0 && len(tagList) > 0 {
+				fmt.Fprintln(stderr, "--question and --tag cannot be combined")
+				return ExitUsage
+			}
+			selectors = []runner.TaskSelector{{TaskID: "question-eval", QuestionIDs: ids, Tags: tagList}}
+		}
+
 		var logFile io.WriteCloser
 		if strings.TrimSpace(*logPath) != "" {
 			dir := filepath.Dir(*logPath)
@@ -152,9 +170,13 @@ func runEval(cmd *Command) func(args []string, stdout, stderr io.Writer) int {
 			observer = uiController
 		}
 
-		results, paths, err := runEvalAndWrite(context.Background(), evalConfig, runner.RunParams{
+		ctx, stopSignals := interruptContext(stderr)
+		defer stopSignals()
+		results, paths, err := runEvalAndWrite(ctx, evalConfig, runner.RunParams{
 			RepoRoot:         repoRoot,
 			OutputDir:        *outputDir,
+			Selectors:        selectors,
+			Sampling:         runner.QuestionSampling{Limit: *limit, Sample: *sample, Seed: *seed},
 			Verbose:          *verbose,
 			VerboseWriter:    stdout,
 			VerboseLogWriter: logFile,
@@ -167,21 +189,23 @@ func runEval(cmd *Command) func(args []string, stdout, stderr io.Writer) int {
 			return ExitError
 		}
 
-		fmt.Fprintf(stdout, "Run %s completed\n", results.RunID)
+		if results.Incomplete {
+			fmt.Fprintf(stdout, "Run %s cancelled; partial results written\n", results.RunID)
+		} else {
+			fmt.Fprintf(stdout, "Run %s completed\n", results.RunID)
+		}
 		for _, task := range results.Tasks {
 			if task.QuestionEval == nil {
 				continue
 			}
-			summary := task.QuestionEval.Summary
-			fmt.Fprintf(stdout, "Question task %s accuracy: %d/%d (%.1f%%)\n",
-				task.TaskID,
-				summary.QuestionsCorrect,
-				summary.QuestionsTotal,
-				summary.Accuracy*100,
-			)
+			printQuestionAccuracy(stdout, task.TaskID, task.QuestionEval.Summary)
+			printPositionAccuracy(stdout, task.QuestionEval)
 		}
 		fmt.Fprintf(stdout, "Results: %s\n", paths.ResultsPath())
 		fmt.Fprintf(stdout, "Report: %s\n", paths.ReportPath())
+		if results.Incomplete {
+			return ExitError
+		}
 		return ExitOK
 	}
 }
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"cogni/internal/runner"
+)
+
+// printLeaderboard prints the per-agent standings of a matrix run.
+func printLeaderboard(w io.Writer, standings []runner.AgentStanding) {
+	if len(standings) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "Leaderboard:")
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "  #\tAGENT\tMODEL\tACCURACY\tPASSED\tTOKENS\tCOST\tWALL TIME")
+	for i, standing := range standings {
+		cost := "-"
+		if standing.CostUSD != nil {
+			cost = fmt.Sprintf("$%.4f", *standing.CostUSD)
+		}
+		fmt.Fprintf(table, "  %d\t%s\t%s\t%.1f%%\t%d/%d\t%d\t%s\t%.1fs\n",
+			i+1,
+			standing.AgentID,
+			standing.Model,
+			standing.Accuracy*100,
+			standing.TasksPassed,
+			standing.TasksTotal,
+			standing.TokensTotal,
+			cost,
+			standing.WallTimeSeconds,
+		)
+	}
+	_ = table.Flush()
+}
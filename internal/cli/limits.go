@@ -0,0 +1,357 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"cogni/pkg/ratelimiter"
+	"cogni/pkg/ratelimiter/httpclient"
+)
+
+// Environment variables supplying defaults for the limits command.
+const (
+	limitsServerEnv       = "COGNI_RATE_LIMITER_URL"
+	limitsTokenEnvDefault = "COGNI_RATE_LIMITER_TOKEN"
+	limitsServerDefault   = "http://127.0.0.1:8080"
+)
+
+// limitsClient is the subset of the ratelimiterd admin API used by the limits command.
+type limitsClient interface {
+	ListLimits(ctx context.Context) ([]ratelimiter.LimitState, error)
+	GetLimit(ctx context.Context, key ratelimiter.LimitKey) (ratelimiter.LimitState, error)
+	PutLimit(ctx context.Context, def ratelimiter.LimitDefinition) (ratelimiter.LimitStatus, error)
+	ApplyLimits(ctx context.Context, req ratelimiter.ApplyLimitsRequest) (ratelimiter.ApplyLimitsResponse, error)
+	LimitUsage(ctx context.Context, key ratelimiter.LimitKey) (ratelimiter.LimitUsageResponse, error)
+}
+
+// newLimitsClient is a test seam for constructing the admin client.
+var newLimitsClient = func(server string, opts httpclient.Options) limitsClient {
+	return httpclient.NewWithOptions(server, opts)
+}
+
+// limitsOptions holds flags shared by every limits subcommand.
+type limitsOptions struct {
+	server   string
+	tokenEnv string
+	timeout  time.Duration
+	json     bool
+}
+
+// runLimits builds the handler for the limits command.
+func runLimits(cmd *Command) func(args []string, stdout, stderr io.Writer) int {
+	return func(args []string, stdout, stderr io.Writer) int {
+		if len(args) == 0 || isHelpArg(args[0]) {
+			printCommandUsage(cmd, stdout)
+			if len(args) == 0 {
+				return ExitUsage
+			}
+			return ExitOK
+		}
+		switch args[0] {
+		case "list":
+			return runLimitsList(cmd, args[1:], stdout, stderr)
+		case "get":
+			return runLimitsGet(cmd, args[1:], stdout, stderr)
+		case "put":
+			return runLimitsPut(cmd, args[1:], stdout, stderr)
+		case "apply":
+			return runLimitsApply(cmd, args[1:], false, stdout, stderr)
+		case "diff":
+			return runLimitsApply(cmd, args[1:], true, stdout, stderr)
+		case "usage":
+			return runLimitsUsage(cmd, args[1:], stdout, stderr)
+		default:
+			fmt.Fprintf(stderr, "Unknown limits subcommand: %s\n", args[0])
+			printCommandUsage(cmd, stderr)
+			return ExitUsage
+		}
+	}
+}
+
+// limitsFlagSet registers the flags shared by every limits subcommand.
+func limitsFlagSet(name string, stderr io.Writer) (*flag.FlagSet, *limitsOptions) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	opts := &limitsOptions{}
+	server := strings.TrimSpace(os.Getenv(limitsServerEnv))
+	if server == "" {
+		server = limitsServerDefault
+	}
+	fs.StringVar(&opts.server, "server", server, "ratelimiterd base URL (default $"+limitsServerEnv+")")
+	fs.StringVar(&opts.tokenEnv, "token-env", limitsTokenEnvDefault, "Environment variable holding an admin bearer token")
+	fs.DurationVar(&opts.timeout, "timeout", 10*time.Second, "Request timeout")
+	fs.BoolVar(&opts.json, "json", false, "Print JSON instead of a table")
+	return fs, opts
+}
+
+// parseLimitsFlags parses subcommand flags and checks the positional argument count.
+func parseLimitsFlags(cmd *Command, fs *flag.FlagSet, args []string, wantArgs int, stdout, stderr io.Writer) (bool, int) {
+	if wantsHelp(args) {
+		printCommandUsage(cmd, stdout)
+		return false, ExitOK
+	}
+	if err := fs.Parse(args); err != nil {
+		return false, ExitUsage
+	}
+	if fs.NArg() < wantArgs {
+		fmt.Fprintln(stderr, "Missing <key>")
+		return false, ExitUsage
+	}
+	if fs.NArg() > wantArgs {
+		fmt.Fprintf(stderr, "Unexpected arguments: %s\n", strings.Join(fs.Args()[wantArgs:], " "))
+		return false, ExitUsage
+	}
+	return true, ExitOK
+}
+
+// client builds an admin client from the shared flags.
+func (o *limitsOptions) client() (limitsClient, error) {
+	server := strings.TrimSpace(o.server)
+	if server == "" {
+		return nil, fmt.Errorf("missing --server")
+	}
+	httpOpts := httpclient.Options{Timeout: o.timeout}
+	if env := strings.TrimSpace(o.tokenEnv); env != "" {
+		httpOpts.Token = strings.TrimSpace(os.Getenv(env))
+		if httpOpts.Token == "" && env != limitsTokenEnvDefault {
+			return nil, fmt.Errorf("token environment variable %s is not set", env)
+		}
+	}
+	return newLimitsClient(server, httpOpts), nil
+}
+
+// runLimitsList prints every registered limit.
+func runLimitsList(cmd *Command, args []string, stdout, stderr io.Writer) int {
+	fs, opts := limitsFlagSet(cmd.Name+" list", stderr)
+	if ok, code := parseLimitsFlags(cmd, fs, args, 0, stdout, stderr); !ok {
+		return code
+	}
+	client, err := opts.client()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return ExitUsage
+	}
+	states, err := client.ListLimits(context.Background())
+	if err != nil {
+		fmt.Fprintf(stderr, "List limits failed: %v\n", err)
+		return ExitError
+	}
+	if opts.json {
+		return writeLimitsJSON(stdout, stderr, states)
+	}
+	printLimitStates(stdout, states)
+	return ExitOK
+}
+
+// runLimitsGet prints one limit.
+func runLimitsGet(cmd *Command, args []string, stdout, stderr io.Writer) int {
+	fs, opts := limitsFlagSet(cmd.Name+" get", stderr)
+	if ok, code := parseLimitsFlags(cmd, fs, args, 1, stdout, stderr); !ok {
+		return code
+	}
+	client, err := opts.client()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return ExitUsage
+	}
+	state, err := client.GetLimit(context.Background(), ratelimiter.LimitKey(fs.Arg(0)))
+	if err != nil {
+		fmt.Fprintf(stderr, "Get limit failed: %v\n", err)
+		return ExitError
+	}
+	if opts.json {
+		return writeLimitsJSON(stdout, stderr, state)
+	}
+	printLimitStates(stdout, []ratelimiter.LimitState{state})
+	return ExitOK
+}
+
+// runLimitsPut creates or updates a single limit read from a JSON file.
+func runLimitsPut(cmd *Command, args []string, stdout, stderr io.Writer) int {
+	fs, opts := limitsFlagSet(cmd.Name+" put", stderr)
+	file := fs.String("f", "", "JSON file with one limit definition (- for stdin)")
+	if ok, code := parseLimitsFlags(cmd, fs, args, 0, stdout, stderr); !ok {
+		return code
+	}
+	if strings.TrimSpace(*file) == "" {
+		fmt.Fprintln(stderr, "Missing -f <limit.json>")
+		return ExitUsage
+	}
+	data, err := readLimitsFile(*file)
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to read limit: %v\n", err)
+		return ExitError
+	}
+	defs, err := parseLimitDefinitions(data)
+	if err != nil || len(defs) != 1 {
+		if err == nil {
+			err = fmt.Errorf("expected one limit, got %d", len(defs))
+		}
+		fmt.Fprintf(stderr, "Invalid limit file: %v\n", err)
+		return ExitError
+	}
+	client, err := opts.client()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return ExitUsage
+	}
+	status, err := client.PutLimit(context.Background(), defs[0])
+	if err != nil {
+		fmt.Fprintf(stderr, "Put limit failed: %v\n", err)
+		return ExitError
+	}
+	if opts.json {
+		return writeLimitsJSON(stdout, stderr, map[string]any{"key": defs[0].Key, "status": status})
+	}
+	fmt.Fprintf(stdout, "%s: %s\n", defs[0].Key, status)
+	if status == ratelimiter.LimitStatusDecreasing {
+		fmt.Fprintf(stdout, "  capacity lowers to %d once in-flight usage drains; new reservations wait until then\n", defs[0].Capacity)
+	}
+	return ExitOK
+}
+
+// runLimitsApply replaces the server's limits with a file's set, or only
+// prints the plan when dryRun is set.
+func runLimitsApply(cmd *Command, args []string, dryRun bool, stdout, stderr io.Writer) int {
+	name := "apply"
+	if dryRun {
+		name = "diff"
+	}
+	fs, opts := limitsFlagSet(cmd.Name+" "+name, stderr)
+	file := fs.String("f", "", "JSON file with the full limit set (- for stdin)")
+	if !dryRun {
+		fs.BoolVar(&dryRun, "dry-run", false, "Print the plan without applying it")
+	}
+	if ok, code := parseLimitsFlags(cmd, fs, args, 0, stdout, stderr); !ok {
+		return code
+	}
+	if strings.TrimSpace(*file) == "" {
+		fmt.Fprintln(stderr, "Missing -f <limits.json>")
+		return ExitUsage
+	}
+	data, err := readLimitsFile(*file)
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to read limits: %v\n", err)
+		return ExitError
+	}
+	defs, err := parseLimitDefinitions(data)
+	if err != nil {
+		fmt.Fprintf(stderr, "Invalid limits file: %v\n", err)
+		return ExitError
+	}
+	client, err := opts.client()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return ExitUsage
+	}
+	res, err := client.ApplyLimits(context.Background(), ratelimiter.ApplyLimitsRequest{Limits: defs, DryRun: dryRun})
+	if err != nil {
+		fmt.Fprintf(stderr, "Apply limits failed: %v\n", err)
+		printApplyFailure(stderr, res)
+		return ExitError
+	}
+	if opts.json {
+		return writeLimitsJSON(stdout, stderr, res)
+	}
+	printLimitChanges(stdout, res)
+	return ExitOK
+}
+
+// runLimitsUsage prints a limit's usage and the leases holding it.
+func runLimitsUsage(cmd *Command, args []string, stdout, stderr io.Writer) int {
+	fs, opts := limitsFlagSet(cmd.Name+" usage", stderr)
+	if ok, code := parseLimitsFlags(cmd, fs, args, 1, stdout, stderr); !ok {
+		return code
+	}
+	client, err := opts.client()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return ExitUsage
+	}
+	usage, err := client.LimitUsage(context.Background(), ratelimiter.LimitKey(fs.Arg(0)))
+	if err != nil {
+		fmt.Fprintf(stderr, "Get usage failed: %v\n", err)
+		return ExitError
+	}
+	if opts.json {
+		return writeLimitsJSON(stdout, stderr, usage)
+	}
+	printLimitUsage(stdout, usage)
+	return ExitOK
+}
+
+// readLimitsFile reads a limits file, or stdin for "-".
+func readLimitsFile(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
+
+// limitFileEntry accepts either a bare definition or a registry state that
+// wraps one under "definition".
+type limitFileEntry struct {
+	ratelimiter.LimitDefinition
+	Definition *ratelimiter.LimitDefinition `json:"definition"`
+}
+
+// parseLimitDefinitions decodes a limits file: a single definition, an array
+// of definitions or registry states, or an object with a "limits" array.
+func parseLimitDefinitions(data []byte) ([]ratelimiter.LimitDefinition, error) {
+	trimmed := bytes.TrimSpace(data)
+	var entries []limitFileEntry
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("[")):
+		if err := json.Unmarshal(trimmed, &entries); err != nil {
+			return nil, err
+		}
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		var wrapper struct {
+			Limits []limitFileEntry `json:"limits"`
+		}
+		if err := json.Unmarshal(trimmed, &wrapper); err != nil {
+			return nil, err
+		}
+		if wrapper.Limits != nil {
+			entries = wrapper.Limits
+			break
+		}
+		var entry limitFileEntry
+		if err := json.Unmarshal(trimmed, &entry); err != nil {
+			return nil, err
+		}
+		entries = []limitFileEntry{entry}
+	default:
+		return nil, fmt.Errorf("expected a JSON object or array")
+	}
+	defs := make([]ratelimiter.LimitDefinition, 0, len(entries))
+	for i, entry := range entries {
+		def := entry.LimitDefinition
+		if entry.Definition != nil {
+			def = *entry.Definition
+		}
+		if strings.TrimSpace(string(def.Key)) == "" {
+			return nil, fmt.Errorf("limit %d has no key", i)
+		}
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
+
+// writeLimitsJSON prints an indented JSON document.
+func writeLimitsJSON(stdout, stderr io.Writer, value any) int {
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		fmt.Fprintf(stderr, "Failed to encode JSON: %v\n", err)
+		return ExitError
+	}
+	return ExitOK
+}
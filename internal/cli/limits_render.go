@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"cogni/pkg/ratelimiter"
+)
+
+// printLimitStates prints limits as a table, explaining pending decreases.
+func printLimitStates(w io.Writer, states []ratelimiter.LimitState) {
+	if len(states) == 0 {
+		fmt.Fprintln(w, "No limits registered.")
+		return
+	}
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "KEY\tKIND\tCAPACITY\tWINDOW\tOVERAGE\tSTATUS")
+	pending := 0
+	for _, state := range states {
+		def := state.Definition
+		if state.Status == ratelimiter.LimitStatusDecreasing {
+			pending++
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			def.Key,
+			def.Kind,
+			formatCapacity(def.Capacity, def.Unit),
+			formatLimitWindow(def),
+			def.Overage,
+			formatLimitStatus(state.Status, state.PendingDecreaseTo),
+		)
+	}
+	_ = table.Flush()
+	if pending > 0 {
+		fmt.Fprintf(w, "\n%d decreasing: reservations are denied until usage falls to the new capacity.\n", pending)
+	}
+}
+
+// printLimitUsage prints one limit's usage followed by the leases holding it.
+func printLimitUsage(w io.Writer, res ratelimiter.LimitUsageResponse) {
+	usage := res.Usage
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(table, "Key:\t%s\n", usage.Key)
+	fmt.Fprintf(table, "Kind:\t%s\n", usage.Kind)
+	fmt.Fprintf(table, "Status:\t%s\n", formatLimitStatus(usage.Status, usage.PendingDecreaseTo))
+	fmt.Fprintf(table, "Capacity:\t%d\n", usage.Capacity)
+	if usage.EffectiveCapacity > 0 && usage.EffectiveCapacity != usage.Capacity {
+		fmt.Fprintf(table, "Effective:\t%d\n", usage.EffectiveCapacity)
+	}
+	fmt.Fprintf(table, "Used:\t%d\n", usage.Used)
+	fmt.Fprintf(table, "Available:\t%d\n", usage.Available)
+	fmt.Fprintf(table, "In flight:\t%d\n", usage.InFlight)
+	fmt.Fprintf(table, "Debt:\t%d\n", usage.Debt)
+	_ = table.Flush()
+	if usage.Status == ratelimiter.LimitStatusDecreasing && usage.Used > usage.PendingDecreaseTo {
+		fmt.Fprintf(w, "Waiting for %d to drain before capacity drops to %d.\n", usage.Used-usage.PendingDecreaseTo, usage.PendingDecreaseTo)
+	}
+	if adj := res.Adjustment; adj != nil {
+		fmt.Fprintf(w, "Backed off to %.0f%% after %d provider throttle(s); next recovery %s.\n",
+			adj.Fraction*100, adj.Throttles, formatUnixMs(adj.NextRecoveryAtUnixMs))
+	}
+	if len(res.Holds) == 0 {
+		fmt.Fprintln(w, "\nNo leases hold this limit.")
+		return
+	}
+	fmt.Fprintln(w, "\nHolds:")
+	table = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "  LEASE\tAMOUNT\tRESERVED\tEXPIRES")
+	for _, hold := range res.Holds {
+		fmt.Fprintf(table, "  %s\t%d\t%s\t%s\n",
+			hold.LeaseID,
+			hold.Amount,
+			formatUnixMs(hold.ReservedAtUnixMs),
+			formatUnixMs(hold.ExpiresAtUnixMs),
+		)
+	}
+	_ = table.Flush()
+}
+
+// printLimitChanges prints the plan or result of applying a limit set.
+func printLimitChanges(w io.Writer, res ratelimiter.ApplyLimitsResponse) {
+	for _, change := range res.Changes {
+		switch change.Action {
+		case ratelimiter.LimitChangeCreate:
+			fmt.Fprintf(w, "+ %s: create, capacity %d\n", change.Key, afterCapacity(change))
+		case ratelimiter.LimitChangeUpdate:
+			fmt.Fprintf(w, "~ %s: update%s\n", change.Key, describeLimitUpdate(change))
+		case ratelimiter.LimitChangeDecrease:
+			fmt.Fprintf(w, "v %s: decrease capacity %d -> %d (decreasing until usage drains)\n", change.Key, beforeCapacity(change), afterCapacity(change))
+		case ratelimiter.LimitChangeDelete:
+			fmt.Fprintf(w, "- %s: delete (draining until in-flight leases complete)\n", change.Key)
+		default:
+			fmt.Fprintf(w, "? %s: %s\n", change.Key, change.Action)
+		}
+	}
+	verb := "Applied"
+	if res.DryRun {
+		verb = "Plan:"
+	}
+	fmt.Fprintf(w, "%s %d change(s), %d unchanged.\n", verb, len(res.Changes), res.Unchanged)
+}
+
+// printApplyFailure explains what a failed limit set left behind.
+func printApplyFailure(w io.Writer, res ratelimiter.ApplyLimitsResponse) {
+	if res.FailedKey == "" {
+		return
+	}
+	fmt.Fprintf(w, "Change to %s failed; earlier changes were rolled back.\n", res.FailedKey)
+	for _, change := range res.Applied {
+		fmt.Fprintf(w, "  still applied: %s %s\n", change.Action, change.Key)
+	}
+}
+
+// describeLimitUpdate lists the fields an update changes.
+func describeLimitUpdate(change ratelimiter.LimitChange) string {
+	if change.Before == nil || change.After == nil {
+		return ""
+	}
+	before, after := *change.Before, *change.After
+	var fields []string
+	if before.Capacity != after.Capacity {
+		fields = append(fields, fmt.Sprintf("capacity %d -> %d", before.Capacity, after.Capacity))
+	}
+	if before.Kind != after.Kind {
+		fields = append(fields, fmt.Sprintf("kind %s -> %s", before.Kind, after.Kind))
+	}
+	if before.WindowSeconds != after.WindowSeconds || before.TimeoutSeconds != after.TimeoutSeconds {
+		fields = append(fields, fmt.Sprintf("window %s -> %s", formatLimitWindow(before), formatLimitWindow(after)))
+	}
+	if before.Overage != after.Overage {
+		fields = append(fields, fmt.Sprintf("overage %s -> %s", before.Overage, after.Overage))
+	}
+	if before.Unit != after.Unit || before.Description != after.Description {
+		fields = append(fields, "metadata")
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	return " " + strings.Join(fields, ", ")
+}
+
+// formatLimitStatus renders a status, including the target of a pending decrease.
+func formatLimitStatus(status ratelimiter.LimitStatus, pendingDecreaseTo uint64) string {
+	if status == "" {
+		status = ratelimiter.LimitStatusActive
+	}
+	if status == ratelimiter.LimitStatusDecreasing {
+		return fmt.Sprintf("decreasing -> %d", pendingDecreaseTo)
+	}
+	return string(status)
+}
+
+// formatLimitWindow renders the rolling window or concurrency timeout.
+func formatLimitWindow(def ratelimiter.LimitDefinition) string {
+	switch {
+	case def.WindowSeconds > 0:
+		return (time.Duration(def.WindowSeconds) * time.Second).String()
+	case def.TimeoutSeconds > 0:
+		return "timeout " + (time.Duration(def.TimeoutSeconds) * time.Second).String()
+	default:
+		return "-"
+	}
+}
+
+// formatCapacity appends the unit to a capacity when one is set.
+func formatCapacity(capacity uint64, unit string) string {
+	if unit == "" {
+		return fmt.Sprintf("%d", capacity)
+	}
+	return fmt.Sprintf("%d %s", capacity, unit)
+}
+
+// formatUnixMs renders a millisecond timestamp in UTC.
+func formatUnixMs(ms int64) string {
+	if ms == 0 {
+		return "-"
+	}
+	return time.UnixMilli(ms).UTC().Format(time.RFC3339)
+}
+
+func beforeCapacity(change ratelimiter.LimitChange) uint64 {
+	if change.Before == nil {
+		return 0
+	}
+	return change.Before.Capacity
+}
+
+func afterCapacity(change ratelimiter.LimitChange) uint64 {
+	if change.After == nil {
+		return 0
+	}
+	return change.After.Capacity
+}
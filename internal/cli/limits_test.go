@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"cogni/internal/api"
+	"cogni/internal/backend/memory"
+	"cogni/internal/registry"
+	"cogni/internal/testutil"
+	"cogni/pkg/ratelimiter"
+	"cogni/pkg/ratelimiter/httpclient"
+)
+
+// TestLimitsCommandManagesRemoteLimits drives put, diff, apply, list and usage against a live API.
+func TestLimitsCommandManagesRemoteLimits(t *testing.T) {
+	clock := testutil.NewFakeClock(time.Unix(0, 0))
+	reg := registry.New()
+	backend := memory.New(clock)
+	backend.AttachRegistry(reg, "")
+	srv := httptest.NewServer(api.NewHandler(api.Config{Registry: reg, Backend: backend, Now: clock.Now}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	limitPath := filepath.Join(dir, "limit.json")
+	writeLimitsFixture(t, limitPath, `{"key":"org/conc","kind":"concurrency","capacity":2,"timeout_seconds":30}`)
+	run := func(args ...string) string {
+		t.Helper()
+		var stdout, stderr bytes.Buffer
+		full := append([]string{"limits", args[0], "--server", srv.URL}, args[1:]...)
+		if code := Run(full, &stdout, &stderr); code != ExitOK {
+			t.Fatalf("%v: exit %d: %s", args, code, stderr.String())
+		}
+		return stdout.String()
+	}
+
+	if out := run("put", "-f", limitPath); !strings.Contains(out, "org/conc: active") {
+		t.Fatalf("unexpected put output: %s", out)
+	}
+	client := httpclient.New(srv.URL)
+	for _, lease := range []string{"L1", "L2"} {
+		res, err := client.Reserve(context.Background(), ratelimiter.ReserveRequest{LeaseID: lease, Requirements: []ratelimiter.Requirement{{Key: "org/conc", Amount: 1}}})
+		if err != nil || !res.Allowed {
+			t.Fatalf("reserve %s: %+v %v", lease, res, err)
+		}
+	}
+
+	setPath := filepath.Join(dir, "limits.json")
+	writeLimitsFixture(t, setPath, `{"limits":[
+		{"key":"org/conc","kind":"concurrency","capacity":1,"timeout_seconds":30},
+		{"key":"org/rpm","kind":"rolling","capacity":60,"window_seconds":60}
+	]}`)
+	diff := run("diff", "-f", setPath)
+	if !strings.Contains(diff, "v org/conc: decrease capacity 2 -> 1") || !strings.Contains(diff, "+ org/rpm: create") || !strings.Contains(diff, "Plan: 2 change(s)") {
+		t.Fatalf("unexpected diff output: %s", diff)
+	}
+	if _, ok := reg.Get("org/rpm"); ok {
+		t.Fatalf("diff must not apply changes")
+	}
+	if out := run("apply", "-f", setPath); !strings.Contains(out, "Applied 2 change(s)") {
+		t.Fatalf("unexpected apply output: %s", out)
+	}
+
+	list := run("list")
+	if !strings.Contains(list, "decreasing -> 1") || !strings.Contains(list, "1 decreasing") {
+		t.Fatalf("expected pending decrease in list: %s", list)
+	}
+	usage := run("usage", "org/conc")
+	if !strings.Contains(usage, "decreasing -> 1") || !strings.Contains(usage, "Waiting for 1 to drain") || !strings.Contains(usage, "L2") {
+		t.Fatalf("unexpected usage output: %s", usage)
+	}
+
+	var state ratelimiter.LimitState
+	if err := json.Unmarshal([]byte(run("get", "--json", "org/conc")), &state); err != nil {
+		t.Fatalf("parse get json: %v", err)
+	}
+	if state.Status != ratelimiter.LimitStatusDecreasing || state.PendingDecreaseTo != 1 {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+}
+
+// TestParseLimitDefinitionsAcceptsRegistryStates ensures a saved registry file can be applied as-is.
+func TestParseLimitDefinitionsAcceptsRegistryStates(t *testing.T) {
+	defs, err := parseLimitDefinitions([]byte(`[{"definition":{"key":"a","kind":"rolling","capacity":5,"window_seconds":60},"status":"active"},{"key":"b","kind":"concurrency","capacity":1,"timeout_seconds":5}]`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(defs) != 2 || defs[0].Key != "a" || defs[0].Capacity != 5 || defs[1].Key != "b" {
+		t.Fatalf("unexpected definitions: %+v", defs)
+	}
+	if _, err := parseLimitDefinitions([]byte(`[{"kind":"rolling"}]`)); err == nil {
+		t.Fatalf("expected error for a limit without a key")
+	}
+}
+
+func writeLimitsFixture(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
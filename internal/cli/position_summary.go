@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"cogni/internal/runner"
+)
+
+// printPositionAccuracy prints accuracy by presented answer position for shuffled tasks.
+func printPositionAccuracy(w io.Writer, eval *runner.QuestionEval) {
+	if eval == nil || eval.AnswerOrder == nil || !eval.AnswerOrder.Shuffled {
+		return
+	}
+	parts := make([]string, 0, len(eval.Summary.PositionAccuracy))
+	for _, stat := range eval.Summary.PositionAccuracy {
+		if stat.Questions == 0 && stat.Chosen == 0 {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("#%d %d/%d chosen=%d", stat.Position, stat.Correct, stat.Questions, stat.Chosen))
+	}
+	if len(parts) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "  by position (seed %d): %s\n", eval.AnswerOrder.Seed, strings.Join(parts, ", "))
+}
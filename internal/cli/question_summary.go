@@ -0,0 +1,22 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"cogni/internal/runner"
+)
+
+// printQuestionAccuracy prints a question task's accuracy over its scored
+// questions and how many were skipped or cancelled.
+func printQuestionAccuracy(w io.Writer, label string, summary runner.QuestionSummary) {
+	fmt.Fprintf(w, "Question task %s accuracy: %d/%d (%.1f%%)\n",
+		label,
+		summary.QuestionsCorrect,
+		summary.QuestionsScored(),
+		summary.Accuracy*100,
+	)
+	if summary.QuestionsSkipped > 0 || summary.QuestionsCancelled > 0 {
+		fmt.Fprintf(w, "  Not scored: %d skipped, %d cancelled\n", summary.QuestionsSkipped, summary.QuestionsCancelled)
+	}
+}
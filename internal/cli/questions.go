@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cogni/internal/config"
+	"cogni/internal/questiongen"
+	"cogni/internal/spec"
+)
+
+// defaultDraftPath is where generated question drafts are written, relative to the repo root.
+const defaultDraftPath = ".cogni/questions/draft.yml"
+
+// generateQuestions is a test seam for question generation.
+var generateQuestions = questiongen.Generate
+
+// verifyQuestions is a test seam for candidate verification.
+var verifyQuestions = questiongen.Verify
+
+// runQuestions builds the handler for the questions command.
+func runQuestions(cmd *Command) func(args []string, stdout, stderr io.Writer) int {
+	return func(args []string, stdout, stderr io.Writer) int {
+		if len(args) == 0 || isHelpArg(args[0]) {
+			printCommandUsage(cmd, stdout)
+			if len(args) == 0 {
+				return ExitUsage
+			}
+			return ExitOK
+		}
+		switch args[0] {
+		case "generate":
+			return runQuestionsGenerate(cmd, args[1:], stdout, stderr)
+		default:
+			fmt.Fprintf(stderr, "Unknown questions subcommand: %s\n", args[0])
+			printCommandUsage(cmd, stderr)
+			return ExitUsage
+		}
+	}
+}
+
+// runQuestionsGenerate drafts a question spec using the configured agent.
+func runQuestionsGenerate(cmd *Command, args []string, stdout, stderr io.Writer) int {
+	if wantsHelp(args) {
+		printCommandUsage(cmd, stdout)
+		return ExitOK
+	}
+	fs := flag.NewFlagSet(cmd.Name+" generate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	specPath := fs.String("spec", "", "Path to config file (default: search for .cogni/config.yml)")
+	agentID := fs.String("agent", "", "Agent id that proposes questions (defaults to config default_agent)")
+	verifyAgentID := fs.String("verify-agent", "", "Agent id that must answer each candidate correctly before it is accepted")
+	paths := fs.String("paths", "", "Comma-separated repository paths to focus on")
+	count := fs.Int("count", 10, "Number of questions to propose")
+	outputPath := fs.String("output", "", "Draft spec path (default: "+defaultDraftPath+" in the repo)")
+	force := fs.Bool("force", false, "Overwrite an existing draft spec")
+	verbose := fs.Bool("verbose", false, "Verbose logging")
+	noColor := fs.Bool("no-color", false, "Disable ANSI colors in verbose logs")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "Unexpected arguments: %s\n", strings.Join(fs.Args(), " "))
+		return ExitUsage
+	}
+	if *count < 1 {
+		fmt.Fprintln(stderr, "--count must be >= 1")
+		return ExitUsage
+	}
+
+	resolvedSpec, err := resolveSpecPath(*specPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to locate config: %v\n", err)
+		return ExitError
+	}
+	cfg, err := config.Load(resolvedSpec)
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to load config: %v\n", err)
+		return ExitError
+	}
+	repoRoot := config.RepoRootFromConfigPath(resolvedSpec)
+
+	selectedAgent := strings.TrimSpace(*agentID)
+	if selectedAgent == "" {
+		selectedAgent = strings.TrimSpace(cfg.DefaultAgent)
+	}
+	agentConfig, ok := findAgent(cfg, selectedAgent)
+	if !ok {
+		if selectedAgent == "" {
+			fmt.Fprintln(stderr, "Missing --agent (no default_agent configured)")
+			return ExitUsage
+		}
+		fmt.Fprintf(stderr, "Unknown agent %q\n", selectedAgent)
+		return ExitUsage
+	}
+	verifyAgent := strings.TrimSpace(*verifyAgentID)
+	if verifyAgent != "" {
+		if _, ok := findAgent(cfg, verifyAgent); !ok {
+			fmt.Fprintf(stderr, "Unknown verify agent %q\n", verifyAgent)
+			return ExitUsage
+		}
+	}
+
+	focusPaths := splitList(*paths)
+	for _, path := range focusPaths {
+		if _, err := os.Stat(filepath.Join(repoRoot, path)); err != nil {
+			fmt.Fprintf(stderr, "Path not found in repo: %s\n", path)
+			return ExitUsage
+		}
+	}
+
+	draftPath := filepath.Join(repoRoot, defaultDraftPath)
+	if strings.TrimSpace(*outputPath) != "" {
+		draftPath, err = filepath.Abs(*outputPath)
+		if err != nil {
+			fmt.Fprintf(stderr, "Failed to resolve output path: %v\n", err)
+			return ExitError
+		}
+	}
+	if _, err := os.Stat(draftPath); err == nil && !*force {
+		fmt.Fprintf(stderr, "Draft spec already exists at %s (use --force to overwrite)\n", draftPath)
+		return ExitError
+	}
+
+	ctx := context.Background()
+	fmt.Fprintf(stdout, "Generating %d questions with agent %s...\n", *count, agentConfig.ID)
+	result, err := generateQuestions(ctx, questiongen.Params{
+		RepoRoot: repoRoot,
+		Agent:    agentConfig,
+		Paths:    focusPaths,
+		Count:    *count,
+		Verbose:  *verbose,
+		NoColor:  *noColor,
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "Question generation failed: %v\n", err)
+		return ExitError
+	}
+	if verifyAgent != "" {
+		fmt.Fprintf(stdout, "Verifying candidates with agent %s...\n", verifyAgent)
+		result.Candidates, err = verifyQuestions(ctx, cfg, result.Candidates, questiongen.VerifyParams{
+			RepoRoot: repoRoot,
+			AgentID:  verifyAgent,
+		})
+		if err != nil {
+			fmt.Fprintf(stderr, "Question verification failed: %v\n", err)
+			return ExitError
+		}
+	}
+
+	printCandidates(stdout, result.Candidates)
+	accepted := result.Accepted()
+	fmt.Fprintf(stdout, "Accepted %d of %d proposed questions (tokens=%d)\n", len(accepted), len(result.Candidates), result.Tokens)
+	if len(accepted) == 0 {
+		fmt.Fprintln(stderr, "No questions accepted; draft not written")
+		return ExitError
+	}
+	if err := questiongen.WriteSpec(draftPath, accepted); err != nil {
+		fmt.Fprintf(stderr, "Failed to write draft spec: %v\n", err)
+		return ExitError
+	}
+	fmt.Fprintf(stdout, "Draft spec written to %s\n", displayPath(repoRoot, draftPath))
+	return ExitOK
+}
+
+// printCandidates lists each candidate with its acceptance status and issues.
+func printCandidates(w io.Writer, candidates []questiongen.Candidate) {
+	for _, candidate := range candidates {
+		status := "accepted"
+		switch {
+		case len(candidate.Issues) > 0:
+			status = "invalid"
+		case candidate.Verification != nil && !candidate.Verification.Correct:
+			status = "rejected"
+		}
+		fmt.Fprintf(w, "  %-8s %s %s\n", status, candidate.Question.ID, firstLine(candidate.Question.Prompt))
+		for _, issue := range candidate.Issues {
+			fmt.Fprintf(w, "           %s\n", issue)
+		}
+		if verification := candidate.Verification; verification != nil && !verification.Correct {
+			detail := fmt.Sprintf("answered %q", verification.Answer)
+			if verification.Error != "" {
+				detail = verification.Error
+			}
+			fmt.Fprintf(w, "           %s: %s\n", verification.AgentID, detail)
+		}
+	}
+}
+
+// findAgent returns the agent config with the given id.
+func findAgent(cfg spec.Config, id string) (spec.AgentConfig, bool) {
+	for _, agentConfig := range cfg.Agents {
+		if agentConfig.ID == id {
+			return agentConfig, true
+		}
+	}
+	return spec.AgentConfig{}, false
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
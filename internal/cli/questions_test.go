@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cogni/internal/question"
+	"cogni/internal/questiongen"
+	"cogni/internal/spec"
+)
+
+// TestQuestionsGenerateWritesAcceptedDraft verifies only accepted candidates
+// reach the draft spec and existing drafts require --force.
+func TestQuestionsGenerateWritesAcceptedDraft(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, ".cogni", "config.yml")
+	if err := os.MkdirAll(filepath.Dir(specPath), 0o755); err != nil {
+		t.Fatalf("create config dir: %v", err)
+	}
+	config := []byte(`version: 1
+repo:
+  output_dir: "./out"
+agents:
+  - id: writer
+    type: builtin
+    provider: openrouter
+    model: model
+  - id: checker
+    type: builtin
+    provider: openrouter
+    model: model
+default_agent: writer
+`)
+	if err := os.WriteFile(specPath, config, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	origGenerate, origVerify := generateQuestions, verifyQuestions
+	t.Cleanup(func() { generateQuestions, verifyQuestions = origGenerate, origVerify })
+	var gotParams questiongen.Params
+	generateQuestions = func(_ context.Context, params questiongen.Params) (questiongen.Result, error) {
+		gotParams = params
+		return questiongen.Result{Candidates: []questiongen.Candidate{
+			{Question: question.Question{ID: "keep", Prompt: "Keep?", Answers: []string{"yes", "no"}, CorrectAnswers: []string{"yes"}}},
+			{Question: question.Question{ID: "drop", Prompt: "Drop?", Answers: []string{"yes", "no"}, CorrectAnswers: []string{"no"}}},
+		}}, nil
+	}
+	verifyQuestions = func(_ context.Context, _ spec.Config, candidates []questiongen.Candidate, params questiongen.VerifyParams) ([]questiongen.Candidate, error) {
+		candidates[0].Verification = &questiongen.Verification{AgentID: params.AgentID, Answer: "yes", Correct: true}
+		candidates[1].Verification = &questiongen.Verification{AgentID: params.AgentID, Answer: "yes"}
+		return candidates, nil
+	}
+
+	args := []string{"questions", "generate", "--spec", specPath, "--count", "2", "--verify-agent", "checker"}
+	var out, errOut bytes.Buffer
+	if code := Run(args, &out, &errOut); code != ExitOK {
+		t.Fatalf("expected exit %d, got %d (stderr %q)", ExitOK, code, errOut.String())
+	}
+	if gotParams.Agent.ID != "writer" || gotParams.Count != 2 {
+		t.Fatalf("unexpected generate params: %+v", gotParams)
+	}
+	if !strings.Contains(out.String(), "rejected drop") || !strings.Contains(out.String(), "Accepted 1 of 2") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+	loaded, err := question.LoadSpec(filepath.Join(dir, ".cogni", "questions", "draft.yml"))
+	if err != nil {
+		t.Fatalf("load draft: %v", err)
+	}
+	if len(loaded.Questions) != 1 || loaded.Questions[0].ID != "keep" {
+		t.Fatalf("unexpected draft questions: %+v", loaded.Questions)
+	}
+
+	errOut.Reset()
+	if code := Run(args, &out, &errOut); code != ExitError || !strings.Contains(errOut.String(), "--force") {
+		t.Fatalf("expected overwrite refusal, got %d %q", code, errOut.String())
+	}
+}
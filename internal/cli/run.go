@@ -1,7 +1,6 @@
 package cli
 
 import (
-	"context"
 	"flag"
 	"fmt"
 	"io"
@@ -10,6 +9,7 @@ import (
 	"strings"
 
 	"cogni/internal/config"
+	"cogni/internal/report"
 	"cogni/internal/runner"
 	"cogni/internal/ui/live"
 )
@@ -17,6 +17,9 @@ import (
 // runAndWrite is a test seam for runner execution.
 var runAndWrite = runner.RunAndWrite
 
+// findRunDir is a test seam for locating a run directory by id.
+var findRunDir = report.FindRunDir
+
 // runRun builds the handler for the run command.
 func runRun(cmd *Command) func(args []string, stdout, stderr io.Writer) int {
 	return func(args []string, stdout, stderr io.Writer) int {
@@ -28,11 +31,19 @@ func runRun(cmd *Command) func(args []string, stdout, stderr io.Writer) int {
 		fs.SetOutput(stderr)
 		specPath := fs.String("spec", "", "Path to config file (default: search for .cogni/config.yml)")
 		agentOverride := fs.String("agent", "", "Agent id override")
+		agentList := fs.String("agents", "", "Comma-separated agent ids; run every task against each (matrix run)")
 		outputDir := fs.String("output-dir", "", "Override output directory")
+		rev := fs.String("rev", "", "Evaluate a commit in a temporary worktree instead of the working tree")
+		resumeRunID := fs.String("resume", "", "Resume an interrupted run by run id, rerunning only unfinished questions")
+		rerunFailed := fs.String("rerun-failed", "", "Start a new run that re-evaluates selected questions from a run id or commit")
+		rerunStatus := fs.String("rerun-status", strings.Join(runner.DefaultRerunStatuses, ","), "Question statuses to re-evaluate with --rerun-failed (correct, incorrect, parse_error, runtime_error, budget_exceeded, cancelled, skipped)")
 		verbose := fs.Bool("verbose", false, "Verbose logging")
 		logPath := fs.String("log", "", "Write verbose logs to a file")
 		noColor := fs.Bool("no-color", false, "Disable ANSI colors in verbose logs")
 		uiMode := fs.String("ui", "auto", "UI mode: auto, live, plain")
+		limit := fs.Int("limit", 0, "Evaluate at most N questions per question task")
+		sample := fs.Int("sample", 0, "Evaluate N randomly sampled questions per question task")
+		seed := fs.Int64("seed", 0, "Random seed for --sample")
 		if err := fs.Parse(args); err != nil {
 			return ExitUsage
 		}
@@ -57,6 +68,40 @@ func runRun(cmd *Command) func(args []string, stdout, stderr io.Writer) int {
 
 		repoRoot := config.RepoRootFromConfigPath(resolvedSpec)
 
+		matrixAgents := splitList(*agentList)
+		if len(matrixAgents) > 0 && strings.TrimSpace(*agentOverride) != "" {
+			fmt.Fprintln(stderr, "--agent and --agents cannot be combined")
+			return ExitUsage
+		}
+		if strings.TrimSpace(*resumeRunID) != "" && strings.TrimSpace(*rerunFailed) != "" {
+			fmt.Fprintln(stderr, "--resume and --rerun-failed cannot be combined")
+			return ExitUsage
+		}
+		runOutputDir := resolveRunOutputDir(repoRoot, *outputDir, cfg.Repo.OutputDir)
+		var resume *runner.Checkpoint
+		if strings.TrimSpace(*resumeRunID) != "" {
+			resume, err = loadResumeCheckpoint(runOutputDir, *resumeRunID)
+			if err != nil {
+				fmt.Fprintf(stderr, "Cannot resume run: %v\n", err)
+				return ExitError
+			}
+		}
+		var rerun *runner.Checkpoint
+		if strings.TrimSpace(*rerunFailed) != "" {
+			prior, _, err := resolveRun(runOutputDir, repoRoot, *rerunFailed)
+			if err != nil {
+				fmt.Fprintf(stderr, "Run not found: %v\n", err)
+				return ExitError
+			}
+			var count int
+			rerun, count, err = runner.RerunCheckpoint(prior, splitList(*rerunStatus))
+			if err != nil {
+				fmt.Fprintf(stderr, "Cannot rerun: %v\n", err)
+				return ExitUsage
+			}
+			fmt.Fprintf(stdout, "Rerunning %d questions from run %s\n", count, prior.RunID)
+		}
+
 		var logFile io.WriteCloser
 		if strings.TrimSpace(*logPath) != "" {
 			dir := filepath.Dir(*logPath)
@@ -83,6 +128,10 @@ func runRun(cmd *Command) func(args []string, stdout, stderr io.Writer) int {
 		if decision.warning != "" {
 			fmt.Fprintln(stderr, decision.warning)
 		}
+		// The live UI follows one task at a time; matrix cells run concurrently.
+		if decision.useLive && strings.TrimSpace(*agentOverride) == "" && (len(matrixAgents) > 0 || len(cfg.Matrix.Agents) > 0) {
+			decision.useLive = false
+		}
 		var uiController *live.Controller
 		if decision.useLive {
 			uiController = live.Start(stdout, live.Options{NoColor: *noColor})
@@ -100,11 +149,18 @@ func runRun(cmd *Command) func(args []string, stdout, stderr io.Writer) int {
 			observer = uiController
 		}
 
-		results, paths, err := runAndWrite(context.Background(), cfg, runner.RunParams{
+		ctx, stopSignals := interruptContext(stderr)
+		defer stopSignals()
+		results, paths, err := runAndWrite(ctx, cfg, runner.RunParams{
 			RepoRoot:         repoRoot,
 			OutputDir:        *outputDir,
+			Rev:              *rev,
+			Resume:           resume,
+			Rerun:            rerun,
 			AgentOverride:    *agentOverride,
+			Agents:           matrixAgents,
 			Selectors:        selectors,
+			Sampling:         runner.QuestionSampling{Limit: *limit, Sample: *sample, Seed: *seed},
 			Verbose:          *verbose,
 			VerboseWriter:    stdout,
 			VerboseLogWriter: logFile,
@@ -117,20 +173,61 @@ func runRun(cmd *Command) func(args []string, stdout, stderr io.Writer) int {
 			return ExitError
 		}
 
-		fmt.Fprintf(stdout, "Run %s completed\n", results.RunID)
+		if results.Incomplete {
+			fmt.Fprintf(stdout, "Run %s cancelled; partial results written\n", results.RunID)
+		} else {
+			fmt.Fprintf(stdout, "Run %s completed\n", results.RunID)
+		}
 		for _, task := range results.Tasks {
+			label := task.TaskID
+			if len(results.Leaderboard) > 0 {
+				label += "@" + task.AgentID
+			}
 			if task.QuestionEval != nil {
 				summary := task.QuestionEval.Summary
-				fmt.Fprintf(stdout, "Question task %s accuracy: %d/%d (%.1f%%)\n",
-					task.TaskID,
-					summary.QuestionsCorrect,
-					summary.QuestionsTotal,
-					summary.Accuracy*100,
+				printQuestionAccuracy(stdout, label, summary)
+				if available := task.QuestionEval.QuestionsAvailable; available > 0 {
+					fmt.Fprintf(stdout, "  Partial run: %d of %d questions selected\n", summary.QuestionsTotal, available)
+				}
+				printPositionAccuracy(stdout, task.QuestionEval)
+			}
+			if task.CodeChange != nil {
+				fmt.Fprintf(stdout, "Code change task %s: %s (%d/%d verification commands passed, %d files changed)\n",
+					label,
+					task.Status,
+					task.CodeChange.VerificationPassed(),
+					len(task.CodeChange.Verification),
+					len(task.CodeChange.FilesChanged),
 				)
 			}
 		}
+		printLeaderboard(stdout, results.Leaderboard)
 		fmt.Fprintf(stdout, "Results: %s\n", paths.ResultsPath())
 		fmt.Fprintf(stdout, "Report: %s\n", paths.ReportPath())
+		if results.Incomplete {
+			return ExitError
+		}
 		return ExitOK
 	}
 }
+
+// resolveRunOutputDir applies the --output-dir override and resolves it against the repo root.
+func resolveRunOutputDir(repoRoot, outputOverride, configuredOutput string) string {
+	outputDir := outputOverride
+	if strings.TrimSpace(outputDir) == "" {
+		outputDir = configuredOutput
+	}
+	if !filepath.IsAbs(outputDir) {
+		outputDir = filepath.Join(repoRoot, outputDir)
+	}
+	return outputDir
+}
+
+// loadResumeCheckpoint finds a run by id in the output directory and loads its checkpoint.
+func loadResumeCheckpoint(outputDir, runID string) (*runner.Checkpoint, error) {
+	runDir, err := findRunDir(outputDir, runID)
+	if err != nil {
+		return nil, err
+	}
+	return runner.LoadCheckpoint(filepath.Join(runDir, runner.CheckpointFileName))
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"cogni/internal/runner"
@@ -63,7 +64,7 @@ questions:
 		t.Fatalf("run command not found")
 	}
 	var stdout, stderr bytes.Buffer
-	exitCode := cmd.Run([]string{"--spec", specPath, "--agent", "default", "--verbose", "--no-color", "--log", logPath, "task-1"}, &stdout, &stderr)
+	exitCode := cmd.Run([]string{"--spec", specPath, "--agent", "default", "--verbose", "--no-color", "--log", logPath, "--rev", "abc123", "--sample", "5", "--seed", "9", "task-1"}, &stdout, &stderr)
 	if exitCode != ExitOK {
 		t.Fatalf("unexpected exit: %d, stderr: %s", exitCode, stderr.String())
 	}
@@ -82,10 +83,229 @@ questions:
 	if !gotParams.NoColor {
 		t.Fatalf("expected no-color enabled")
 	}
+	if gotParams.Rev != "abc123" {
+		t.Fatalf("unexpected rev: %q", gotParams.Rev)
+	}
 	if len(gotParams.Selectors) != 1 || gotParams.Selectors[0].TaskID != "task-1" {
 		t.Fatalf("unexpected selectors: %+v", gotParams.Selectors)
 	}
+	if gotParams.Sampling.Sample != 5 || gotParams.Sampling.Seed != 9 {
+		t.Fatalf("unexpected sampling: %+v", gotParams.Sampling)
+	}
 	if _, err := os.Stat(logPath); err != nil {
 		t.Fatalf("expected log file to exist: %v", err)
 	}
 }
+
+// TestRunCommandResume verifies --resume loads the run's checkpoint.
+func TestRunCommandResume(t *testing.T) {
+	specDir := t.TempDir()
+	specPath := filepath.Join(specDir, ".cogni", "config.yml")
+	specBody := `version: 1
+repo:
+  output_dir: "./out"
+agents:
+  - id: default
+    type: builtin
+    provider: openrouter
+    model: test-model
+default_agent: default
+tasks:
+  - id: task-1
+    type: question_eval
+    agent: default
+    questions_file: "questions.yml"
+`
+	questionsBody := `version: 1
+questions:
+  - question: "What is 1+1?"
+    answers: ["2"]
+    correct_answers: ["2"]
+`
+	runDir := filepath.Join(specDir, "out", "abc", "run-9")
+	for _, dir := range []string{filepath.Dir(specPath), runDir} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("create dir: %v", err)
+		}
+	}
+	files := map[string]string{
+		specPath:                                  specBody,
+		filepath.Join(specDir, "questions.yml"):   questionsBody,
+		filepath.Join(runDir, "checkpoint.jsonl"): `{"kind":"run","run_id":"run-9","commit":"abc"}` + "\n",
+	}
+	for path, body := range files {
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+
+	var gotParams runner.RunParams
+	origRun := runAndWrite
+	runAndWrite = func(_ context.Context, _ spec.Config, params runner.RunParams) (runner.Results, runner.OutputPaths, error) {
+		gotParams = params
+		return runner.Results{RunID: "run-9"}, runner.OutputPaths{Root: specDir, Commit: "abc", RunID: "run-9"}, nil
+	}
+	t.Cleanup(func() { runAndWrite = origRun })
+
+	cmd := findCommand("run")
+	var stdout, stderr bytes.Buffer
+	if exitCode := cmd.Run([]string{"--spec", specPath, "--ui", "plain", "--resume", "run-9"}, &stdout, &stderr); exitCode != ExitOK {
+		t.Fatalf("unexpected exit: %d, stderr: %s", exitCode, stderr.String())
+	}
+	if gotParams.Resume == nil || gotParams.Resume.RunID != "run-9" || gotParams.Resume.Commit != "abc" {
+		t.Fatalf("expected checkpoint for run-9, got %+v", gotParams.Resume)
+	}
+
+	if exitCode := cmd.Run([]string{"--spec", specPath, "--ui", "plain", "--resume", "missing"}, &stdout, &stderr); exitCode != ExitError {
+		t.Fatalf("expected error for unknown run, got %d", exitCode)
+	}
+}
+
+// TestRunCommandRerunFailed verifies --rerun-failed loads the prior run and selects errored questions.
+func TestRunCommandRerunFailed(t *testing.T) {
+	specDir := t.TempDir()
+	specPath := filepath.Join(specDir, ".cogni", "config.yml")
+	specBody := `version: 1
+repo:
+  output_dir: "./out"
+agents:
+  - id: default
+    type: builtin
+    provider: openrouter
+    model: test-model
+default_agent: default
+tasks:
+  - id: task-1
+    type: question_eval
+    agent: default
+    questions_file: "questions.yml"
+`
+	if err := os.MkdirAll(filepath.Dir(specPath), 0o755); err != nil {
+		t.Fatalf("create dir: %v", err)
+	}
+	if err := os.WriteFile(specPath, []byte(specBody), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+	questionsBody := "version: 1\nquestions:\n  - question: \"What is 1+1?\"\n    answers: [\"2\"]\n    correct_answers: [\"2\"]\n"
+	if err := os.WriteFile(filepath.Join(specDir, "questions.yml"), []byte(questionsBody), 0o644); err != nil {
+		t.Fatalf("write questions: %v", err)
+	}
+
+	var gotRef string
+	origResolve := resolveRun
+	resolveRun = func(_ string, _ string, ref string) (runner.Results, string, error) {
+		gotRef = ref
+		return runner.Results{
+			RunID: "run-7",
+			Repo:  runner.RepoMetadata{Commit: "abc"},
+			Tasks: []runner.TaskResult{{
+				TaskID:  "task-1",
+				AgentID: "default",
+				QuestionEval: &runner.QuestionEval{Questions: []runner.QuestionResult{
+					{Question: "What is 1+1?", Correct: true},
+					{Question: "What is 2+2?", RunError: "provider unavailable"},
+				}},
+			}},
+		}, "", nil
+	}
+	var gotParams runner.RunParams
+	origRun := runAndWrite
+	runAndWrite = func(_ context.Context, _ spec.Config, params runner.RunParams) (runner.Results, runner.OutputPaths, error) {
+		gotParams = params
+		return runner.Results{RunID: "run-8"}, runner.OutputPaths{Root: specDir, Commit: "abc", RunID: "run-8"}, nil
+	}
+	t.Cleanup(func() {
+		resolveRun = origResolve
+		runAndWrite = origRun
+	})
+
+	cmd := findCommand("run")
+	var stdout, stderr bytes.Buffer
+	if exitCode := cmd.Run([]string{"--spec", specPath, "--ui", "plain", "--rerun-failed", "run-7"}, &stdout, &stderr); exitCode != ExitOK {
+		t.Fatalf("unexpected exit: %d, stderr: %s", exitCode, stderr.String())
+	}
+	if gotRef != "run-7" {
+		t.Fatalf("expected run-7 to be resolved, got %q", gotRef)
+	}
+	if gotParams.Rerun == nil || gotParams.Rerun.RunID != "run-7" || len(gotParams.Rerun.Selectors) != 1 {
+		t.Fatalf("expected rerun checkpoint for run-7, got %+v", gotParams.Rerun)
+	}
+	if !strings.Contains(stdout.String(), "Rerunning 1 questions from run run-7") {
+		t.Fatalf("expected rerun summary, got %q", stdout.String())
+	}
+
+	if exitCode := cmd.Run([]string{"--spec", specPath, "--ui", "plain", "--rerun-failed", "run-7", "--resume", "run-7"}, &stdout, &stderr); exitCode != ExitUsage {
+		t.Fatalf("expected usage error when combining --resume, got %d", exitCode)
+	}
+	if exitCode := cmd.Run([]string{"--spec", specPath, "--ui", "plain", "--rerun-failed", "run-7", "--rerun-status", "bogus"}, &stdout, &stderr); exitCode != ExitUsage {
+		t.Fatalf("expected usage error for unknown status, got %d", exitCode)
+	}
+}
+
+// TestRunCommandAgents verifies --agents starts a matrix run and prints the leaderboard.
+func TestRunCommandAgents(t *testing.T) {
+	specDir := t.TempDir()
+	specPath := filepath.Join(specDir, ".cogni", "config.yml")
+	specBody := `version: 1
+repo:
+  output_dir: "./out"
+agents:
+  - id: default
+    type: builtin
+    provider: openrouter
+    model: test-model
+  - id: other
+    type: builtin
+    provider: openrouter
+    model: other-model
+default_agent: default
+tasks:
+  - id: task-1
+    type: question_eval
+    questions_file: "questions.yml"
+`
+	if err := os.MkdirAll(filepath.Dir(specPath), 0o755); err != nil {
+		t.Fatalf("create dir: %v", err)
+	}
+	if err := os.WriteFile(specPath, []byte(specBody), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+	questionsBody := "version: 1\nquestions:\n  - question: \"What is 1+1?\"\n    answers: [\"2\"]\n    correct_answers: [\"2\"]\n"
+	if err := os.WriteFile(filepath.Join(specDir, "questions.yml"), []byte(questionsBody), 0o644); err != nil {
+		t.Fatalf("write questions: %v", err)
+	}
+
+	var gotParams runner.RunParams
+	origRun := runAndWrite
+	runAndWrite = func(_ context.Context, _ spec.Config, params runner.RunParams) (runner.Results, runner.OutputPaths, error) {
+		gotParams = params
+		cost := 0.25
+		return runner.Results{
+			RunID: "run-1",
+			Leaderboard: []runner.AgentStanding{
+				{AgentID: "other", Model: "other-model", TasksTotal: 1, TasksPassed: 1, Accuracy: 1, TokensTotal: 120, CostUSD: &cost, WallTimeSeconds: 2},
+				{AgentID: "default", Model: "test-model", TasksTotal: 1, Accuracy: 0.5, TokensTotal: 90, WallTimeSeconds: 3},
+			},
+		}, runner.OutputPaths{Root: specDir, Commit: "abc", RunID: "run-1"}, nil
+	}
+	t.Cleanup(func() { runAndWrite = origRun })
+
+	cmd := findCommand("run")
+	var stdout, stderr bytes.Buffer
+	if exitCode := cmd.Run([]string{"--spec", specPath, "--ui", "plain", "--agents", "default, other"}, &stdout, &stderr); exitCode != ExitOK {
+		t.Fatalf("unexpected exit: %d, stderr: %s", exitCode, stderr.String())
+	}
+	if len(gotParams.Agents) != 2 || gotParams.Agents[0] != "default" || gotParams.Agents[1] != "other" {
+		t.Fatalf("unexpected agents: %+v", gotParams.Agents)
+	}
+	output := stdout.String()
+	for _, want := range []string{"Leaderboard:", "other-model", "$0.2500", "100.0%"} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output:\n%s", want, output)
+		}
+	}
+
+	if exitCode := cmd.Run([]string{"--spec", specPath, "--ui", "plain", "--agents", "other", "--agent", "default"}, &stdout, &stderr); exitCode != ExitUsage {
+		t.Fatalf("expected usage error combining --agent and --agents, got %d", exitCode)
+	}
+}
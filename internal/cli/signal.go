@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// exitProcess is a test seam for forced exits.
+var exitProcess = os.Exit
+
+// interruptContext returns a context cancelled by the first SIGINT or SIGTERM
+// so the run can stop gracefully and write partial results. A second signal
+// exits immediately. The returned stop func releases the signal handler.
+func interruptContext(stderr io.Writer) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-signals:
+		case <-done:
+			return
+		}
+		fmt.Fprintln(stderr, "Cancelling run; writing partial results (press Ctrl-C again to force exit)")
+		cancel()
+		select {
+		case <-signals:
+			fmt.Fprintln(stderr, "Forced exit")
+			exitProcess(ExitError)
+		case <-done:
+		}
+	}()
+	return ctx, func() {
+		signal.Stop(signals)
+		close(done)
+		cancel()
+	}
+}
@@ -1,12 +1,17 @@
 package cli
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"path/filepath"
 	"strings"
 
 	"cogni/internal/config"
+	"cogni/internal/question"
+	"cogni/internal/runner"
+	"cogni/internal/spec"
 )
 
 // runValidate builds the handler for the validate command.
@@ -20,6 +25,7 @@ func runValidate(cmd *Command) func(args []string, stdout, stderr io.Writer) int
 		flags := flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
 		flags.SetOutput(stderr)
 		specPath := flags.String("spec", "", "Path to config file (default: search for .cogni/config.yml)")
+		listQuestions := flags.Bool("questions", false, "List resolved questions with their source locations")
 		if err := flags.Parse(args); err != nil {
 			if err == flag.ErrHelp {
 				printCommandUsage(cmd, stdout)
@@ -41,12 +47,91 @@ func runValidate(cmd *Command) func(args []string, stdout, stderr io.Writer) int
 			return ExitError
 		}
 
-		if _, err := config.Load(resolvedSpec); err != nil {
+		cfg, err := config.Load(resolvedSpec)
+		if err != nil {
 			fmt.Fprintf(stderr, "Validation failed:\n%s\n", err.Error())
 			return ExitError
 		}
 
+		repoRoot := config.RepoRootFromConfigPath(resolvedSpec)
+		if !validateQuestionSpecs(cfg, repoRoot, *listQuestions, stdout, stderr) {
+			return ExitError
+		}
+
 		fmt.Fprintln(stdout, "Config OK")
 		return ExitOK
 	}
 }
+
+// validateQuestionSpecs resolves each question_eval task's questions and reports
+// the resolved set, or each issue with its source location.
+func validateQuestionSpecs(cfg spec.Config, repoRoot string, list bool, stdout, stderr io.Writer) bool {
+	ok := true
+	for _, task := range cfg.Tasks {
+		if task.Type != "question_eval" {
+			continue
+		}
+		path := task.QuestionsFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(repoRoot, path)
+		}
+		resolved, err := question.LoadSpecWithOptions(path, runner.QuestionLoadOptions(repoRoot, task))
+		if err != nil {
+			ok = false
+			fmt.Fprintf(stderr, "Task %s questions invalid:\n", task.ID)
+			var validationErr *question.ValidationError
+			if errors.As(err, &validationErr) {
+				for _, issue := range validationErr.Issues {
+					location := displayPath(repoRoot, issue.Source.File)
+					if issue.Source.Line > 0 {
+						location = fmt.Sprintf("%s:%d", location, issue.Source.Line)
+					}
+					if location == "" {
+						location = task.QuestionsFile
+					}
+					fmt.Fprintf(stderr, "  %s: %s: %s\n", location, issue.Field, issue.Message)
+				}
+				continue
+			}
+			fmt.Fprintf(stderr, "  %v\n", err)
+			continue
+		}
+		files := map[string]struct{}{}
+		for _, item := range resolved.Questions {
+			files[item.Source.File] = struct{}{}
+		}
+		fmt.Fprintf(stdout, "Task %s: %d questions from %d file(s)\n", task.ID, len(resolved.Questions), len(files))
+		if !list {
+			continue
+		}
+		for index, item := range resolved.Questions {
+			id := item.ID
+			if id == "" {
+				id = fmt.Sprintf("#%d", index+1)
+			}
+			location := displayPath(repoRoot, item.Source.File)
+			if item.Source.Line > 0 {
+				location = fmt.Sprintf("%s:%d", location, item.Source.Line)
+			}
+			fmt.Fprintf(stdout, "  %s %s %s\n", id, location, firstLine(item.Prompt))
+		}
+	}
+	return ok
+}
+
+// displayPath renders a path relative to the repo root when possible.
+func displayPath(repoRoot, path string) string {
+	if path == "" || repoRoot == "" {
+		return path
+	}
+	if rel, err := filepath.Rel(repoRoot, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
+
+// firstLine returns the first line of text for compact listings.
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	return line
+}
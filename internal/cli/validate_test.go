@@ -146,3 +146,76 @@ questions:
 		t.Fatalf("expected success message, got %q", out.String())
 	}
 }
+
+// TestValidateReportsQuestionSourceLocations verifies question issues point at their files.
+func TestValidateReportsQuestionSourceLocations(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, ".cogni", "config.yml")
+	config := []byte(`version: 1
+repo:
+  output_dir: "./out"
+agents:
+  - id: default
+    type: builtin
+    provider: openrouter
+    model: gpt-4.1-mini
+default_agent: default
+tasks:
+  - id: task1
+    type: question_eval
+    agent: default
+    questions_file: "questions.yml"
+`)
+	if err := os.MkdirAll(filepath.Dir(specPath), 0o755); err != nil {
+		t.Fatalf("create config dir: %v", err)
+	}
+	if err := os.WriteFile(specPath, config, 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	questionsBody := []byte(`version: 1
+include: ["shared.yml"]
+questions:
+  - id: q1
+    question: "What is 1+1?"
+    answers: ["2"]
+    correct_answers: ["2"]
+`)
+	if err := os.WriteFile(filepath.Join(dir, "questions.yml"), questionsBody, 0o644); err != nil {
+		t.Fatalf("write questions file: %v", err)
+	}
+	sharedBody := []byte(`version: 1
+questions:
+  - id: shared
+    question: "Which file?"
+    answers: ["a.go"]
+    correct_answers: ["b.go"]
+`)
+	if err := os.WriteFile(filepath.Join(dir, "shared.yml"), sharedBody, 0o644); err != nil {
+		t.Fatalf("write shared file: %v", err)
+	}
+
+	var out, stderr bytes.Buffer
+	code := Run([]string{"validate", "--spec", specPath}, &out, &stderr)
+	if code != ExitError {
+		t.Fatalf("expected exit %d, got %d", ExitError, code)
+	}
+	if !strings.Contains(stderr.String(), "shared.yml:3: questions[0].correct_answers[0]") {
+		t.Fatalf("expected source location in error, got %q", stderr.String())
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "shared.yml"), []byte(strings.Replace(string(sharedBody), `["b.go"]`, `["a.go"]`, 1)), 0o644); err != nil {
+		t.Fatalf("rewrite shared file: %v", err)
+	}
+	out.Reset()
+	stderr.Reset()
+	code = Run([]string{"validate", "--spec", specPath, "--questions"}, &out, &stderr)
+	if code != ExitOK {
+		t.Fatalf("expected exit %d, got %d (%s)", ExitOK, code, stderr.String())
+	}
+	if !strings.Contains(out.String(), "Task task1: 2 questions from 2 file(s)") {
+		t.Fatalf("expected resolved summary, got %q", out.String())
+	}
+	if !strings.Contains(out.String(), "shared shared.yml:3 Which file?") {
+		t.Fatalf("expected question listing, got %q", out.String())
+	}
+}
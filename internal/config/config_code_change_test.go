@@ -0,0 +1,65 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"cogni/internal/spec"
+)
+
+// TestValidateCodeChangeTask verifies code_change tasks require a prompt and verify commands.
+func TestValidateCodeChangeTask(t *testing.T) {
+	baseDir := t.TempDir()
+	cfg := validConfig()
+	cfg.Tasks = []spec.TaskConfig{{
+		ID:             "fix-bug",
+		Type:           "code_change",
+		Agent:          "default",
+		Prompt:         "Fix the off-by-one error in pager.go.",
+		VerifyCommands: []string{"go test ./..."},
+	}}
+	if err := Validate(&cfg, baseDir); err != nil {
+		t.Fatalf("expected code_change task to be valid, got %v", err)
+	}
+
+	cfg.Tasks[0].Prompt = ""
+	cfg.Tasks[0].VerifyCommands = nil
+	cfg.Tasks[0].AnswerFormat = "tool"
+	err := Validate(&cfg, baseDir)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, field := range []string{"tasks[0].prompt", "tasks[0].verify_commands", "tasks[0].answer_format"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Fatalf("expected %s error, got %v", field, err)
+		}
+	}
+}
+
+// TestValidateTaskWorkspace verifies workspace modes and write tool requirements.
+func TestValidateTaskWorkspace(t *testing.T) {
+	baseDir := t.TempDir()
+	writeQuestionSpec(t, baseDir)
+
+	cfg := validConfig()
+	cfg.Tasks[0].Workspace = "worktree"
+	cfg.Tasks[0].WriteTools = true
+	cfg.Tasks[0].Concurrency = 4
+	if err := Validate(&cfg, baseDir); err != nil {
+		t.Fatalf("expected isolated write tools to be valid, got %v", err)
+	}
+
+	cfg = validConfig()
+	cfg.Tasks[0].WriteTools = true
+	err := Validate(&cfg, baseDir)
+	if err == nil || !strings.Contains(err.Error(), "write_tools") {
+		t.Fatalf("expected write_tools error, got %v", err)
+	}
+
+	cfg = validConfig()
+	cfg.Tasks[0].Workspace = "tmpfs"
+	err = Validate(&cfg, baseDir)
+	if err == nil || !strings.Contains(err.Error(), "workspace") {
+		t.Fatalf("expected workspace error, got %v", err)
+	}
+}
@@ -53,3 +53,38 @@ questions:
 		t.Fatalf("unexpected validation error: %v", err)
 	}
 }
+
+// TestValidateQuestionEvalRejectsUnknownAnswerLabels verifies answer_order labels are checked.
+func TestValidateQuestionEvalRejectsUnknownAnswerLabels(t *testing.T) {
+	cfg := validConfig()
+	cfg.Tasks[0].AnswerOrder = spec.TaskAnswerOrder{Shuffle: true, Labels: "roman"}
+
+	baseDir := t.TempDir()
+	writeQuestionSpec(t, baseDir)
+	err := Validate(&cfg, baseDir)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	if !strings.Contains(err.Error(), "answer_order.labels") {
+		t.Fatalf("expected answer_order.labels error, got %q", err.Error())
+	}
+}
+
+// TestValidateQuestionEvalAnswerFormat verifies answer_format values are checked.
+func TestValidateQuestionEvalAnswerFormat(t *testing.T) {
+	baseDir := t.TempDir()
+	writeQuestionSpec(t, baseDir)
+
+	cfg := validConfig()
+	cfg.Tasks[0].AnswerFormat = "tool"
+	if err := Validate(&cfg, baseDir); err != nil {
+		t.Fatalf("expected tool answer format to be valid, got %v", err)
+	}
+
+	cfg = validConfig()
+	cfg.Tasks[0].AnswerFormat = "yaml"
+	err := Validate(&cfg, baseDir)
+	if err == nil || !strings.Contains(err.Error(), "answer_format") {
+		t.Fatalf("expected answer_format error, got %v", err)
+	}
+}
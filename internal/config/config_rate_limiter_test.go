@@ -156,6 +156,38 @@ func TestValidateRateLimiterRejectsInvalidTimeout(t *testing.T) {
 	}
 }
 
+// TestValidateRateLimiterRejectsInvalidAdaptive ensures adaptive backoff must shrink capacity.
+func TestValidateRateLimiterRejectsInvalidAdaptive(t *testing.T) {
+	cfg := validConfig()
+	cfg.RateLimiter.Adaptive = spec.AdaptiveConfig{Enabled: true, BackoffFactor: 1.5}
+
+	baseDir := t.TempDir()
+	writeQuestionSpec(t, baseDir)
+	err := validateWithTimeout(t, cfg, baseDir)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	if !strings.Contains(err.Error(), "rate_limiter.adaptive") {
+		t.Fatalf("expected adaptive error, got %q", err.Error())
+	}
+}
+
+// TestValidateRateLimiterRejectsUnknownPriority ensures priority must name a known class.
+func TestValidateRateLimiterRejectsUnknownPriority(t *testing.T) {
+	cfg := validConfig()
+	cfg.RateLimiter.Priority = "urgent"
+
+	baseDir := t.TempDir()
+	writeQuestionSpec(t, baseDir)
+	err := validateWithTimeout(t, cfg, baseDir)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	if !strings.Contains(err.Error(), "rate_limiter.priority") {
+		t.Fatalf("expected priority error, got %q", err.Error())
+	}
+}
+
 // TestValidateTaskConcurrencyRejectsNonPositive ensures invalid task concurrency is rejected.
 func TestValidateTaskConcurrencyRejectsNonPositive(t *testing.T) {
 	cfg := validConfig()
@@ -205,3 +237,21 @@ func validateWithTimeout(t *testing.T, cfg spec.Config, baseDir string) error {
 		return ctx.Err()
 	}
 }
+
+// TestValidateRateLimiterAuthRequiresRemote ensures token_env and tls apply only to remote mode.
+func TestValidateRateLimiterAuthRequiresRemote(t *testing.T) {
+	cfg := validConfig()
+	cfg.RateLimiter.Mode = "disabled"
+	cfg.RateLimiter.TokenEnv = "LIMITER_TOKEN"
+	cfg.RateLimiter.TLS.CertFile = "client.crt"
+
+	baseDir := t.TempDir()
+	writeQuestionSpec(t, baseDir)
+	err := validateWithTimeout(t, cfg, baseDir)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	if !strings.Contains(err.Error(), "rate_limiter.token_env") || !strings.Contains(err.Error(), "rate_limiter.tls.cert_file") {
+		t.Fatalf("expected token_env and tls errors, got %q", err.Error())
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"strings"
 	"testing"
+
+	"cogni/internal/spec"
 )
 
 // TestValidateDetectsDuplicateAgentIDs verifies duplicate agent IDs are flagged.
@@ -110,3 +112,56 @@ func TestValidateRejectsMissingCompactionPromptFile(t *testing.T) {
 		t.Fatalf("expected summary prompt file error, got %q", err.Error())
 	}
 }
+
+// TestValidateMatrix verifies matrix agents must exist and be unique.
+func TestValidateMatrix(t *testing.T) {
+	baseDir := t.TempDir()
+	writeQuestionSpec(t, baseDir)
+
+	cfg := validConfig()
+	cfg.Matrix.Agents = []string{"default"}
+	cfg.Matrix.ProviderConcurrency = 2
+	if err := Validate(&cfg, baseDir); err != nil {
+		t.Fatalf("expected matrix to be valid, got %v", err)
+	}
+
+	cfg = validConfig()
+	cfg.Matrix.Agents = []string{"default", "missing", "default"}
+	cfg.Matrix.ProviderConcurrency = -1
+	cfg.Agents[0].CostPerMillionTokens = -1
+	err := Validate(&cfg, baseDir)
+	if err == nil {
+		t.Fatalf("expected matrix errors")
+	}
+	for _, want := range []string{`unknown agent "missing"`, `duplicate agent "default"`, "matrix.provider_concurrency", "cost_per_million_tokens"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in %v", want, err)
+		}
+	}
+}
+
+// TestValidateAggregateBudget verifies run and task budgets reject negative
+// limits and cost ceilings on unpriced agents.
+func TestValidateAggregateBudget(t *testing.T) {
+	baseDir := t.TempDir()
+	writeQuestionSpec(t, baseDir)
+
+	cfg := validConfig()
+	cfg.Budget = spec.AggregateBudget{MaxTokens: 100000, MaxSeconds: 600}
+	if err := Validate(&cfg, baseDir); err != nil {
+		t.Fatalf("expected budget to be valid, got %v", err)
+	}
+
+	cfg = validConfig()
+	cfg.Budget = spec.AggregateBudget{MaxTokens: -1, MaxCostUSD: 5}
+	cfg.Tasks[0].TotalBudget.MaxSeconds = -1
+	err := Validate(&cfg, baseDir)
+	if err == nil {
+		t.Fatalf("expected budget errors")
+	}
+	for _, want := range []string{"budget.max_tokens", `requires cost_per_million_tokens on agent "default"`, "tasks[0].total_budget.max_seconds"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in %v", want, err)
+		}
+	}
+}
@@ -39,4 +39,15 @@ func normalizeRateLimiter(cfg *spec.Config) {
 	if cfg.RateLimiter.Batch.FlushMs == 0 {
 		cfg.RateLimiter.Batch.FlushMs = 2
 	}
+	if cfg.RateLimiter.Retry.MaxAttempts == 0 {
+		cfg.RateLimiter.Retry.MaxAttempts = 3
+	}
+	if cfg.RateLimiter.Retry.BaseDelayMs == 0 {
+		cfg.RateLimiter.Retry.BaseDelayMs = 1000
+	}
+	if cfg.RateLimiter.Retry.MaxDelayMs == 0 {
+		cfg.RateLimiter.Retry.MaxDelayMs = 30000
+	}
+	cfg.RateLimiter.Priority = strings.ToLower(strings.TrimSpace(cfg.RateLimiter.Priority))
+	cfg.RateLimiter.Tenant = strings.TrimSpace(cfg.RateLimiter.Tenant)
 }
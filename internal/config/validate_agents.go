@@ -37,10 +37,36 @@ func validateAgents(cfg *spec.Config, add issueAdder) map[string]struct{} {
 		if agent.MaxSteps < 0 {
 			add(fieldPrefix+".max_steps", "must be >= 0")
 		}
+		if agent.CostPerMillionTokens < 0 {
+			add(fieldPrefix+".cost_per_million_tokens", "must be >= 0")
+		}
 	}
 	return agentIDs
 }
 
+// validateMatrix ensures matrix agents exist and are listed once.
+func validateMatrix(cfg *spec.Config, agentIDs map[string]struct{}, add issueAdder) {
+	seen := map[string]struct{}{}
+	for i, agentID := range cfg.Matrix.Agents {
+		field := fmt.Sprintf("matrix.agents[%d]", i)
+		agentID = strings.TrimSpace(agentID)
+		if agentID == "" {
+			add(field, "is required")
+			continue
+		}
+		if _, ok := agentIDs[agentID]; !ok {
+			add(field, fmt.Sprintf("unknown agent %q", agentID))
+		}
+		if _, dup := seen[agentID]; dup {
+			add(field, fmt.Sprintf("duplicate agent %q", agentID))
+		}
+		seen[agentID] = struct{}{}
+	}
+	if cfg.Matrix.ProviderConcurrency < 0 {
+		add("matrix.provider_concurrency", "must be >= 0")
+	}
+}
+
 // validateDefaultAgent ensures the configured default agent exists.
 func validateDefaultAgent(cfg *spec.Config, agentIDs map[string]struct{}, add issueAdder) {
 	defaultAgent := strings.TrimSpace(cfg.DefaultAgent)
@@ -52,3 +78,25 @@ func validateDefaultAgent(cfg *spec.Config, agentIDs map[string]struct{}, add is
 		add("default_agent", fmt.Sprintf("unknown agent %q", defaultAgent))
 	}
 }
+
+// validateAggregateBudget checks a run or task total budget. A cost ceiling
+// needs every agent priced, since any of them may run under a matrix.
+func validateAggregateBudget(cfg *spec.Config, field string, budget spec.AggregateBudget, add issueAdder) {
+	if budget.MaxTokens < 0 {
+		add(field+".max_tokens", "must be >= 0")
+	}
+	if budget.MaxCostUSD < 0 {
+		add(field+".max_cost_usd", "must be >= 0")
+	}
+	if budget.MaxSeconds < 0 {
+		add(field+".max_seconds", "must be >= 0")
+	}
+	if budget.MaxCostUSD <= 0 {
+		return
+	}
+	for _, agent := range cfg.Agents {
+		if agent.CostPerMillionTokens <= 0 {
+			add(field+".max_cost_usd", fmt.Sprintf("requires cost_per_million_tokens on agent %q", agent.ID))
+		}
+	}
+}
@@ -27,6 +27,8 @@ func Validate(cfg *spec.Config, baseDir string) error {
 
 	agentIDs := validateAgents(cfg, collector.add)
 	validateDefaultAgent(cfg, agentIDs, collector.add)
+	validateMatrix(cfg, agentIDs, collector.add)
+	validateAggregateBudget(cfg, "budget", cfg.Budget, collector.add)
 	validateRateLimiter(cfg, collector.add)
 	validateTasks(cfg, baseDir, agentIDs, collector.add)
 
@@ -3,7 +3,10 @@ package config
 import (
 	"strings"
 
+	"cogni/internal/adaptive"
+	"cogni/internal/ratelimit"
 	"cogni/internal/spec"
+	"cogni/pkg/ratelimiter"
 )
 
 // validateRateLimiter checks rate limiter configuration rules.
@@ -28,6 +31,13 @@ func validateRateLimiter(cfg *spec.Config, add issueAdder) {
 	default:
 		add("rate_limiter.mode", "must be one of disabled, remote, embedded")
 	}
+	tls := cfg.RateLimiter.TLS
+	if mode != "remote" && (cfg.RateLimiter.TokenEnv != "" || tls != (spec.RateLimiterTLSConfig{})) {
+		add("rate_limiter.token_env", "and tls are only valid when mode is remote")
+	}
+	if (strings.TrimSpace(tls.CertFile) == "") != (strings.TrimSpace(tls.KeyFile) == "") {
+		add("rate_limiter.tls.cert_file", "and key_file must be set together")
+	}
 
 	if cfg.RateLimiter.Workers < 1 {
 		add("rate_limiter.workers", "must be >= 1")
@@ -41,4 +51,20 @@ func validateRateLimiter(cfg *spec.Config, add issueAdder) {
 	if cfg.RateLimiter.RequestTimeoutMs < 1 {
 		add("rate_limiter.request_timeout_ms", "must be >= 1")
 	}
+	retry := cfg.RateLimiter.Retry
+	if retry.MaxAttempts < 0 {
+		add("rate_limiter.retry.max_attempts", "must be >= 1")
+	}
+	if retry.BaseDelayMs < 0 {
+		add("rate_limiter.retry.base_delay_ms", "must be >= 1")
+	}
+	if retry.MaxDelayMs < 0 || (retry.MaxDelayMs > 0 && retry.MaxDelayMs < retry.BaseDelayMs) {
+		add("rate_limiter.retry.max_delay_ms", "must be >= base_delay_ms")
+	}
+	if _, err := ratelimiter.ParsePriority(cfg.RateLimiter.Priority); err != nil {
+		add("rate_limiter.priority", "must be one of low, normal, high")
+	}
+	if err := adaptive.FromSettings(ratelimit.AdaptiveSettings(cfg.RateLimiter.Adaptive)).Validate(); err != nil {
+		add("rate_limiter.adaptive", err.Error())
+	}
 }
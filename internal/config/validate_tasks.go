@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"cogni/internal/spec"
+	"cogni/internal/workspace"
 )
 
 // validateTasks checks task entries for correctness.
@@ -25,7 +26,7 @@ func validateTasks(cfg *spec.Config, baseDir string, agentIDs map[string]struct{
 		taskType := strings.TrimSpace(task.Type)
 		if taskType == "" {
 			add(fieldPrefix+".type", "is required")
-		} else if taskType != "question_eval" {
+		} else if taskType != "question_eval" && taskType != "code_change" {
 			add(fieldPrefix+".type", fmt.Sprintf("unsupported type %q", task.Type))
 		}
 		if task.Concurrency != 0 {
@@ -36,6 +37,47 @@ func validateTasks(cfg *spec.Config, baseDir string, agentIDs map[string]struct{
 				add(fieldPrefix+".concurrency", "is only valid for question_eval tasks")
 			}
 		}
+		if task.AnswerOrder.Shuffle || task.AnswerOrder.Seed != 0 || strings.TrimSpace(task.AnswerOrder.Labels) != "" {
+			if taskType != "" && taskType != "question_eval" {
+				add(fieldPrefix+".answer_order", "is only valid for question_eval tasks")
+			}
+			switch strings.TrimSpace(task.AnswerOrder.Labels) {
+			case "", "none", "letters":
+			default:
+				add(fieldPrefix+".answer_order.labels", fmt.Sprintf("unsupported labels %q (expected none or letters)", task.AnswerOrder.Labels))
+			}
+			if task.AnswerOrder.Seed != 0 && !task.AnswerOrder.Shuffle {
+				add(fieldPrefix+".answer_order.seed", "requires shuffle: true")
+			}
+		}
+		if len(task.QuestionVars) > 0 && taskType != "" && taskType != "question_eval" {
+			add(fieldPrefix+".question_vars", "is only valid for question_eval tasks")
+		}
+		if answerFormat := strings.TrimSpace(task.AnswerFormat); answerFormat != "" {
+			if taskType != "" && taskType != "question_eval" {
+				add(fieldPrefix+".answer_format", "is only valid for question_eval tasks")
+			}
+			switch answerFormat {
+			case "xml", "tool", "json":
+			default:
+				add(fieldPrefix+".answer_format", fmt.Sprintf("unsupported format %q (expected xml, tool or json)", task.AnswerFormat))
+			}
+		}
+		if task.ParseRecovery && taskType != "" && taskType != "question_eval" {
+			add(fieldPrefix+".parse_recovery", "is only valid for question_eval tasks")
+		}
+		if _, ok := workspace.ParseMode(strings.TrimSpace(task.Workspace)); !ok {
+			add(fieldPrefix+".workspace", fmt.Sprintf("unsupported workspace %q (expected shared, worktree or copy)", task.Workspace))
+		} else if taskType == "code_change" && strings.TrimSpace(task.Workspace) == string(workspace.ModeShared) {
+			add(fieldPrefix+".workspace", "code_change tasks require an isolated workspace (worktree or copy)")
+		}
+		if task.WriteTools {
+			if taskType != "" && taskType != "question_eval" {
+				add(fieldPrefix+".write_tools", "is only valid for question_eval tasks")
+			} else if mode, ok := workspace.ParseMode(strings.TrimSpace(task.Workspace)); ok && mode == workspace.ModeShared {
+				add(fieldPrefix+".write_tools", "requires workspace: worktree or copy")
+			}
+		}
 		if strings.TrimSpace(task.Agent) == "" {
 			add(fieldPrefix+".agent", "is required")
 		} else if _, ok := agentIDs[task.Agent]; !ok {
@@ -50,6 +92,7 @@ func validateTasks(cfg *spec.Config, baseDir string, agentIDs map[string]struct{
 		if task.Budget.MaxSteps < 0 {
 			add(fieldPrefix+".budget.max_steps", "must be >= 0")
 		}
+		validateAggregateBudget(cfg, fieldPrefix+".total_budget", task.TotalBudget, add)
 		if task.Compaction.MaxTokens < 0 {
 			add(fieldPrefix+".compaction.max_tokens", "must be >= 0")
 		}
@@ -77,6 +120,8 @@ func validateTasks(cfg *spec.Config, baseDir string, agentIDs map[string]struct{
 		switch taskType {
 		case "question_eval":
 			validateQuestionTask(task, fieldPrefix, baseDir, add)
+		case "code_change":
+			validateCodeChangeTask(task, fieldPrefix, add)
 		}
 	}
 }
@@ -101,3 +146,22 @@ func validateQuestionTask(task spec.TaskConfig, fieldPrefix, baseDir string, add
 		add(fieldPrefix+".questions_file", fmt.Sprintf("path %q is a directory", questionsFile))
 	}
 }
+
+// validateCodeChangeTask enforces code change task requirements.
+func validateCodeChangeTask(task spec.TaskConfig, fieldPrefix string, add issueAdder) {
+	if strings.TrimSpace(task.Prompt) == "" {
+		add(fieldPrefix+".prompt", "is required")
+	}
+	if strings.TrimSpace(task.QuestionsFile) != "" {
+		add(fieldPrefix+".questions_file", "is only valid for question_eval tasks")
+	}
+	commands := 0
+	for _, command := range task.VerifyCommands {
+		if strings.TrimSpace(command) != "" {
+			commands++
+		}
+	}
+	if commands == 0 {
+		add(fieldPrefix+".verify_commands", "must include at least one command")
+	}
+}
@@ -1,2 +1,2 @@
-// Package metrics provides metrics-related functionality.
+// Package metrics exposes ratelimiterd metrics in the Prometheus text format.
 package metrics
@@ -0,0 +1,193 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"cogni/internal/backend"
+	"cogni/pkg/ratelimiter"
+)
+
+// Reserve and complete outcomes used as the "outcome" label.
+const (
+	OutcomeAllowed             = "allowed"
+	OutcomeDenied              = "denied"
+	OutcomeUnknownLimitKey     = "unknown_limit_key"
+	OutcomeLimitDecreasing     = "limit_decreasing"
+	OutcomeLimitDraining       = "limit_draining"
+	OutcomeForbiddenKey        = "forbidden_limit_key"
+	OutcomeMissingKey          = "missing_limit_key"
+	OutcomeForbiddenLease      = "forbidden_lease"
+	OutcomeUnsupportedPriority = "unsupported_priority"
+	OutcomeInvalidRequest      = "invalid_request"
+	OutcomeBackendError        = "backend_error"
+	OutcomeOK                  = "ok"
+)
+
+// latencyBuckets spans sub-millisecond memory decisions to slow TB batches.
+var latencyBuckets = []float64{0.0001, 0.00025, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1}
+
+// batchSizeBuckets covers batch requests up to the API maximum of 256.
+var batchSizeBuckets = []float64{1, 2, 4, 8, 16, 32, 64, 128, 256}
+
+const (
+	// usageTimeout bounds the backend lookup made during a scrape.
+	usageTimeout = 2 * time.Second
+	// usageMaxAge lets the per-limit gauges of one scrape share a lookup.
+	usageMaxAge = time.Second
+)
+
+// Limiter records ratelimiterd request metrics. A nil Limiter records nothing.
+type Limiter struct {
+	reserves  *CounterVec
+	completes *CounterVec
+	latency   *HistogramVec
+	batchSize *HistogramVec
+}
+
+// NewLimiter registers the request metrics on reg.
+func NewLimiter(reg *Registry) *Limiter {
+	return &Limiter{
+		reserves: reg.NewCounterVec("ratelimiterd_reserve_requests_total",
+			"Reserve requests by outcome, counting each item of a batch.", "outcome"),
+		completes: reg.NewCounterVec("ratelimiterd_complete_requests_total",
+			"Complete requests by outcome, counting each item of a batch.", "outcome"),
+		latency: reg.NewHistogramVec("ratelimiterd_request_duration_seconds",
+			"HTTP request latency by endpoint.", latencyBuckets, "endpoint"),
+		batchSize: reg.NewHistogramVec("ratelimiterd_batch_size",
+			"Items per batch request by endpoint.", batchSizeBuckets, "endpoint"),
+	}
+}
+
+// ObserveReserve counts a reserve decision.
+func (m *Limiter) ObserveReserve(res ratelimiter.ReserveResponse, err error) {
+	if m == nil {
+		return
+	}
+	m.reserves.Inc(ReserveOutcome(res, err))
+}
+
+// ObserveComplete counts a complete result.
+func (m *Limiter) ObserveComplete(res ratelimiter.CompleteResponse, err error) {
+	if m == nil {
+		return
+	}
+	m.completes.Inc(CompleteOutcome(res, err))
+}
+
+// ObserveRequest records the latency of one HTTP request.
+func (m *Limiter) ObserveRequest(endpoint string, elapsed time.Duration) {
+	if m == nil {
+		return
+	}
+	m.latency.Observe(elapsed.Seconds(), endpoint)
+}
+
+// ObserveBatch records the number of items in a batch request.
+func (m *Limiter) ObserveBatch(endpoint string, size int) {
+	if m == nil {
+		return
+	}
+	m.batchSize.Observe(float64(size), endpoint)
+}
+
+// ReserveOutcome classifies a reserve response for the outcome label.
+func ReserveOutcome(res ratelimiter.ReserveResponse, err error) string {
+	code, _, _ := strings.Cut(res.Error, ":")
+	switch {
+	case err != nil || code == OutcomeBackendError:
+		return OutcomeBackendError
+	case res.Allowed:
+		return OutcomeAllowed
+	case code == OutcomeUnknownLimitKey, code == OutcomeLimitDecreasing, code == OutcomeLimitDraining, code == OutcomeForbiddenKey, code == OutcomeMissingKey, code == OutcomeUnsupportedPriority, code == OutcomeInvalidRequest:
+		return code
+	default:
+		return OutcomeDenied
+	}
+}
+
+// CompleteOutcome classifies a complete response for the outcome label.
+func CompleteOutcome(res ratelimiter.CompleteResponse, err error) string {
+	switch {
+	case err != nil || res.Error == OutcomeBackendError:
+		return OutcomeBackendError
+	case res.Ok:
+		return OutcomeOK
+	case res.Error == OutcomeInvalidRequest, res.Error == OutcomeForbiddenLease:
+		return res.Error
+	case strings.HasPrefix(res.Error, OutcomeForbiddenKey+":"):
+		return OutcomeForbiddenKey
+	default:
+		return OutcomeBackendError
+	}
+}
+
+// RegisterUsage exports per-limit capacity, effective capacity, used,
+// in-flight and debt gauges read from source at scrape time. Lookup errors
+// leave the gauges empty.
+func RegisterUsage(reg *Registry, source backend.UsageReporter) {
+	snapshot := &usageSnapshot{source: source}
+	gauge := func(name, help string, value func(backend.LimitUsage) uint64) {
+		reg.NewGaugeFunc(name, help, func() []Sample {
+			usage := snapshot.get()
+			samples := make([]Sample, 0, len(usage))
+			for _, entry := range usage {
+				samples = append(samples, Sample{
+					Labels: []string{string(entry.Key), string(entry.Kind)},
+					Value:  float64(value(entry)),
+				})
+			}
+			return samples
+		}, "key", "kind")
+	}
+	gauge("ratelimiterd_limit_capacity", "Configured capacity per limit.",
+		func(u backend.LimitUsage) uint64 { return u.Capacity })
+	gauge("ratelimiterd_limit_effective_capacity", "Capacity enforced per limit after adaptive backoff.",
+		func(u backend.LimitUsage) uint64 {
+			if u.EffectiveCapacity > 0 {
+				return u.EffectiveCapacity
+			}
+			return u.Capacity
+		})
+	gauge("ratelimiterd_limit_used", "Capacity currently consumed per limit.",
+		func(u backend.LimitUsage) uint64 { return u.Used })
+	gauge("ratelimiterd_limit_in_flight", "Amount held by uncompleted leases per limit.",
+		func(u backend.LimitUsage) uint64 { return u.InFlight })
+	gauge("ratelimiterd_limit_debt", "Recorded overage debt per limit.",
+		func(u backend.LimitUsage) uint64 { return u.Debt })
+}
+
+// usageSnapshot shares one backend lookup across the gauges of a scrape.
+type usageSnapshot struct {
+	source backend.UsageReporter
+
+	mu    sync.Mutex
+	at    time.Time
+	usage []backend.LimitUsage
+}
+
+// get returns usage no older than usageMaxAge, refreshing it if needed.
+func (s *usageSnapshot) get() []backend.LimitUsage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.at.IsZero() && time.Since(s.at) < usageMaxAge {
+		return s.usage
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), usageTimeout)
+	defer cancel()
+	usage, err := s.source.Usage(ctx)
+	if err != nil {
+		usage = nil
+	}
+	s.at, s.usage = time.Now(), usage
+	return usage
+}
+
+// RegisterQueueDepth exports the TigerBeetle submitter queue depth.
+func RegisterQueueDepth(reg *Registry, depth func() int) {
+	reg.NewGaugeFunc("ratelimiterd_tb_submitter_queue_depth",
+		"Work items waiting for the TigerBeetle transfer submitter.",
+		func() []Sample { return []Sample{{Value: float64(depth())}} })
+}
@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"cogni/internal/backend"
+	"cogni/pkg/ratelimiter"
+)
+
+type usageStub struct {
+	calls int
+	usage []backend.LimitUsage
+}
+
+func (s *usageStub) Usage(context.Context) ([]backend.LimitUsage, error) {
+	s.calls++
+	return s.usage, nil
+}
+
+// TestRegistryWritesPrometheusText verifies counters, histograms and gauges
+// render in the text exposition format.
+func TestRegistryWritesPrometheusText(t *testing.T) {
+	reg := NewRegistry()
+	limiter := NewLimiter(reg)
+	limiter.ObserveReserve(ratelimiter.ReserveResponse{Allowed: true}, nil)
+	limiter.ObserveReserve(ratelimiter.ReserveResponse{Allowed: true}, nil)
+	limiter.ObserveReserve(ratelimiter.ReserveResponse{RetryAfterMs: 10}, nil)
+	limiter.ObserveComplete(ratelimiter.CompleteResponse{}, errors.New("tb down"))
+	limiter.ObserveRequest("reserve", 3*time.Millisecond)
+	limiter.ObserveBatch("reserve_batch", 3)
+	source := &usageStub{usage: []backend.LimitUsage{{Key: "k\"1", Kind: ratelimiter.KindRolling, Capacity: 100, Used: 40, InFlight: 10, Debt: 2}}}
+	RegisterUsage(reg, source)
+	RegisterQueueDepth(reg, func() int { return 7 })
+
+	var out strings.Builder
+	reg.WriteText(&out)
+	text := out.String()
+	for _, want := range []string{
+		"# TYPE ratelimiterd_reserve_requests_total counter",
+		`ratelimiterd_reserve_requests_total{outcome="allowed"} 2`,
+		`ratelimiterd_reserve_requests_total{outcome="denied"} 1`,
+		`ratelimiterd_complete_requests_total{outcome="backend_error"} 1`,
+		"# TYPE ratelimiterd_request_duration_seconds histogram",
+		`ratelimiterd_request_duration_seconds_bucket{endpoint="reserve",le="0.0025"} 0`,
+		`ratelimiterd_request_duration_seconds_bucket{endpoint="reserve",le="0.005"} 1`,
+		`ratelimiterd_request_duration_seconds_bucket{endpoint="reserve",le="+Inf"} 1`,
+		`ratelimiterd_request_duration_seconds_count{endpoint="reserve"} 1`,
+		`ratelimiterd_batch_size_bucket{endpoint="reserve_batch",le="4"} 1`,
+		`ratelimiterd_limit_capacity{key="k\"1",kind="rolling"} 100`,
+		`ratelimiterd_limit_used{key="k\"1",kind="rolling"} 40`,
+		`ratelimiterd_limit_in_flight{key="k\"1",kind="rolling"} 10`,
+		`ratelimiterd_limit_debt{key="k\"1",kind="rolling"} 2`,
+		"ratelimiterd_tb_submitter_queue_depth 7",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in:\n%s", want, text)
+		}
+	}
+	if source.calls != 1 {
+		t.Fatalf("expected one usage lookup per scrape, got %d", source.calls)
+	}
+}
+
+// TestReserveOutcome verifies reserve responses map to outcome labels.
+func TestReserveOutcome(t *testing.T) {
+	cases := []struct {
+		res  ratelimiter.ReserveResponse
+		err  error
+		want string
+	}{
+		{res: ratelimiter.ReserveResponse{Allowed: true}, want: OutcomeAllowed},
+		{res: ratelimiter.ReserveResponse{RetryAfterMs: 5}, want: OutcomeDenied},
+		{res: ratelimiter.ReserveResponse{Error: "unknown_limit_key:k"}, want: OutcomeUnknownLimitKey},
+		{res: ratelimiter.ReserveResponse{Error: "limit_decreasing:k"}, want: OutcomeLimitDecreasing},
+		{res: ratelimiter.ReserveResponse{Error: "missing_limit_key:k"}, want: OutcomeMissingKey},
+		{res: ratelimiter.ReserveResponse{Error: "unsupported_priority:low"}, want: OutcomeUnsupportedPriority},
+		{res: ratelimiter.ReserveResponse{Error: "invalid_request"}, want: OutcomeInvalidRequest},
+		{res: ratelimiter.ReserveResponse{Error: "backend_error"}, want: OutcomeBackendError},
+		{err: errors.New("boom"), want: OutcomeBackendError},
+	}
+	for _, tc := range cases {
+		if got := ReserveOutcome(tc.res, tc.err); got != tc.want {
+			t.Fatalf("ReserveOutcome(%+v, %v) = %q, want %q", tc.res, tc.err, got, tc.want)
+		}
+	}
+}
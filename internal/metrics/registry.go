@@ -0,0 +1,290 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Registry holds metric families and renders them in the Prometheus text
+// exposition format.
+type Registry struct {
+	mu       sync.Mutex
+	families []family
+}
+
+// family is a named group of samples rendered together.
+type family interface {
+	write(w io.Writer)
+}
+
+// Sample is a single gauge value with its label values.
+type Sample struct {
+	Labels []string
+	Value  float64
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// register adds a family in registration order.
+func (r *Registry) register(f family) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.families = append(r.families, f)
+}
+
+// WriteText renders every family in the Prometheus text format.
+func (r *Registry) WriteText(w io.Writer) {
+	r.mu.Lock()
+	families := append([]family(nil), r.families...)
+	r.mu.Unlock()
+	for _, f := range families {
+		f.write(w)
+	}
+}
+
+// Handler serves the registry for Prometheus scrapes.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		r.WriteText(w)
+	})
+}
+
+// CounterVec is a monotonically increasing counter partitioned by labels.
+type CounterVec struct {
+	desc
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+// NewCounterVec registers a counter family.
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{desc: desc{name: name, help: help, kind: "counter", labels: labels}, values: map[string]float64{}}
+	r.register(c)
+	return c
+}
+
+// Inc adds one to the counter for the label values.
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds delta to the counter for the label values.
+func (c *CounterVec) Add(delta float64, labelValues ...string) {
+	key := joinLabelValues(labelValues)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] += delta
+}
+
+// Value returns the current count for the label values.
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.values[joinLabelValues(labelValues)]
+}
+
+// write renders the counter family.
+func (c *CounterVec) write(w io.Writer) {
+	c.mu.Lock()
+	samples := make([]Sample, 0, len(c.values))
+	for key, value := range c.values {
+		samples = append(samples, Sample{Labels: splitLabelValues(key), Value: value})
+	}
+	c.mu.Unlock()
+	c.writeHeader(w)
+	for _, sample := range sortSamples(samples) {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, c.labelPairs(sample.Labels), formatValue(sample.Value))
+	}
+}
+
+// HistogramVec counts observations into cumulative buckets, partitioned by labels.
+type HistogramVec struct {
+	desc
+	buckets []float64
+	mu      sync.Mutex
+	series  map[string]*histogram
+}
+
+// histogram is one labelled series of a HistogramVec.
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewHistogramVec registers a histogram family with ascending upper bounds.
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	h := &HistogramVec{
+		desc:    desc{name: name, help: help, kind: "histogram", labels: labels},
+		buckets: append([]float64(nil), buckets...),
+		series:  map[string]*histogram{},
+	}
+	r.register(h)
+	return h
+}
+
+// Observe records value for the label values.
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	key := joinLabelValues(labelValues)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	series, ok := h.series[key]
+	if !ok {
+		series = &histogram{counts: make([]uint64, len(h.buckets))}
+		h.series[key] = series
+	}
+	for i, bound := range h.buckets {
+		if value <= bound {
+			series.counts[i]++
+		}
+	}
+	series.count++
+	series.sum += value
+}
+
+// Count returns the number of observations for the label values.
+func (h *HistogramVec) Count(labelValues ...string) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if series, ok := h.series[joinLabelValues(labelValues)]; ok {
+		return series.count
+	}
+	return 0
+}
+
+// write renders the histogram family with _bucket, _sum and _count series.
+func (h *HistogramVec) write(w io.Writer) {
+	h.mu.Lock()
+	keys := make([]string, 0, len(h.series))
+	for key := range h.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	h.writeHeader(w)
+	for _, key := range keys {
+		series := h.series[key]
+		labels := splitLabelValues(key)
+		for i, bound := range h.buckets {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairsWith(labels, "le", formatValue(bound)), series.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairsWith(labels, "le", "+Inf"), series.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, h.labelPairs(labels), formatValue(series.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, h.labelPairs(labels), series.count)
+	}
+	h.mu.Unlock()
+}
+
+// GaugeFunc is a gauge family whose samples are read at scrape time.
+type GaugeFunc struct {
+	desc
+	collect func() []Sample
+}
+
+// NewGaugeFunc registers a gauge family collected by fn on every scrape.
+func (r *Registry) NewGaugeFunc(name, help string, fn func() []Sample, labels ...string) *GaugeFunc {
+	g := &GaugeFunc{desc: desc{name: name, help: help, kind: "gauge", labels: labels}, collect: fn}
+	r.register(g)
+	return g
+}
+
+// write renders the gauge family.
+func (g *GaugeFunc) write(w io.Writer) {
+	g.writeHeader(w)
+	for _, sample := range sortSamples(g.collect()) {
+		fmt.Fprintf(w, "%s%s %s\n", g.name, g.labelPairs(sample.Labels), formatValue(sample.Value))
+	}
+}
+
+// desc names a family and its label names.
+type desc struct {
+	name   string
+	help   string
+	kind   string
+	labels []string
+}
+
+// writeHeader renders the HELP and TYPE lines.
+func (d desc) writeHeader(w io.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", d.name, escapeHelp(d.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", d.name, d.kind)
+}
+
+// labelPairs renders {name="value",...} for the family labels.
+func (d desc) labelPairs(values []string) string {
+	return d.labelPairsWith(values, "", "")
+}
+
+// labelPairsWith renders the family labels plus an optional extra label.
+func (d desc) labelPairsWith(values []string, extraName, extraValue string) string {
+	var pairs []string
+	for i, name := range d.labels {
+		value := ""
+		if i < len(values) {
+			value = values[i]
+		}
+		pairs = append(pairs, name+`="`+escapeLabel(value)+`"`)
+	}
+	if extraName != "" {
+		pairs = append(pairs, extraName+`="`+escapeLabel(extraValue)+`"`)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// labelSeparator joins label values into a map key; it cannot appear in
+// valid UTF-8 label values.
+const labelSeparator = "\xff"
+
+// joinLabelValues builds the series key for label values.
+func joinLabelValues(values []string) string {
+	return strings.Join(values, labelSeparator)
+}
+
+// splitLabelValues reverses joinLabelValues.
+func splitLabelValues(key string) []string {
+	if key == "" {
+		return nil
+	}
+	return strings.Split(key, labelSeparator)
+}
+
+// sortSamples orders samples by label values for stable output.
+func sortSamples(samples []Sample) []Sample {
+	sort.Slice(samples, func(i, j int) bool {
+		return joinLabelValues(samples[i].Labels) < joinLabelValues(samples[j].Labels)
+	})
+	return samples
+}
+
+// formatValue renders a float the way Prometheus expects.
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// escapeHelp escapes backslashes and newlines in HELP text.
+func escapeHelp(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(value)
+}
+
+// escapeLabel escapes backslashes, quotes and newlines in label values.
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
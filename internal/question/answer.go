@@ -1,6 +1,7 @@
 package question
 
 import (
+	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
@@ -70,3 +71,32 @@ func ParseAnswerFromOutput(output string) (ParsedAnswer, error) {
 	}
 	return ParseAnswerXML(fragment)
 }
+
+// ParseAnswerText parses a plain answer value, such as a structured tool argument.
+func ParseAnswerText(text string) (ParsedAnswer, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return ParsedAnswer{}, ErrEmptyAnswer
+	}
+	return ParsedAnswer{Raw: text, Normalized: NormalizeAnswerText(text)}, nil
+}
+
+// ParseAnswerJSON parses structured output of the form {"answer": "..."}.
+func ParseAnswerJSON(output string) (ParsedAnswer, error) {
+	trimmed := strings.TrimSpace(output)
+	trimmed = strings.TrimSuffix(strings.TrimPrefix(trimmed, "```json"), "```")
+	trimmed = strings.TrimSpace(trimmed)
+	if trimmed == "" {
+		return ParsedAnswer{}, ErrMissingAnswer
+	}
+	var payload struct {
+		Answer *string `json:"answer"`
+	}
+	if err := json.Unmarshal([]byte(trimmed), &payload); err != nil {
+		return ParsedAnswer{}, fmt.Errorf("parse answer json: %w", err)
+	}
+	if payload.Answer == nil {
+		return ParsedAnswer{}, ErrMissingAnswer
+	}
+	return ParseAnswerText(*payload.Answer)
+}
@@ -38,3 +38,20 @@ func TestParseAnswerXMLRejectsWrongRoot(t *testing.T) {
 		t.Fatalf("expected error")
 	}
 }
+
+// TestParseAnswerJSON verifies structured JSON answers are parsed.
+func TestParseAnswerJSON(t *testing.T) {
+	answer, err := ParseAnswerJSON("```json\n{\"answer\": \" Blue \"}\n```")
+	if err != nil {
+		t.Fatalf("parse answer: %v", err)
+	}
+	if answer.Raw != "Blue" || answer.Normalized != "blue" {
+		t.Fatalf("unexpected answer: %+v", answer)
+	}
+	if _, err := ParseAnswerJSON(`{"reason": "x"}`); !errors.Is(err, ErrMissingAnswer) {
+		t.Fatalf("expected missing answer error, got %v", err)
+	}
+	if _, err := ParseAnswerJSON(`{"answer": ""}`); !errors.Is(err, ErrEmptyAnswer) {
+		t.Fatalf("expected empty answer error, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package question
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -12,23 +13,145 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// LoadOptions configures how a question spec is resolved.
+type LoadOptions struct {
+	// Vars supplies values for {{ name }} placeholders. They take precedence
+	// over vars declared in spec files.
+	Vars map[string]string
+}
+
 // LoadSpec reads, parses, and validates a question specification file.
 func LoadSpec(path string) (Spec, error) {
-	data, err := os.ReadFile(path)
+	return LoadSpecWithOptions(path, LoadOptions{})
+}
+
+// LoadSpecWithOptions reads a question spec, resolves includes, preambles and
+// variables, and validates the combined question set.
+func LoadSpecWithOptions(path string, opts LoadOptions) (Spec, error) {
+	loader := &specLoader{collector: &issueCollector{}, visiting: map[string]bool{}}
+	questions, err := loader.load(path, opts.Vars)
 	if err != nil {
-		return Spec{}, fmt.Errorf("read question spec: %w", err)
+		return Spec{}, err
 	}
-	spec, err := parseSpec(data, path)
-	if err != nil {
+	normalized, err := NormalizeSpec(Spec{Version: 1, Questions: questions})
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		loader.collector.issues = append(loader.collector.issues, validationErr.Issues...)
+	} else if err != nil {
 		return Spec{}, err
 	}
-	normalized, err := NormalizeSpec(spec)
-	if err != nil {
+	if err := loader.collector.result(); err != nil {
 		return Spec{}, err
 	}
 	return normalized, nil
 }
 
+// specLoader resolves a spec file and its includes into a flat question list.
+type specLoader struct {
+	collector *issueCollector
+	visiting  map[string]bool
+}
+
+// load parses a spec file and returns its included questions followed by its own.
+// Vars declared by the file act as defaults for values passed down by includers.
+func (l *specLoader) load(path string, inherited map[string]string) ([]Question, error) {
+	key, err := filepath.Abs(path)
+	if err != nil {
+		key = path
+	}
+	if l.visiting[key] {
+		return nil, fmt.Errorf("question spec include cycle at %s", path)
+	}
+	l.visiting[key] = true
+	defer delete(l.visiting, key)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read question spec: %w", err)
+	}
+	spec, err := parseSpec(data, path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	fileSource := SourceLocation{File: path}
+	if spec.Version == 0 {
+		l.collector.addAt(fileSource, "version", "is required")
+	} else if spec.Version != 1 {
+		l.collector.addAt(fileSource, "version", fmt.Sprintf("unsupported version %d", spec.Version))
+	}
+
+	vars := mergeVars(spec.Vars, inherited)
+	questions := make([]Question, 0, len(spec.Questions))
+	for i, include := range spec.Include {
+		include = strings.TrimSpace(include)
+		if include == "" {
+			l.collector.addAt(fileSource, fmt.Sprintf("include[%d]", i), "is required")
+			continue
+		}
+		includePath := include
+		if !filepath.IsAbs(includePath) {
+			includePath = filepath.Join(filepath.Dir(path), includePath)
+		}
+		included, err := l.load(includePath, vars)
+		if err != nil {
+			return nil, fmt.Errorf("include %q from %s: %w", include, path, err)
+		}
+		questions = append(questions, included...)
+	}
+
+	preamble, missing := substituteVars(strings.TrimSpace(spec.Preamble), vars)
+	l.reportMissing(fileSource, "preamble", missing)
+	lines := questionLines(data, path)
+	for i, item := range spec.Questions {
+		item.Source = SourceLocation{File: path, Index: i}
+		if i < len(lines) {
+			item.Source.Line = lines[i]
+		}
+		l.substituteQuestion(&item, i, vars)
+		if preamble != "" && strings.TrimSpace(item.Prompt) != "" {
+			item.Prompt = preamble + "\n\n" + strings.TrimSpace(item.Prompt)
+		}
+		questions = append(questions, item)
+	}
+	return questions, nil
+}
+
+// substituteQuestion applies vars to the prompt and answers of a question.
+func (l *specLoader) substituteQuestion(item *Question, index int, vars map[string]string) {
+	prefix := item.Source.fieldPrefix(index)
+	var missing []string
+	item.Prompt, missing = substituteVars(item.Prompt, vars)
+	l.reportMissing(item.Source, prefix+".question", missing)
+	item.Answers = substituteSlice(item.Answers, vars, func(i int, names []string) {
+		l.reportMissing(item.Source, fmt.Sprintf("%s.answers[%d]", prefix, i), names)
+	})
+	item.CorrectAnswers = substituteSlice(item.CorrectAnswers, vars, func(i int, names []string) {
+		l.reportMissing(item.Source, fmt.Sprintf("%s.correct_answers[%d]", prefix, i), names)
+	})
+}
+
+// reportMissing records an issue for undefined variables.
+func (l *specLoader) reportMissing(source SourceLocation, field string, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	l.collector.addAt(source, field, fmt.Sprintf("undefined variable(s): %s", strings.Join(sortedUnique(names), ", ")))
+}
+
+// substituteSlice applies vars to each entry, reporting missing names by index.
+func substituteSlice(values []string, vars map[string]string, report func(index int, names []string)) []string {
+	if values == nil {
+		return nil
+	}
+	out := make([]string, len(values))
+	for i, value := range values {
+		var missing []string
+		out[i], missing = substituteVars(value, vars)
+		report(i, missing)
+	}
+	return out
+}
+
 // parseSpec chooses the JSON or YAML decoder based on the file extension.
 func parseSpec(data []byte, path string) (Spec, error) {
 	ext := strings.ToLower(filepath.Ext(path))
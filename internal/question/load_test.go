@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -100,3 +101,148 @@ questions:
 		t.Fatalf("expected issues to be populated")
 	}
 }
+
+// TestLoadSpecResolvesIncludesVarsAndPreamble verifies shared fixtures are merged.
+func TestLoadSpecResolvesIncludesVarsAndPreamble(t *testing.T) {
+	dir := t.TempDir()
+	shared := `version: 1
+vars:
+  pkg: default
+preamble: "Module {{ module_path }}."
+questions:
+  - id: shared1
+    question: "Where is {{pkg}} defined?"
+    answers: ["{{pkg}}/a.go", "other.go"]
+    correct_answers: ["{{pkg}}/a.go"]
+`
+	root := `version: 1
+include: ["common/shared.yml"]
+vars:
+  pkg: internal/core
+questions:
+  - id: local1
+    question: "Repo {{ repo_name }}?"
+    answers: ["yes", "no"]
+    correct_answers: ["yes"]
+`
+	if err := os.MkdirAll(filepath.Join(dir, "common"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "common", "shared.yml"), []byte(shared), 0o644); err != nil {
+		t.Fatalf("write shared: %v", err)
+	}
+	rootPath := filepath.Join(dir, "questions.yml")
+	if err := os.WriteFile(rootPath, []byte(root), 0o644); err != nil {
+		t.Fatalf("write root: %v", err)
+	}
+	spec, err := LoadSpecWithOptions(rootPath, LoadOptions{Vars: map[string]string{"repo_name": "demo", "module_path": "example.com/demo"}})
+	if err != nil {
+		t.Fatalf("load spec: %v", err)
+	}
+	if len(spec.Questions) != 2 || spec.Questions[0].ID != "shared1" || spec.Questions[1].ID != "local1" {
+		t.Fatalf("unexpected questions: %+v", spec.Questions)
+	}
+	included := spec.Questions[0]
+	if included.Prompt != "Module example.com/demo.\n\nWhere is internal/core defined?" {
+		t.Fatalf("unexpected included prompt: %q", included.Prompt)
+	}
+	if included.CorrectAnswers[0] != "internal/core/a.go" {
+		t.Fatalf("expected includer vars to override defaults, got %+v", included.CorrectAnswers)
+	}
+	if included.Source.File != filepath.Join(dir, "common", "shared.yml") || included.Source.Line != 6 {
+		t.Fatalf("unexpected source: %+v", included.Source)
+	}
+	if spec.Questions[1].Prompt != "Repo demo?" {
+		t.Fatalf("unexpected local prompt: %q", spec.Questions[1].Prompt)
+	}
+}
+
+// TestLoadSpecReportsSourceLocations verifies issues carry file and line.
+func TestLoadSpecReportsSourceLocations(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "questions.json")
+	payload := `{
+  "version": 1,
+  "questions": [
+    {"id": "q1", "question": "Ok?", "answers": ["a"], "correct_answers": ["a"]},
+    {"id": "q2", "question": "{{missing}}", "answers": ["a"], "correct_answers": ["b"]}
+  ]
+}`
+	if err := os.WriteFile(path, []byte(payload), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+	_, err := LoadSpec(path)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	for _, issue := range validationErr.Issues {
+		if issue.Source.File != path || issue.Source.Line != 5 {
+			t.Fatalf("expected issue at %s:5, got %+v", path, issue)
+		}
+	}
+	if len(validationErr.Issues) != 2 {
+		t.Fatalf("expected missing variable and unknown answer issues, got %+v", validationErr.Issues)
+	}
+}
+
+// TestLoadSpecReportsIncludedEntryIndex verifies issues name the entry index within its own file.
+func TestLoadSpecReportsIncludedEntryIndex(t *testing.T) {
+	dir := t.TempDir()
+	shared := "version: 1\nquestions:\n  - {id: s1, question: ok, answers: [a], correct_answers: [a]}\n  - {id: s2, question: bad, answers: [a], correct_answers: [b]}\n"
+	root := "version: 1\ninclude: [shared.yml]\nquestions:\n  - {id: r1, question: ok, answers: [a], correct_answers: [a]}\n  - {id: r2, question: ok, answers: [a], correct_answers: [c]}\n"
+	sharedPath := filepath.Join(dir, "shared.yml")
+	rootPath := filepath.Join(dir, "questions.yml")
+	if err := os.WriteFile(sharedPath, []byte(shared), 0o644); err != nil {
+		t.Fatalf("write shared: %v", err)
+	}
+	if err := os.WriteFile(rootPath, []byte(root), 0o644); err != nil {
+		t.Fatalf("write root: %v", err)
+	}
+	_, err := LoadSpec(rootPath)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if len(validationErr.Issues) != 2 {
+		t.Fatalf("expected two issues, got %+v", validationErr.Issues)
+	}
+	want := map[string]string{sharedPath: "questions[1].correct_answers[0]", rootPath: "questions[1].correct_answers[0]"}
+	for _, issue := range validationErr.Issues {
+		if want[issue.Source.File] != issue.Field {
+			t.Fatalf("unexpected issue location: %+v", issue)
+		}
+	}
+}
+
+// TestNormalizeSpecLeavesCallerAnchorsUntouched verifies anchors are normalized on a copy.
+func TestNormalizeSpecLeavesCallerAnchorsUntouched(t *testing.T) {
+	anchors := []Anchor{{Path: "  main.go  ", StartLine: 1}}
+	item := Question{ID: "q1", Prompt: "Where?", Answers: []string{"a"}, CorrectAnswers: []string{"a"}, Anchors: anchors}
+	normalized, err := NormalizeSpec(Spec{Version: 1, Questions: []Question{item}})
+	if err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	if normalized.Questions[0].Anchors[0].Path != "main.go" {
+		t.Fatalf("expected trimmed anchor path, got %q", normalized.Questions[0].Anchors[0].Path)
+	}
+	if anchors[0].Path != "  main.go  " {
+		t.Fatalf("expected caller anchors unchanged, got %q", anchors[0].Path)
+	}
+}
+
+// TestLoadSpecRejectsIncludeCycle verifies include cycles are detected.
+func TestLoadSpecRejectsIncludeCycle(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.yml")
+	b := filepath.Join(dir, "b.yml")
+	if err := os.WriteFile(a, []byte("version: 1\ninclude: [b.yml]\n"), 0o644); err != nil {
+		t.Fatalf("write a: %v", err)
+	}
+	if err := os.WriteFile(b, []byte("version: 1\ninclude: [a.yml]\n"), 0o644); err != nil {
+		t.Fatalf("write b: %v", err)
+	}
+	if _, err := LoadSpec(a); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expected include cycle error, got %v", err)
+	}
+}
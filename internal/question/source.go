@@ -0,0 +1,102 @@
+package question
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// questionLines returns the 1-based line of each entry in the questions list.
+// Lines are best effort; a nil slice means positions could not be determined.
+func questionLines(data []byte, path string) []int {
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		return jsonQuestionLines(data)
+	}
+	return yamlQuestionLines(data)
+}
+
+// yamlQuestionLines reads question entry lines from the YAML node tree.
+func yamlQuestionLines(data []byte) []int {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != "questions" {
+			continue
+		}
+		items := root.Content[i+1]
+		if items.Kind != yaml.SequenceNode {
+			return nil
+		}
+		lines := make([]int, 0, len(items.Content))
+		for _, item := range items.Content {
+			lines = append(lines, item.Line)
+		}
+		return lines
+	}
+	return nil
+}
+
+// jsonQuestionLines walks top-level JSON tokens to find question entry offsets.
+func jsonQuestionLines(data []byte) []int {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil
+	}
+	for decoder.More() {
+		keyToken, err := decoder.Token()
+		if err != nil {
+			return nil
+		}
+		key, _ := keyToken.(string)
+		if key != "questions" {
+			var skip json.RawMessage
+			if err := decoder.Decode(&skip); err != nil {
+				return nil
+			}
+			continue
+		}
+		if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+			return nil
+		}
+		var lines []int
+		for decoder.More() {
+			lines = append(lines, lineAtOffset(data, nextNonSpace(data, int(decoder.InputOffset()))))
+			var skip json.RawMessage
+			if err := decoder.Decode(&skip); err != nil {
+				return nil
+			}
+		}
+		return lines
+	}
+	return nil
+}
+
+// nextNonSpace skips whitespace and commas starting at offset.
+func nextNonSpace(data []byte, offset int) int {
+	for offset < len(data) {
+		switch data[offset] {
+		case ' ', '\t', '\r', '\n', ',':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// lineAtOffset converts a byte offset into a 1-based line number.
+func lineAtOffset(data []byte, offset int) int {
+	if offset > len(data) {
+		offset = len(data)
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
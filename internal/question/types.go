@@ -1,15 +1,58 @@
 package question
 
+import "fmt"
+
 // Spec defines the question specification schema loaded from JSON or YAML.
 type Spec struct {
-	Version   int        `json:"version" yaml:"version"`
-	Questions []Question `json:"questions" yaml:"questions"`
+	Version   int               `json:"version" yaml:"version"`
+	Include   []string          `json:"include,omitempty" yaml:"include,omitempty"`
+	Vars      map[string]string `json:"vars,omitempty" yaml:"vars,omitempty"`
+	Preamble  string            `json:"preamble,omitempty" yaml:"preamble,omitempty"`
+	Questions []Question        `json:"questions" yaml:"questions"`
 }
 
 // Question represents a single question with answer choices and correct answers.
 type Question struct {
-	ID             string   `json:"id" yaml:"id"`
-	Prompt         string   `json:"question" yaml:"question"`
-	Answers        []string `json:"answers" yaml:"answers"`
-	CorrectAnswers []string `json:"correct_answers" yaml:"correct_answers"`
+	ID             string         `json:"id" yaml:"id"`
+	Prompt         string         `json:"question" yaml:"question"`
+	Answers        []string       `json:"answers" yaml:"answers"`
+	CorrectAnswers []string       `json:"correct_answers" yaml:"correct_answers"`
+	Anchors        []Anchor       `json:"anchors,omitempty" yaml:"anchors,omitempty"`
+	Tags           []string       `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Source         SourceLocation `json:"-" yaml:"-"`
+}
+
+// Anchor points at the repository code that supports a question's answer.
+type Anchor struct {
+	Path      string `json:"path" yaml:"path"`
+	StartLine int    `json:"start_line,omitempty" yaml:"start_line,omitempty"`
+	EndLine   int    `json:"end_line,omitempty" yaml:"end_line,omitempty"`
+}
+
+// SourceLocation identifies where a question was declared. Index is the
+// entry's position in its own file's questions list.
+type SourceLocation struct {
+	File  string
+	Line  int
+	Index int
+}
+
+// fieldPrefix names a question entry for issues: its index within the
+// declaring file when known, otherwise its position in the combined list.
+func (loc SourceLocation) fieldPrefix(combined int) string {
+	if loc.File != "" {
+		return fmt.Sprintf("questions[%d]", loc.Index)
+	}
+	return fmt.Sprintf("questions[%d]", combined)
+}
+
+// String renders the location as file:line, omitting unknown parts.
+func (loc SourceLocation) String() string {
+	if loc.File == "" {
+		return ""
+	}
+	if loc.Line <= 0 {
+		return loc.File
+	}
+	return fmt.Sprintf("%s:%d", loc.File, loc.Line)
 }
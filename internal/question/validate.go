@@ -9,6 +9,7 @@ import (
 type Issue struct {
 	Field   string
 	Message string
+	Source  SourceLocation
 }
 
 // ValidationError reports one or more validation issues.
@@ -23,6 +24,10 @@ func (err *ValidationError) Error() string {
 	}
 	parts := make([]string, 0, len(err.Issues))
 	for _, issue := range err.Issues {
+		if location := issue.Source.String(); location != "" {
+			parts = append(parts, fmt.Sprintf("%s: %s: %s", location, issue.Field, issue.Message))
+			continue
+		}
 		parts = append(parts, fmt.Sprintf("%s: %s", issue.Field, issue.Message))
 	}
 	return fmt.Sprintf("question spec validation failed: %s", strings.Join(parts, "; "))
@@ -34,7 +39,12 @@ type issueCollector struct {
 
 // add records a validation issue.
 func (collector *issueCollector) add(field, message string) {
-	collector.issues = append(collector.issues, Issue{Field: field, Message: message})
+	collector.addAt(SourceLocation{}, field, message)
+}
+
+// addAt records a validation issue attributed to a source location.
+func (collector *issueCollector) addAt(source SourceLocation, field, message string) {
+	collector.issues = append(collector.issues, Issue{Field: field, Message: message, Source: source})
 }
 
 // result returns a validation error when issues are present.
@@ -59,11 +69,11 @@ func NormalizeSpec(spec Spec) (Spec, error) {
 
 	seenIDs := map[string]struct{}{}
 	for i, question := range spec.Questions {
-		prefix := fmt.Sprintf("questions[%d]", i)
+		prefix := question.Source.fieldPrefix(i)
 		question.ID = strings.TrimSpace(question.ID)
 		if question.ID != "" {
 			if _, exists := seenIDs[question.ID]; exists {
-				collector.add(prefix+".id", fmt.Sprintf("duplicate id %q", question.ID))
+				collector.addAt(question.Source, prefix+".id", fmt.Sprintf("duplicate id %q", question.ID))
 			} else {
 				seenIDs[question.ID] = struct{}{}
 			}
@@ -71,23 +81,23 @@ func NormalizeSpec(spec Spec) (Spec, error) {
 
 		question.Prompt = strings.TrimSpace(question.Prompt)
 		if question.Prompt == "" {
-			collector.add(prefix+".question", "is required")
+			collector.addAt(question.Source, prefix+".question", "is required")
 		}
 
 		question.Answers = normalizeStringSlice(question.Answers)
 		if len(question.Answers) == 0 {
-			collector.add(prefix+".answers", "must include at least one entry")
+			collector.addAt(question.Source, prefix+".answers", "must include at least one entry")
 		} else {
 			for answerIndex, answer := range question.Answers {
 				if answer == "" {
-					collector.add(fmt.Sprintf("%s.answers[%d]", prefix, answerIndex), "is required")
+					collector.addAt(question.Source, fmt.Sprintf("%s.answers[%d]", prefix, answerIndex), "is required")
 				}
 			}
 		}
 
 		question.CorrectAnswers = normalizeStringSlice(question.CorrectAnswers)
 		if len(question.CorrectAnswers) == 0 {
-			collector.add(prefix+".correct_answers", "must include at least one entry")
+			collector.addAt(question.Source, prefix+".correct_answers", "must include at least one entry")
 		} else {
 			answerSet := map[string]struct{}{}
 			for _, answer := range question.Answers {
@@ -98,11 +108,36 @@ func NormalizeSpec(spec Spec) (Spec, error) {
 			}
 			for correctIndex, correct := range question.CorrectAnswers {
 				if correct == "" {
-					collector.add(fmt.Sprintf("%s.correct_answers[%d]", prefix, correctIndex), "is required")
+					collector.addAt(question.Source, fmt.Sprintf("%s.correct_answers[%d]", prefix, correctIndex), "is required")
 					continue
 				}
 				if _, ok := answerSet[NormalizeAnswerText(correct)]; !ok {
-					collector.add(fmt.Sprintf("%s.correct_answers[%d]", prefix, correctIndex), fmt.Sprintf("unknown answer %q", correct))
+					collector.addAt(question.Source, fmt.Sprintf("%s.correct_answers[%d]", prefix, correctIndex), fmt.Sprintf("unknown answer %q", correct))
+				}
+			}
+		}
+		if question.Anchors != nil {
+			// Copy so normalizing never rewrites the caller's anchors.
+			question.Anchors = append([]Anchor(nil), question.Anchors...)
+		}
+		for anchorIndex, anchor := range question.Anchors {
+			field := fmt.Sprintf("%s.anchors[%d]", prefix, anchorIndex)
+			anchor.Path = strings.TrimSpace(anchor.Path)
+			if anchor.Path == "" {
+				collector.addAt(question.Source, field+".path", "is required")
+			}
+			if anchor.StartLine < 0 || anchor.EndLine < 0 {
+				collector.addAt(question.Source, field, "line numbers must be >= 0")
+			} else if anchor.EndLine > 0 && anchor.EndLine < anchor.StartLine {
+				collector.addAt(question.Source, field+".end_line", "must be >= start_line")
+			}
+			question.Anchors[anchorIndex] = anchor
+		}
+		if len(question.Tags) > 0 {
+			question.Tags = normalizeStringSlice(question.Tags)
+			for tagIndex, tag := range question.Tags {
+				if tag == "" {
+					collector.addAt(question.Source, fmt.Sprintf("%s.tags[%d]", prefix, tagIndex), "is required")
 				}
 			}
 		}
@@ -0,0 +1,95 @@
+package question
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// variablePattern matches {{ name }} placeholders in question text.
+var variablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_.]*)\s*\}\}`)
+
+// BuiltinVars returns variables derived from the repository at repoRoot:
+// repo_name, repo_root and, when a go.mod is present, module_path.
+func BuiltinVars(repoRoot string) map[string]string {
+	return BuiltinVarsAt(repoRoot, repoRoot)
+}
+
+// BuiltinVarsAt is BuiltinVars for a checkout of the repository at repoRoot
+// made elsewhere, such as a --rev workspace. repo_name and repo_root still
+// name repoRoot, so prompts match runs in the repository itself, while
+// module_path is read from the checkout.
+func BuiltinVarsAt(repoRoot, checkout string) map[string]string {
+	vars := map[string]string{}
+	if strings.TrimSpace(repoRoot) == "" {
+		return vars
+	}
+	vars["repo_name"] = filepath.Base(repoRoot)
+	vars["repo_root"] = repoRoot
+	if modulePath := readModulePath(filepath.Join(checkout, "go.mod")); modulePath != "" {
+		vars["module_path"] = modulePath
+	}
+	return vars
+}
+
+// readModulePath returns the module directive from a go.mod file, if readable.
+func readModulePath(path string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if rest, ok := strings.CutPrefix(line, "module "); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`)
+		}
+	}
+	return ""
+}
+
+// mergeVars layers overrides on top of base without mutating either map.
+func mergeVars(base, overrides map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(overrides))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range overrides {
+		merged[key] = value
+	}
+	return merged
+}
+
+// substituteVars replaces {{ name }} placeholders and returns any unknown names.
+func substituteVars(text string, vars map[string]string) (string, []string) {
+	var missing []string
+	replaced := variablePattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := variablePattern.FindStringSubmatch(match)[1]
+		value, ok := vars[name]
+		if !ok {
+			missing = append(missing, name)
+			return match
+		}
+		return value
+	})
+	return replaced, missing
+}
+
+// sortedUnique returns the distinct values in sorted order.
+func sortedUnique(values []string) []string {
+	seen := map[string]struct{}{}
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		unique = append(unique, value)
+	}
+	sort.Strings(unique)
+	return unique
+}
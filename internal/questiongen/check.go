@@ -0,0 +1,87 @@
+package questiongen
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cogni/internal/question"
+)
+
+// checkCandidates normalizes proposed questions, assigns missing or duplicate
+// IDs, and records issues such as invalid fields or anchors that do not exist.
+func checkCandidates(proposed []question.Question, repoRoot string) []Candidate {
+	candidates := make([]Candidate, 0, len(proposed))
+	seenIDs := map[string]struct{}{}
+	for i, item := range proposed {
+		item.ID = uniqueID(strings.TrimSpace(item.ID), i, seenIDs)
+		candidate := Candidate{Question: item}
+		normalized, err := question.NormalizeSpec(question.Spec{Version: 1, Questions: []question.Question{item}})
+		if err != nil {
+			candidate.Issues = append(candidate.Issues, validationMessages(err)...)
+		} else {
+			candidate.Question = normalized.Questions[0]
+		}
+		candidate.Issues = append(candidate.Issues, anchorIssues(candidate.Question.Anchors, repoRoot)...)
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
+
+// uniqueID returns id, or a generated ID when it is empty or already taken.
+func uniqueID(id string, index int, seen map[string]struct{}) string {
+	if id == "" {
+		id = fmt.Sprintf("generated-%d", index+1)
+	}
+	candidate := id
+	for suffix := 2; ; suffix++ {
+		if _, exists := seen[candidate]; !exists {
+			break
+		}
+		candidate = fmt.Sprintf("%s-%d", id, suffix)
+	}
+	seen[candidate] = struct{}{}
+	return candidate
+}
+
+// validationMessages flattens question validation errors into messages.
+func validationMessages(err error) []string {
+	var validationErr *question.ValidationError
+	if !errors.As(err, &validationErr) {
+		return []string{err.Error()}
+	}
+	messages := make([]string, 0, len(validationErr.Issues))
+	for _, issue := range validationErr.Issues {
+		field := strings.TrimPrefix(issue.Field, "questions[0].")
+		messages = append(messages, fmt.Sprintf("%s: %s", field, issue.Message))
+	}
+	return messages
+}
+
+// anchorIssues reports anchors that fall outside the repository or past the end of a file.
+func anchorIssues(anchors []question.Anchor, repoRoot string) []string {
+	var issues []string
+	for i, anchor := range anchors {
+		if anchor.Path == "" {
+			continue
+		}
+		field := fmt.Sprintf("anchors[%d]", i)
+		cleaned := filepath.Clean(filepath.FromSlash(anchor.Path))
+		if filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+			issues = append(issues, fmt.Sprintf("%s.path: %q is outside the repository", field, anchor.Path))
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(repoRoot, cleaned))
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("%s.path: file not found at %q", field, anchor.Path))
+			continue
+		}
+		lines := strings.Count(string(data), "\n") + 1
+		if anchor.EndLine > lines || anchor.StartLine > lines {
+			issues = append(issues, fmt.Sprintf("%s: lines %d-%d exceed %d lines in %q", field, anchor.StartLine, anchor.EndLine, lines, anchor.Path))
+		}
+	}
+	return issues
+}
@@ -0,0 +1,2 @@
+// Package questiongen drafts question specs by having an agent explore a repository.
+package questiongen
@@ -0,0 +1,105 @@
+package questiongen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"cogni/internal/agent"
+	"cogni/internal/agent/call"
+	"cogni/internal/runner"
+	"cogni/internal/spec"
+	"cogni/internal/tools"
+)
+
+// Generate asks the configured agent to explore the repository and propose
+// candidate questions, then checks each candidate against the repository.
+func Generate(ctx context.Context, params Params) (Result, error) {
+	if strings.TrimSpace(params.RepoRoot) == "" {
+		return Result{}, errors.New("repo root is required")
+	}
+	count := params.Count
+	if count <= 0 {
+		count = defaultCount
+	}
+	model := params.Model
+	if model == "" {
+		model = params.Agent.Model
+	}
+	providerFactory := params.Deps.ProviderFactory
+	if providerFactory == nil {
+		providerFactory = func(agentConfig spec.AgentConfig, model string) (agent.Provider, error) {
+			return agent.ProviderFromEnv(agentConfig.Provider, model, nil)
+		}
+	}
+	toolRunnerFactory := params.Deps.ToolRunnerFactory
+	if toolRunnerFactory == nil {
+		toolRunnerFactory = func(root string) (*tools.Runner, error) {
+			return tools.NewRunner(root)
+		}
+	}
+	tokenCounter := params.Deps.TokenCounter
+	if tokenCounter == nil {
+		tokenCounter = agent.ApproxTokenCount
+	}
+	verboseWriter := params.VerboseWriter
+	if params.Verbose && verboseWriter == nil {
+		verboseWriter = os.Stdout
+	}
+
+	provider, err := providerFactory(params.Agent, model)
+	if err != nil {
+		return Result{}, err
+	}
+	toolRunner, err := toolRunnerFactory(params.RepoRoot)
+	if err != nil {
+		return Result{}, err
+	}
+	session := newSession(model, params.RepoRoot, params.Verbose)
+	maxSteps := params.Budget.MaxSteps
+	if maxSteps == 0 {
+		maxSteps = params.Agent.MaxSteps
+	}
+	if maxSteps == 0 {
+		maxSteps = defaultMaxSteps
+	}
+	callResult, err := call.RunCall(ctx, session, provider, agent.RunnerExecutor{Runner: toolRunner}, buildGeneratePrompt(count, params.Paths), call.RunOptions{
+		TokenCounter: tokenCounter,
+		Limits: call.RunLimits{
+			MaxSteps:   maxSteps,
+			MaxSeconds: time.Duration(params.Budget.MaxSeconds) * time.Second,
+			MaxTokens:  params.Budget.MaxTokens,
+		},
+		Verbose:          params.Verbose,
+		VerboseWriter:    verboseWriter,
+		VerboseLogWriter: params.VerboseLogWriter,
+		NoColor:          params.NoColor,
+	}, nil)
+	result := Result{Tokens: callResult.Metrics.Tokens, Steps: callResult.Metrics.Steps}
+	if err != nil {
+		return result, fmt.Errorf("generate questions: %w", err)
+	}
+	proposed, err := ParseCandidates(callResult.Output)
+	if err != nil {
+		return result, fmt.Errorf("generate questions: %w", err)
+	}
+	result.Candidates = checkCandidates(proposed, params.RepoRoot)
+	return result, nil
+}
+
+// newSession constructs an agent session with the read-only repository tools.
+func newSession(model, repoRoot string, verbose bool) *agent.Session {
+	ctx := agent.TurnContext{
+		Model:   model,
+		Tools:   runner.DefaultToolDefinitions(),
+		CWD:     repoRoot,
+		Verbose: verbose,
+	}
+	return &agent.Session{
+		Ctx:     ctx,
+		History: agent.BuildInitialContext(ctx),
+	}
+}
@@ -0,0 +1,169 @@
+package questiongen
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"cogni/internal/agent"
+	"cogni/internal/question"
+	"cogni/internal/runner"
+	"cogni/internal/spec"
+	"cogni/internal/testutil"
+	"cogni/internal/vcs"
+)
+
+// fakeStream replays a predetermined stream of events for tests.
+type fakeStream struct {
+	events []agent.StreamEvent
+	index  int
+}
+
+// Recv returns the next event or io.EOF for fakeStream.
+func (s *fakeStream) Recv() (agent.StreamEvent, error) {
+	if s.index >= len(s.events) {
+		return agent.StreamEvent{}, io.EOF
+	}
+	event := s.events[s.index]
+	s.index++
+	return event, nil
+}
+
+// fakeProvider returns a static assistant message for tests.
+type fakeProvider struct {
+	message string
+}
+
+// Stream returns a stream containing the configured message.
+func (p fakeProvider) Stream(_ context.Context, _ agent.Prompt) (agent.Stream, error) {
+	return &fakeStream{events: []agent.StreamEvent{{Type: agent.StreamEventMessage, Message: p.message}}}, nil
+}
+
+// providerFactory returns a factory that always yields the given message.
+func providerFactory(message string) runner.ProviderFactory {
+	return func(_ spec.AgentConfig, _ string) (agent.Provider, error) {
+		return fakeProvider{message: message}, nil
+	}
+}
+
+// writeRepo creates a small repository tree for anchor checks.
+func writeRepo(t *testing.T) string {
+	t.Helper()
+	repoRoot := t.TempDir()
+	if err := os.WriteFile(filepath.Join(repoRoot, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o644); err != nil {
+		t.Fatalf("write main.go: %v", err)
+	}
+	return repoRoot
+}
+
+// TestGenerateChecksCandidates verifies proposed questions are normalized and
+// candidates with invalid fields or missing anchors are flagged.
+func TestGenerateChecksCandidates(t *testing.T) {
+	repoRoot := writeRepo(t)
+	output := `Explored the repo.
+<questions>[
+  {"id": "entry", "question": " Which package is main.go in? ", "answers": ["main", "lib"], "correct_answers": ["main"], "anchors": [{"path": "main.go", "start_line": 1, "end_line": 1}]},
+  {"id": "entry", "question": "Which file defines main?", "answers": ["main.go", "cmd.go"], "correct_answers": ["main.go"], "anchors": [{"path": "cmd.go", "start_line": 1}]},
+  {"question": "Broken", "answers": ["a"], "correct_answers": ["b"]}
+]</questions>`
+	ctx := testutil.Context(t, 0)
+	result, err := Generate(ctx, Params{
+		RepoRoot: repoRoot,
+		Agent:    spec.AgentConfig{ID: "writer", Provider: "openrouter", Model: "model"},
+		Count:    3,
+		Deps:     Dependencies{ProviderFactory: providerFactory(output)},
+	})
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if len(result.Candidates) != 3 {
+		t.Fatalf("expected 3 candidates, got %d", len(result.Candidates))
+	}
+	first, second, third := result.Candidates[0], result.Candidates[1], result.Candidates[2]
+	if len(first.Issues) != 0 || first.Question.Prompt != "Which package is main.go in?" {
+		t.Fatalf("expected first candidate to be valid and trimmed, got %+v", first)
+	}
+	if second.Question.ID != "entry-2" || len(second.Issues) != 1 || !strings.Contains(second.Issues[0], "cmd.go") {
+		t.Fatalf("expected renamed candidate with missing anchor, got %+v", second)
+	}
+	if third.Question.ID != "generated-3" || len(third.Issues) == 0 {
+		t.Fatalf("expected invalid generated candidate, got %+v", third)
+	}
+	accepted := result.Accepted()
+	if len(accepted) != 1 || accepted[0].ID != "entry" {
+		t.Fatalf("expected only the first question accepted, got %+v", accepted)
+	}
+}
+
+// TestGenerateRequiresQuestionsBlock verifies missing output blocks are reported.
+func TestGenerateRequiresQuestionsBlock(t *testing.T) {
+	ctx := testutil.Context(t, 0)
+	_, err := Generate(ctx, Params{
+		RepoRoot: writeRepo(t),
+		Agent:    spec.AgentConfig{ID: "writer", Provider: "openrouter", Model: "model"},
+		Deps:     Dependencies{ProviderFactory: providerFactory("I could not find anything.")},
+	})
+	if err == nil || !strings.Contains(err.Error(), "<questions>") {
+		t.Fatalf("expected missing questions error, got %v", err)
+	}
+}
+
+// TestVerifyRecordsSecondAgentAnswers verifies candidates are accepted only when
+// the verifying agent answers correctly, and drafts round-trip through LoadSpec.
+func TestVerifyRecordsSecondAgentAnswers(t *testing.T) {
+	repoRoot := writeRepo(t)
+	candidates := []Candidate{
+		{Question: question.Question{ID: "q1", Prompt: "Pick main", Answers: []string{"main", "lib"}, CorrectAnswers: []string{"main"}}},
+		{Question: question.Question{ID: "q2", Prompt: "Pick lib", Answers: []string{"main", "lib"}, CorrectAnswers: []string{"lib"}}},
+		{Question: question.Question{ID: "q3", Prompt: "Skipped"}, Issues: []string{"answers: must include at least one entry"}},
+	}
+	cfg := spec.Config{
+		Repo:         spec.RepoConfig{OutputDir: "./out", SetupCommands: []string{"exit 1"}},
+		Agents:       []spec.AgentConfig{{ID: "checker", Type: "builtin", Provider: "openrouter", Model: "model"}},
+		DefaultAgent: "checker",
+	}
+	ctx := testutil.Context(t, 0)
+	verified, err := Verify(ctx, cfg, candidates, VerifyParams{
+		RepoRoot: repoRoot,
+		AgentID:  "checker",
+		Deps: runner.RunDependencies{
+			ProviderFactory: providerFactory("<answer>main</answer>"),
+			RepoRootResolver: func(_ context.Context, root string) (string, error) {
+				return root, nil
+			},
+			RepoMetadataLoader: func(_ context.Context, root string) (vcs.Metadata, error) {
+				return vcs.Metadata{Name: filepath.Base(root), VCS: "git", Commit: "commit"}, nil
+			},
+			RunID: func() (string, error) { return "run-1", nil },
+			Now:   time.Now,
+		},
+	})
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if verified[0].Verification == nil || !verified[0].Accepted() {
+		t.Fatalf("expected q1 accepted, got %+v", verified[0])
+	}
+	if verified[1].Verification == nil || verified[1].Accepted() || verified[1].Verification.Answer != "main" {
+		t.Fatalf("expected q2 rejected with answer main, got %+v", verified[1].Verification)
+	}
+	if verified[2].Verification != nil {
+		t.Fatalf("expected invalid candidate to stay unverified, got %+v", verified[2].Verification)
+	}
+
+	draftPath := filepath.Join(repoRoot, ".cogni", "questions", "draft.yml")
+	if err := WriteSpec(draftPath, Result{Candidates: verified}.Accepted()); err != nil {
+		t.Fatalf("write draft: %v", err)
+	}
+	loaded, err := question.LoadSpec(draftPath)
+	if err != nil {
+		t.Fatalf("load draft: %v", err)
+	}
+	if len(loaded.Questions) != 1 || loaded.Questions[0].ID != "q1" {
+		t.Fatalf("unexpected draft questions: %+v", loaded.Questions)
+	}
+}
@@ -0,0 +1,33 @@
+package questiongen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"cogni/internal/question"
+)
+
+// ErrMissingQuestions indicates the agent output had no <questions> block.
+var ErrMissingQuestions = errors.New("missing <questions> block")
+
+// ParseCandidates extracts proposed questions from the last <questions> block in output.
+func ParseCandidates(output string) ([]question.Question, error) {
+	start := strings.LastIndex(output, "<questions>")
+	if start < 0 {
+		return nil, ErrMissingQuestions
+	}
+	body := output[start+len("<questions>"):]
+	end := strings.Index(body, "</questions>")
+	if end < 0 {
+		return nil, ErrMissingQuestions
+	}
+	body = strings.TrimSpace(body[:end])
+	body = strings.TrimSuffix(strings.TrimPrefix(body, "```json"), "```")
+	var questions []question.Question
+	if err := json.Unmarshal([]byte(strings.TrimSpace(body)), &questions); err != nil {
+		return nil, fmt.Errorf("parse questions: %w", err)
+	}
+	return questions, nil
+}
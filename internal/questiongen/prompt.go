@@ -0,0 +1,24 @@
+package questiongen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// buildGeneratePrompt asks the agent to explore the repo and emit candidate questions.
+func buildGeneratePrompt(count int, paths []string) string {
+	var builder strings.Builder
+	builder.WriteString("You are writing a multiple-choice question bank that tests how well someone understands this repository.\n")
+	if len(paths) > 0 {
+		builder.WriteString("Focus on these paths: ")
+		builder.WriteString(strings.Join(paths, ", "))
+		builder.WriteString("\n")
+	}
+	builder.WriteString("Use the available tools to read the code before writing any question.\n")
+	builder.WriteString(fmt.Sprintf("Propose %d questions. Each question must be answerable from the code alone, have exactly one correct answer and 3-5 plausible answers, and cite the code that justifies the answer as anchors.\n", count))
+	builder.WriteString("Anchor paths are relative to the repository root; start_line and end_line are 1-based and inclusive.\n")
+	builder.WriteString("When you are done, output a JSON array in <questions></questions> tags, for example:\n")
+	builder.WriteString(`<questions>[{"id": "short-kebab-id", "question": "...", "answers": ["...", "..."], "correct_answers": ["..."], "anchors": [{"path": "pkg/file.go", "start_line": 10, "end_line": 24}]}]</questions>`)
+	builder.WriteString("\n")
+	return builder.String()
+}
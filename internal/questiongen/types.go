@@ -0,0 +1,79 @@
+package questiongen
+
+import (
+	"io"
+
+	"cogni/internal/agent"
+	"cogni/internal/question"
+	"cogni/internal/runner"
+	"cogni/internal/spec"
+)
+
+// defaultCount is the number of questions requested when Params.Count is unset.
+const defaultCount = 10
+
+// defaultMaxSteps bounds generation runs when neither params nor agent set a limit.
+const defaultMaxSteps = 60
+
+// Params configures a question generation run.
+type Params struct {
+	RepoRoot         string
+	Agent            spec.AgentConfig
+	Model            string
+	Paths            []string
+	Count            int
+	Budget           spec.TaskBudget
+	Verbose          bool
+	VerboseWriter    io.Writer
+	VerboseLogWriter io.Writer
+	NoColor          bool
+	Deps             Dependencies
+}
+
+// Dependencies allows injecting factories for generation runs.
+type Dependencies struct {
+	ProviderFactory   runner.ProviderFactory
+	ToolRunnerFactory runner.ToolRunnerFactory
+	TokenCounter      agent.TokenCounter
+}
+
+// Candidate is a proposed question with any problems found while checking it.
+type Candidate struct {
+	Question     question.Question
+	Issues       []string
+	Verification *Verification
+}
+
+// Verification records how a second agent answered a candidate question.
+type Verification struct {
+	AgentID string
+	Answer  string
+	Correct bool
+	Error   string
+}
+
+// Accepted reports whether a candidate passed checks and, when verified, was answered correctly.
+func (c Candidate) Accepted() bool {
+	if len(c.Issues) > 0 {
+		return false
+	}
+	return c.Verification == nil || c.Verification.Correct
+}
+
+// Result captures the outcome of a generation run.
+type Result struct {
+	Candidates []Candidate
+	Tokens     int
+	Steps      int
+}
+
+// Accepted returns the questions from accepted candidates in proposal order.
+func (r Result) Accepted() []question.Question {
+	accepted := make([]question.Question, 0, len(r.Candidates))
+	for _, candidate := range r.Candidates {
+		if candidate.Accepted() {
+			accepted = append(accepted, candidate.Question)
+		}
+	}
+	return accepted
+}
@@ -0,0 +1,102 @@
+package questiongen
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"cogni/internal/question"
+	"cogni/internal/runner"
+	"cogni/internal/spec"
+)
+
+// verifyTaskID names the synthetic task used to verify candidates.
+const verifyTaskID = "questions-generate-verify"
+
+// VerifyParams configures verification of candidate questions.
+type VerifyParams struct {
+	RepoRoot string
+	AgentID  string
+	Deps     runner.RunDependencies
+}
+
+// Verify has a second agent answer each valid candidate through a regular
+// question_eval run and records whether it answered correctly. Candidates with
+// issues are left unverified.
+func Verify(ctx context.Context, cfg spec.Config, candidates []Candidate, params VerifyParams) ([]Candidate, error) {
+	var questions []question.Question
+	var indexes []int
+	for i, candidate := range candidates {
+		if len(candidate.Issues) > 0 {
+			continue
+		}
+		questions = append(questions, candidate.Question)
+		indexes = append(indexes, i)
+	}
+	if len(questions) == 0 {
+		return candidates, nil
+	}
+	specPath, cleanup, err := writeTempSpec(questions)
+	if err != nil {
+		return candidates, err
+	}
+	defer cleanup()
+
+	verifyCfg := cfg
+	verifyCfg.Repo.SetupCommands = nil
+	verifyCfg.Tasks = []spec.TaskConfig{{
+		ID:            verifyTaskID,
+		Type:          "question_eval",
+		Agent:         params.AgentID,
+		QuestionsFile: specPath,
+	}}
+	results, err := runner.Run(ctx, verifyCfg, runner.RunParams{RepoRoot: params.RepoRoot, Deps: params.Deps})
+	if err != nil {
+		return candidates, fmt.Errorf("verify questions: %w", err)
+	}
+	if len(results.Tasks) != 1 || results.Tasks[0].QuestionEval == nil {
+		return candidates, fmt.Errorf("verify questions: %s", verifyFailure(results))
+	}
+	verified := append([]Candidate(nil), candidates...)
+	for i, result := range results.Tasks[0].QuestionEval.Questions {
+		if i >= len(indexes) {
+			break
+		}
+		verification := &Verification{AgentID: params.AgentID, Answer: result.ResolvedAnswer, Correct: result.Correct}
+		if verification.Answer == "" {
+			verification.Answer = result.AgentAnswer
+		}
+		switch {
+		case result.RunError != "":
+			verification.Error = result.RunError
+		case result.ParseError != "":
+			verification.Error = result.ParseError
+		}
+		verified[indexes[i]].Verification = verification
+	}
+	return verified, nil
+}
+
+// writeTempSpec writes questions to a temporary spec file for verification.
+func writeTempSpec(questions []question.Question) (string, func(), error) {
+	dir, err := os.MkdirTemp("", "cogni-questions-*")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { _ = os.RemoveAll(dir) }
+	path := filepath.Join(dir, "candidates.yml")
+	if err := WriteSpec(path, questions); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return path, cleanup, nil
+}
+
+// verifyFailure describes why a verification run produced no question results.
+func verifyFailure(results runner.Results) string {
+	if len(results.Tasks) > 0 && results.Tasks[0].FailureReason != nil {
+		return *results.Tasks[0].FailureReason
+	}
+	return "no question results"
+}
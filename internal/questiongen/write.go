@@ -0,0 +1,26 @@
+package questiongen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"cogni/internal/question"
+)
+
+// draftHeader marks generated specs as drafts that need human review.
+const draftHeader = "# Draft generated by `cogni questions generate`. Review each question before use.\n"
+
+// WriteSpec writes questions to path as a version 1 YAML question spec.
+func WriteSpec(path string, questions []question.Question) error {
+	data, err := yaml.Marshal(question.Spec{Version: 1, Questions: questions})
+	if err != nil {
+		return fmt.Errorf("encode questions: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append([]byte(draftHeader), data...), 0o644)
+}
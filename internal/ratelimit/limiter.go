@@ -1,6 +1,8 @@
 package ratelimit
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -22,7 +24,7 @@ func BuildLimiter(cfg spec.Config, repoRoot string) (ratelimiter.Limiter, error)
 	case "", "disabled":
 		return ratelimiter.NoopLimiter, nil
 	case "remote":
-		return buildRemoteLimiter(cfg)
+		return buildRemoteLimiter(cfg, repoRoot)
 	case "embedded":
 		return buildEmbeddedLimiter(cfg, repoRoot)
 	default:
@@ -53,15 +55,57 @@ func MaxOutputTokens(cfg spec.Config, task spec.TaskConfig) uint64 {
 }
 
 // buildRemoteLimiter constructs an HTTP limiter client and wraps batching when enabled.
-func buildRemoteLimiter(cfg spec.Config) (ratelimiter.Limiter, error) {
+func buildRemoteLimiter(cfg spec.Config, repoRoot string) (ratelimiter.Limiter, error) {
 	if strings.TrimSpace(cfg.RateLimiter.BaseURL) == "" {
 		return nil, fmt.Errorf("rate limiter base_url is required for remote mode")
 	}
-	timeout := time.Duration(cfg.RateLimiter.RequestTimeoutMs) * time.Millisecond
-	limiter := ratelimiter.Limiter(httpclient.NewWithTimeout(cfg.RateLimiter.BaseURL, timeout))
+	opts := httpclient.Options{
+		Timeout:      time.Duration(cfg.RateLimiter.RequestTimeoutMs) * time.Millisecond,
+		SendFeedback: cfg.RateLimiter.Adaptive.Enabled,
+	}
+	if env := strings.TrimSpace(cfg.RateLimiter.TokenEnv); env != "" {
+		opts.Token = strings.TrimSpace(os.Getenv(env))
+		if opts.Token == "" {
+			return nil, fmt.Errorf("rate limiter token_env %s is not set", env)
+		}
+	}
+	tlsConfig, err := buildClientTLS(cfg.RateLimiter.TLS, repoRoot)
+	if err != nil {
+		return nil, err
+	}
+	opts.TLS = tlsConfig
+	limiter := ratelimiter.Limiter(httpclient.NewWithOptions(cfg.RateLimiter.BaseURL, opts))
 	return wrapBatcher(cfg, limiter), nil
 }
 
+// buildClientTLS loads a custom CA and client certificate, or returns nil to
+// use the system defaults.
+func buildClientTLS(cfg spec.RateLimiterTLSConfig, repoRoot string) (*tls.Config, error) {
+	if cfg == (spec.RateLimiterTLSConfig{}) {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(resolveRepoPath(repoRoot, cfg.CAFile))
+		if err != nil {
+			return nil, fmt.Errorf("read rate limiter CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("rate limiter CA %s contains no certificates", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if cfg.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(resolveRepoPath(repoRoot, cfg.CertFile), resolveRepoPath(repoRoot, cfg.KeyFile))
+		if err != nil {
+			return nil, fmt.Errorf("load rate limiter client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
 // buildEmbeddedLimiter constructs an in-memory limiter client and wraps batching when enabled.
 func buildEmbeddedLimiter(cfg spec.Config, repoRoot string) (ratelimiter.Limiter, error) {
 	limitsPath := strings.TrimSpace(cfg.RateLimiter.LimitsPath)
@@ -72,33 +116,77 @@ func buildEmbeddedLimiter(cfg spec.Config, repoRoot string) (ratelimiter.Limiter
 	case limitsPath != "" && limitsProvided:
 		return nil, fmt.Errorf("rate limiter limits cannot be set with limits_path for embedded mode")
 	}
-	var limiter ratelimiter.Limiter
+	var embeddedLimiter *local.Client
 	if limitsProvided {
-		embeddedLimiter, err := local.NewMemoryLimiterFromStates(cfg.RateLimiter.Limits)
+		client, err := local.NewMemoryLimiterFromStates(cfg.RateLimiter.Limits)
 		if err != nil {
 			return nil, err
 		}
-		limiter = embeddedLimiter
+		embeddedLimiter = client
 	} else {
-		limitsPath = resolveLimitsPath(repoRoot, limitsPath)
+		limitsPath = resolveRepoPath(repoRoot, limitsPath)
 		if _, err := os.Stat(limitsPath); err != nil {
 			return nil, fmt.Errorf("read limits file: %w", err)
 		}
-		embeddedLimiter, err := local.NewMemoryLimiterFromFile(limitsPath)
+		client, err := local.NewMemoryLimiterFromFile(limitsPath)
 		if err != nil {
 			return nil, err
 		}
-		limiter = embeddedLimiter
+		embeddedLimiter = client
 	}
+	if cfg.RateLimiter.Adaptive.Enabled {
+		if err := embeddedLimiter.EnableAdaptive(AdaptiveSettings(cfg.RateLimiter.Adaptive)); err != nil {
+			return nil, fmt.Errorf("rate limiter adaptive: %w", err)
+		}
+	}
+	limiter := ratelimiter.Limiter(embeddedLimiter)
 	return wrapBatcher(cfg, limiter), nil
 }
 
-// resolveLimitsPath resolves a limits file path against the repository root.
-func resolveLimitsPath(repoRoot, limitsPath string) string {
-	if filepath.IsAbs(limitsPath) || strings.TrimSpace(repoRoot) == "" {
-		return limitsPath
+// RetryPolicy returns the scheduler retry policy for question attempts.
+// Unset fields use ratelimiter.DefaultRetryPolicy.
+func RetryPolicy(cfg spec.Config) ratelimiter.RetryPolicy {
+	retry := cfg.RateLimiter.Retry
+	policy := ratelimiter.DefaultRetryPolicy()
+	if retry.MaxAttempts > 0 {
+		policy.MaxAttempts = retry.MaxAttempts
+	}
+	if retry.BaseDelayMs > 0 {
+		policy.BaseDelay = time.Duration(retry.BaseDelayMs) * time.Millisecond
+	}
+	if retry.MaxDelayMs > 0 {
+		policy.MaxDelay = time.Duration(retry.MaxDelayMs) * time.Millisecond
+	}
+	return policy
+}
+
+// JobPriority returns the configured job priority, normal when unset or
+// invalid; validation reports invalid values.
+func JobPriority(cfg spec.Config) ratelimiter.Priority {
+	priority, err := ratelimiter.ParsePriority(cfg.RateLimiter.Priority)
+	if err != nil {
+		return ratelimiter.PriorityNormal
+	}
+	return priority
+}
+
+// AdaptiveSettings converts adaptive limit settings to controller tuning.
+func AdaptiveSettings(cfg spec.AdaptiveConfig) ratelimiter.AdaptiveSettings {
+	return ratelimiter.AdaptiveSettings{
+		BackoffFactor:    cfg.BackoffFactor,
+		MinFraction:      cfg.MinFraction,
+		RecoveryStep:     cfg.RecoveryStep,
+		RecoveryInterval: time.Duration(cfg.RecoveryIntervalMs) * time.Millisecond,
+		ProviderLimitTTL: time.Duration(cfg.ProviderLimitTTLMs) * time.Millisecond,
+	}
+}
+
+// resolveRepoPath resolves a configured file path against the repository root.
+func resolveRepoPath(repoRoot, path string) string {
+	if filepath.IsAbs(path) || strings.TrimSpace(repoRoot) == "" {
+		return path
 	}
-	return filepath.Join(repoRoot, limitsPath)
+	return filepath.Join(repoRoot, path)
 }
 
 // wrapBatcher wraps the limiter with a batcher when configured.
@@ -2,6 +2,8 @@ package ratelimit
 
 import (
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -195,3 +197,40 @@ func sampleLimitStates() []ratelimiter.LimitState {
 		},
 	}
 }
+
+// TestBuildLimiterRemoteSendsBearerToken ensures token_env is sent as a bearer token.
+func TestBuildLimiterRemoteSendsBearerToken(t *testing.T) {
+	runWithTimeout(t, func() {
+		t.Setenv("COGNI_TEST_LIMITER_TOKEN", "secret")
+		authHeader := make(chan string, 1)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authHeader <- r.Header.Get("Authorization")
+			_, _ = w.Write([]byte(`{"allowed":true}`))
+		}))
+		defer srv.Close()
+		cfg := spec.Config{
+			RateLimiter: spec.RateLimiterConfig{
+				Mode:             "remote",
+				BaseURL:          srv.URL,
+				RequestTimeoutMs: 1000,
+				TokenEnv:         "COGNI_TEST_LIMITER_TOKEN",
+				Batch:            spec.BatchConfig{Size: 1, FlushMs: 1},
+			},
+		}
+		limiter, err := BuildLimiter(cfg, t.TempDir())
+		if err != nil {
+			t.Fatalf("build limiter: %v", err)
+		}
+		if _, err := limiter.Reserve(testutil.Context(t, time.Second), ratelimiter.ReserveRequest{LeaseID: "L1"}); err != nil {
+			t.Fatalf("reserve: %v", err)
+		}
+		if got := <-authHeader; got != "Bearer secret" {
+			t.Fatalf("expected bearer token header, got %q", got)
+		}
+
+		cfg.RateLimiter.TokenEnv = "COGNI_TEST_LIMITER_TOKEN_UNSET"
+		if _, err := BuildLimiter(cfg, t.TempDir()); err == nil {
+			t.Fatalf("expected error for an unset token env")
+		}
+	})
+}
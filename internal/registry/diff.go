@@ -0,0 +1,56 @@
+package registry
+
+import (
+	"sort"
+
+	"cogni/pkg/ratelimiter"
+)
+
+// Delete removes a limit state.
+func (r *Registry) Delete(key ratelimiter.LimitKey) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.states, key)
+}
+
+// Diff compares a full limit set against the registry. Keys missing from defs
+// are deletes; a limit already draining counts as an update when it is kept.
+// Changes are ordered by key and unchanged limits are counted.
+func (r *Registry) Diff(defs []ratelimiter.LimitDefinition) ([]ratelimiter.LimitChange, int) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	changes := []ratelimiter.LimitChange{}
+	unchanged := 0
+	seen := make(map[ratelimiter.LimitKey]bool, len(defs))
+	for _, def := range defs {
+		def := def
+		seen[def.Key] = true
+		prev, ok := r.states[def.Key]
+		if !ok {
+			changes = append(changes, ratelimiter.LimitChange{Key: def.Key, Action: ratelimiter.LimitChangeCreate, After: &def})
+			continue
+		}
+		before := prev.Definition
+		if prev.Status == ratelimiter.LimitStatusDecreasing {
+			before.Capacity = prev.PendingDecreaseTo
+		}
+		switch {
+		case def.Capacity < before.Capacity:
+			changes = append(changes, ratelimiter.LimitChange{Key: def.Key, Action: ratelimiter.LimitChangeDecrease, Before: &before, After: &def})
+		case def != before || prev.Status == ratelimiter.LimitStatusDraining:
+			changes = append(changes, ratelimiter.LimitChange{Key: def.Key, Action: ratelimiter.LimitChangeUpdate, Before: &before, After: &def})
+		default:
+			unchanged++
+		}
+	}
+	for key, state := range r.states {
+		if seen[key] || state.Status == ratelimiter.LimitStatusDraining {
+			continue
+		}
+		before := state.Definition
+		changes = append(changes, ratelimiter.LimitChange{Key: key, Action: ratelimiter.LimitChangeDelete, Before: &before})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes, unchanged
+}
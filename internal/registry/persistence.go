@@ -1,6 +1,7 @@
 package registry
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -21,8 +22,8 @@ func (r *Registry) Load(path string) error {
 		}
 		return err
 	}
-	var states []ratelimiter.LimitState
-	if err := json.Unmarshal(data, &states); err != nil {
+	states, err := DecodeStates(data)
+	if err != nil {
 		return err
 	}
 	r.mu.Lock()
@@ -71,5 +72,44 @@ func (r *Registry) Save(path string) error {
 		_ = os.Remove(tmpPath)
 		return err
 	}
+	r.mu.Lock()
+	r.saved = sha256.Sum256(payload)
+	r.mu.Unlock()
 	return nil
 }
+
+// LastSaved reports whether data is what the last Save wrote, so a file
+// watcher can tell the server's own writes from external edits.
+func (r *Registry) LastSaved(data []byte) bool {
+	sum := sha256.Sum256(data)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return sum == r.saved
+}
+
+// DecodeStates parses the JSON registry file format.
+func DecodeStates(data []byte) ([]ratelimiter.LimitState, error) {
+	var states []ratelimiter.LimitState
+	if err := json.Unmarshal(data, &states); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+// TargetDefinitions returns the definitions a registry file converges to: a
+// decreasing limit targets its pending capacity and draining limits are
+// omitted, so re-applying a saved registry file changes nothing.
+func TargetDefinitions(states []ratelimiter.LimitState) []ratelimiter.LimitDefinition {
+	defs := make([]ratelimiter.LimitDefinition, 0, len(states))
+	for _, state := range states {
+		def := state.Definition
+		switch state.Status {
+		case ratelimiter.LimitStatusDraining:
+			continue
+		case ratelimiter.LimitStatusDecreasing:
+			def.Capacity = state.PendingDecreaseTo
+		}
+		defs = append(defs, def)
+	}
+	return defs
+}
@@ -1,6 +1,7 @@
 package registry
 
 import (
+	"crypto/sha256"
 	"sort"
 	"sync"
 
@@ -11,6 +12,10 @@ import (
 type Registry struct {
 	mu     sync.RWMutex
 	states map[ratelimiter.LimitKey]ratelimiter.LimitState
+	// saved is the digest of the last file Save wrote.
+	saved [sha256.Size]byte
+	// edit serializes multi-step changes; see LockEdits.
+	edit sync.Mutex
 }
 
 // New creates an empty registry.
@@ -33,6 +38,15 @@ func (r *Registry) Put(state ratelimiter.LimitState) {
 	r.states[state.Definition.Key] = state
 }
 
+// LockEdits serializes a change that spans the backend, the registry and its
+// file, such as an admin write or a reload of the file, and returns the
+// unlock function. Without it a reload could read the file between an admin
+// Put and its Save and revert the change.
+func (r *Registry) LockEdits() (unlock func()) {
+	r.edit.Lock()
+	return r.edit.Unlock
+}
+
 // List returns all limit states sorted by key.
 func (r *Registry) List() []ratelimiter.LimitState {
 	r.mu.RLock()
@@ -133,3 +133,72 @@ func runWithTimeout(t *testing.T, timeout time.Duration, fn func()) {
 	case <-done:
 	}
 }
+
+func TestRegistry_DiffComparesPendingDecreaseTarget(t *testing.T) {
+	runWithTimeout(t, time.Second, func() {
+		reg := New()
+		reg.Put(sampleState("limit:a", 10, ratelimiter.LimitStatusDecreasing, 5))
+		reg.Put(sampleState("limit:b", 10, ratelimiter.LimitStatusDraining, 0))
+		reg.Put(sampleState("limit:c", 10, ratelimiter.LimitStatusDraining, 0))
+
+		pending := sampleState("limit:a", 5, ratelimiter.LimitStatusActive, 0).Definition
+		kept := sampleState("limit:b", 10, ratelimiter.LimitStatusActive, 0).Definition
+		changes, unchanged := reg.Diff([]ratelimiter.LimitDefinition{pending, kept})
+		if unchanged != 1 {
+			t.Fatalf("expected pending decrease target to count as unchanged, got %d", unchanged)
+		}
+		if len(changes) != 1 || changes[0].Key != "limit:b" || changes[0].Action != ratelimiter.LimitChangeUpdate {
+			t.Fatalf("expected only the kept draining limit to change, got %+v", changes)
+		}
+	})
+}
+
+func TestRegistry_SavedFileTargetsAreUnchanged(t *testing.T) {
+	runWithTimeout(t, time.Second, func() {
+		reg := New()
+		reg.Put(sampleState("limit:a", 10, ratelimiter.LimitStatusActive, 0))
+		reg.Put(sampleState("limit:b", 10, ratelimiter.LimitStatusDecreasing, 4))
+		reg.Put(sampleState("limit:c", 10, ratelimiter.LimitStatusDraining, 0))
+
+		path := filepath.Join(t.TempDir(), "limits.json")
+		if err := reg.Save(path); err != nil {
+			t.Fatalf("save registry: %v", err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read registry: %v", err)
+		}
+		states, err := DecodeStates(data)
+		if err != nil {
+			t.Fatalf("decode registry: %v", err)
+		}
+		defs := TargetDefinitions(states)
+		if len(defs) != 2 || defs[1].Capacity != 4 {
+			t.Fatalf("unexpected targets: %+v", defs)
+		}
+		if changes, unchanged := reg.Diff(defs); len(changes) != 0 || unchanged != 2 {
+			t.Fatalf("expected a saved registry to re-apply as a no-op, got %+v (%d unchanged)", changes, unchanged)
+		}
+	})
+}
+
+func TestRegistry_LastSavedMatchesOwnWrite(t *testing.T) {
+	runWithTimeout(t, time.Second, func() {
+		reg := New()
+		reg.Put(sampleState("limit:a", 10, ratelimiter.LimitStatusActive, 0))
+		path := filepath.Join(t.TempDir(), "limits.json")
+		if err := reg.Save(path); err != nil {
+			t.Fatalf("save registry: %v", err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read registry: %v", err)
+		}
+		if !reg.LastSaved(data) {
+			t.Fatalf("expected the saved file to match the last save")
+		}
+		if reg.LastSaved(append(data, '\n')) {
+			t.Fatalf("expected an edited file not to match the last save")
+		}
+	})
+}
@@ -3,6 +3,7 @@ package report
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -47,7 +48,7 @@ func ResolveRun(outputDir, repoRoot, ref string) (runner.Results, string, error)
 		results, err := LoadResults(filepath.Join(runDir, "results.json"))
 		return results, runDir, err
 	}
-	runDir, err := findRunByID(outputDir, ref)
+	runDir, err := FindRunDir(outputDir, ref)
 	if err != nil {
 		return runner.Results{}, "", err
 	}
@@ -57,25 +58,55 @@ func ResolveRun(outputDir, repoRoot, ref string) (runner.Results, string, error)
 
 // findLatestRunDir returns the most recent run directory for a commit.
 func findLatestRunDir(commitDir string) (string, error) {
-	entries, err := os.ReadDir(commitDir)
+	runDirs, err := listRunDirs(commitDir)
 	if err != nil {
 		return "", err
 	}
+	if len(runDirs) == 0 {
+		return "", fmt.Errorf("no runs found in %s", commitDir)
+	}
+	return runDirs[0], nil
+}
+
+// CommitRuns returns the run directories recorded for a commit, newest first.
+// A commit without an output directory has no runs and is not an error.
+func CommitRuns(outputDir, commit string) ([]string, error) {
+	runDirs, err := listRunDirs(filepath.Join(outputDir, commit))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return runDirs, err
+}
+
+// listRunDirs lists finished run directories under a commit directory, newest
+// first. Run IDs begin with a UTC timestamp, so reverse lexical order is newest
+// first. Directories without results.json, such as a killed run that left only
+// its checkpoint, are skipped.
+func listRunDirs(commitDir string) ([]string, error) {
+	entries, err := os.ReadDir(commitDir)
+	if err != nil {
+		return nil, err
+	}
 	runIDs := make([]string, 0)
 	for _, entry := range entries {
-		if entry.IsDir() {
-			runIDs = append(runIDs, entry.Name())
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(commitDir, entry.Name(), "results.json")); err != nil {
+			continue
 		}
+		runIDs = append(runIDs, entry.Name())
 	}
-	if len(runIDs) == 0 {
-		return "", fmt.Errorf("no runs found in %s", commitDir)
+	sort.Sort(sort.Reverse(sort.StringSlice(runIDs)))
+	runDirs := make([]string, 0, len(runIDs))
+	for _, runID := range runIDs {
+		runDirs = append(runDirs, filepath.Join(commitDir, runID))
 	}
-	sort.Strings(runIDs)
-	return filepath.Join(commitDir, runIDs[len(runIDs)-1]), nil
+	return runDirs, nil
 }
 
-// findRunByID searches for a run directory by id across commits.
-func findRunByID(outputDir, runID string) (string, error) {
+// FindRunDir searches for a run directory by id across commits.
+func FindRunDir(outputDir, runID string) (string, error) {
 	entries, err := os.ReadDir(outputDir)
 	if err != nil {
 		return "", err
@@ -1,6 +1,8 @@
 package report
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -42,6 +44,34 @@ func TestResolveRunByCommitAndRunID(t *testing.T) {
 	}
 }
 
+// TestResolveRunSkipsCheckpointOnlyRuns verifies an interrupted run without results is ignored.
+func TestResolveRunSkipsCheckpointOnlyRuns(t *testing.T) {
+	root := t.TempDir()
+	finished := runner.Results{RunID: "20240101T000000Z-aaaa", Repo: runner.RepoMetadata{Commit: "abc"}}
+	if _, err := runner.WriteRunOutputs(finished, root); err != nil {
+		t.Fatalf("write outputs: %v", err)
+	}
+	killed := filepath.Join(root, "abc", "20240102T000000Z-bbbb")
+	if err := os.MkdirAll(killed, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(killed, "checkpoint.jsonl"), []byte("{\"kind\":\"run\"}\n"), 0o644); err != nil {
+		t.Fatalf("write checkpoint: %v", err)
+	}
+
+	resolved, _, err := ResolveRun(root, "", "abc")
+	if err != nil {
+		t.Fatalf("resolve commit: %v", err)
+	}
+	if resolved.RunID != finished.RunID {
+		t.Fatalf("expected finished run, got %s", resolved.RunID)
+	}
+	runs, err := CommitRuns(root, "abc")
+	if err != nil || len(runs) != 1 {
+		t.Fatalf("expected only the finished run, got %v (%v)", runs, err)
+	}
+}
+
 // TestBuildReportHTML verifies report HTML includes run metadata.
 func TestBuildReportHTML(t *testing.T) {
 	runs := []runner.Results{
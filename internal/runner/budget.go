@@ -0,0 +1,124 @@
+package runner
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"cogni/internal/spec"
+)
+
+// budgetError reports an exhausted aggregate budget. Its reason becomes the
+// skip reason of work that never started.
+type budgetError struct {
+	reason string
+}
+
+// Error returns the skip reason.
+func (e budgetError) Error() string {
+	return e.reason + " exhausted"
+}
+
+// skipReason maps why a job never ran to its recorded skip reason.
+func skipReason(err error) string {
+	var exhausted budgetError
+	if errors.As(err, &exhausted) {
+		return exhausted.reason
+	}
+	return "cancelled"
+}
+
+// spendTracker accumulates tokens and cost against one aggregate budget. It is
+// shared by concurrent jobs, so it guards its totals with a mutex.
+type spendTracker struct {
+	scope  string
+	budget spec.AggregateBudget
+	start  time.Time
+	now    func() time.Time
+
+	mu     sync.Mutex
+	tokens int
+	cost   float64
+}
+
+// newSpendTracker starts the clock for a budget, or returns nil when the
+// budget sets no limits.
+func newSpendTracker(scope string, budget spec.AggregateBudget, now func() time.Time) *spendTracker {
+	if budget.MaxTokens <= 0 && budget.MaxCostUSD <= 0 && budget.MaxSeconds <= 0 {
+		return nil
+	}
+	return &spendTracker{scope: scope, budget: budget, start: now(), now: now}
+}
+
+// exhausted returns the skip reason once any limit is reached, or "".
+func (t *spendTracker) exhausted() string {
+	if t == nil {
+		return ""
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	switch {
+	case t.budget.MaxTokens > 0 && t.tokens >= t.budget.MaxTokens:
+		return t.scope + "_token_budget"
+	case t.budget.MaxCostUSD > 0 && t.cost >= t.budget.MaxCostUSD:
+		return t.scope + "_cost_budget"
+	case t.budget.MaxSeconds > 0 && t.now().Sub(t.start) >= time.Duration(t.budget.MaxSeconds)*time.Second:
+		return t.scope + "_time_budget"
+	}
+	return ""
+}
+
+// charge adds spent tokens, priced at costPerMillion, to the totals.
+func (t *spendTracker) charge(tokens int, costPerMillion float64) {
+	if t == nil || tokens <= 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tokens += tokens
+	t.cost += float64(tokens) * costPerMillion / 1_000_000
+}
+
+// taskBudget enforces the run budget and one task's total budget for a task
+// run. Work already in flight when a limit is reached runs to completion, so
+// totals can overshoot by up to one call per concurrent worker.
+type taskBudget struct {
+	trackers       []*spendTracker
+	costPerMillion float64
+}
+
+// newTaskBudget combines the shared run tracker with a fresh task tracker.
+func newTaskBudget(run *spendTracker, task taskRun, now func() time.Time) taskBudget {
+	budget := taskBudget{costPerMillion: task.Agent.CostPerMillionTokens}
+	for _, tracker := range []*spendTracker{run, newSpendTracker("task", task.Task.TotalBudget, now)} {
+		if tracker != nil {
+			budget.trackers = append(budget.trackers, tracker)
+		}
+	}
+	return budget
+}
+
+// exhausted returns the skip reason of the first exhausted budget, or "".
+func (b taskBudget) exhausted() string {
+	for _, tracker := range b.trackers {
+		if reason := tracker.exhausted(); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+// admit refuses new work once any budget is exhausted.
+func (b taskBudget) admit() error {
+	if reason := b.exhausted(); reason != "" {
+		return budgetError{reason: reason}
+	}
+	return nil
+}
+
+// charge records spent tokens against every budget.
+func (b taskBudget) charge(tokens int) {
+	for _, tracker := range b.trackers {
+		tracker.charge(tokens, b.costPerMillion)
+	}
+}
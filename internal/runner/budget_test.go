@@ -0,0 +1,81 @@
+package runner
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"cogni/internal/spec"
+)
+
+// TestRunBudgetSkipsRemainingWork verifies that once the run token budget is
+// spent, queued questions and later tasks are skipped with the budget reason.
+func TestRunBudgetSkipsRemainingWork(t *testing.T) {
+	repoRoot, cfg := writeFlakyRepo(t)
+	second := cfg.Tasks[0]
+	second.ID = "task-2"
+	cfg.Tasks = append(cfg.Tasks, second)
+	cfg.Budget = spec.AggregateBudget{MaxTokens: 200}
+
+	var mu sync.Mutex
+	var calls []string
+	results, _ := runFlaky(t, repoRoot, cfg, flakyProvider{mu: &mu, calls: &calls}, "run-1", RunParams{})
+	if len(calls) != 2 {
+		t.Fatalf("expected two questions before the budget ran out, got %d", len(calls))
+	}
+	first := results.Tasks[0]
+	if first.Status != "fail" || first.FailureReason == nil || *first.FailureReason != "run_token_budget" {
+		t.Fatalf("expected task-1 to fail on the run budget, got %s (%v)", first.Status, first.FailureReason)
+	}
+	if skipped := first.QuestionEval.Questions[2].Skipped; skipped != "run_token_budget" {
+		t.Fatalf("expected q3 skipped for the run budget, got %q", skipped)
+	}
+	if QuestionStatus(first.QuestionEval.Questions[2]) != QuestionStatusSkipped {
+		t.Fatalf("expected q3 to be eligible for --rerun-failed")
+	}
+	summary := first.QuestionEval.Summary
+	if summary.QuestionsSkipped != 1 || summary.QuestionsIncorrect != 0 || summary.Accuracy != 1 {
+		t.Fatalf("expected the skipped question left out of accuracy, got %+v", summary)
+	}
+	if results.Summary.QuestionsSkipped != 1 || results.Summary.QuestionAccuracy != 1 {
+		t.Fatalf("expected run accuracy over scored questions only, got %+v", results.Summary)
+	}
+	last := results.Tasks[1]
+	if last.Status != "skipped" || last.FailureReason == nil || *last.FailureReason != "run_token_budget" {
+		t.Fatalf("expected task-2 skipped, got %s (%v)", last.Status, last.FailureReason)
+	}
+}
+
+// TestTaskBudgetCost verifies the task cost ceiling uses the agent price and
+// applies alongside the shared run budget.
+func TestTaskBudgetCost(t *testing.T) {
+	run := newSpendTracker("run", spec.AggregateBudget{MaxTokens: 1000}, time.Now)
+	task := taskRun{
+		Task:  spec.TaskConfig{TotalBudget: spec.AggregateBudget{MaxCostUSD: 1}},
+		Agent: spec.AgentConfig{CostPerMillionTokens: 5000},
+	}
+	budget := newTaskBudget(run, task, time.Now)
+	if err := budget.admit(); err != nil {
+		t.Fatalf("expected fresh budget to admit, got %v", err)
+	}
+	budget.charge(150)
+	if err := budget.admit(); err != nil {
+		t.Fatalf("expected $0.75 to stay under $1, got %v", err)
+	}
+	budget.charge(50)
+	if reason := budget.exhausted(); reason != "task_cost_budget" {
+		t.Fatalf("expected task cost budget exhausted, got %q", reason)
+	}
+
+	other := newTaskBudget(run, taskRun{}, time.Now)
+	if reason := other.exhausted(); reason != "" {
+		t.Fatalf("expected run budget to have headroom, got %q", reason)
+	}
+	other.charge(800)
+	if reason := other.exhausted(); reason != "run_token_budget" {
+		t.Fatalf("expected run token budget exhausted, got %q", reason)
+	}
+	if newSpendTracker("run", spec.AggregateBudget{}, time.Now) != nil {
+		t.Fatalf("expected no tracker for an empty budget")
+	}
+}
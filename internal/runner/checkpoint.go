@@ -0,0 +1,256 @@
+package runner
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"cogni/internal/question"
+)
+
+// Checkpoint record kinds.
+const (
+	checkpointKindRun      = "run"
+	checkpointKindQuestion = "question"
+)
+
+// checkpointRecord is one line of checkpoint.jsonl.
+type checkpointRecord struct {
+	Kind          string            `json:"kind"`
+	RunID         string            `json:"run_id,omitempty"`
+	Commit        string            `json:"commit,omitempty"`
+	StartedAt     *time.Time        `json:"started_at,omitempty"`
+	Selectors     []TaskSelector    `json:"selectors,omitempty"`
+	Agents        []string          `json:"agents,omitempty"`
+	AgentOverride string            `json:"agent_override,omitempty"`
+	Sampling      *QuestionSampling `json:"sampling,omitempty"`
+	RerunOf       string            `json:"rerun_of,omitempty"`
+	AnswerSeed    int64             `json:"answer_seed,omitempty"`
+	TaskID        string            `json:"task_id,omitempty"`
+	AgentID       string            `json:"agent_id,omitempty"`
+	Index         int               `json:"index,omitempty"`
+	Question      *QuestionResult   `json:"question,omitempty"`
+}
+
+// Checkpoint holds the completed work of an interrupted run. Only question
+// results are kept: a question task whose questions are all recorded resumes
+// without model calls, and code_change tasks are cheap to redo relative to
+// their artifacts, which are not part of results.json.
+type Checkpoint struct {
+	RunID         string
+	Commit        string
+	StartedAt     time.Time
+	Selectors     []TaskSelector
+	AgentOverride string
+	Agents        []string
+	Sampling      QuestionSampling
+	RerunOf       string
+	// AnswerSeed is the run's default answer shuffle seed, reused on resume
+	// so unfinished questions keep the original answer order.
+	AnswerSeed int64
+	// carryFrom is set when the checkpoint seeds a rerun of another run rather
+	// than a resume; carried results are stamped with it.
+	carryFrom string
+	questions map[string]map[int]QuestionResult
+}
+
+// LoadCheckpoint reads a checkpoint log. Later records win, so a question that
+// was rerun after a resume replaces its earlier attempt. A truncated final line
+// from a killed process is ignored.
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open checkpoint: %w", err)
+	}
+	defer file.Close()
+	checkpoint := &Checkpoint{
+		questions: map[string]map[int]QuestionResult{},
+	}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		var record checkpointRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		switch record.Kind {
+		case checkpointKindRun:
+			checkpoint.RunID = record.RunID
+			checkpoint.Commit = record.Commit
+			if record.StartedAt != nil {
+				checkpoint.StartedAt = *record.StartedAt
+			}
+			checkpoint.Selectors = record.Selectors
+			checkpoint.AgentOverride = record.AgentOverride
+			checkpoint.Agents = record.Agents
+			if record.Sampling != nil {
+				checkpoint.Sampling = *record.Sampling
+			}
+			checkpoint.RerunOf = record.RerunOf
+			checkpoint.AnswerSeed = record.AnswerSeed
+		case checkpointKindQuestion:
+			if record.Question == nil {
+				continue
+			}
+			key := checkpointKey(record.TaskID, record.AgentID)
+			if checkpoint.questions[key] == nil {
+				checkpoint.questions[key] = map[int]QuestionResult{}
+			}
+			checkpoint.questions[key][record.Index] = *record.Question
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read checkpoint: %w", err)
+	}
+	if checkpoint.RunID == "" {
+		return nil, fmt.Errorf("checkpoint %s has no run header", path)
+	}
+	return checkpoint, nil
+}
+
+// completedQuestion returns a finished result for the question at index.
+// Errored questions, and questions whose text changed since the checkpoint, are rerun.
+func (c *Checkpoint) completedQuestion(task taskRun, index int, item question.Question) (QuestionResult, bool) {
+	if c == nil {
+		return QuestionResult{}, false
+	}
+	result, ok := c.questions[checkpointKey(task.Task.ID, task.AgentID)][index]
+	if !ok && c.carryFrom != "" {
+		// Results written before task_result.agent_id existed have no agent.
+		result, ok = c.questions[checkpointKey(task.Task.ID, "")][index]
+	}
+	if !ok || (c.carryFrom == "" && result.RunError != "") || result.ID != item.ID || result.Question != item.Prompt {
+		return QuestionResult{}, false
+	}
+	return result, true
+}
+
+// checkpointKey identifies a task run within a checkpoint.
+func checkpointKey(taskID, agentID string) string {
+	return taskID + "@" + agentID
+}
+
+// checkpointWriter appends records to checkpoint.jsonl as work completes and
+// serves results from the checkpoint being resumed, if any. A nil writer
+// discards records and resumes nothing.
+type checkpointWriter struct {
+	mu     sync.Mutex
+	file   *os.File
+	resume *Checkpoint
+}
+
+// openCheckpointWriter opens the checkpoint log for appending, creating its directory.
+func openCheckpointWriter(path string, resume *Checkpoint) (*checkpointWriter, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create run dir: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open checkpoint: %w", err)
+	}
+	return &checkpointWriter{file: file, resume: resume}, nil
+}
+
+// header records the run identity needed to resume.
+func (w *checkpointWriter) header(runID, commit string, startedAt time.Time, params RunParams, rerunOf string, answerSeed int64) {
+	record := checkpointRecord{Kind: checkpointKindRun, RunID: runID, Commit: commit, StartedAt: &startedAt, Selectors: params.Selectors, AgentOverride: params.AgentOverride, Agents: params.Agents, RerunOf: rerunOf, AnswerSeed: answerSeed}
+	if params.Sampling.active() {
+		record.Sampling = &params.Sampling
+	}
+	w.write(record)
+}
+
+// question records a finished question.
+func (w *checkpointWriter) question(task taskRun, index int, result QuestionResult) {
+	w.write(checkpointRecord{Kind: checkpointKindQuestion, TaskID: task.Task.ID, AgentID: task.AgentID, Index: index, Question: &result})
+}
+
+// write appends one JSON line; checkpointing is best effort and never fails a run.
+func (w *checkpointWriter) write(record checkpointRecord) {
+	if w == nil {
+		return
+	}
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, _ = w.file.Write(append(payload, '\n'))
+}
+
+// close closes the checkpoint log.
+func (w *checkpointWriter) close() {
+	if w == nil {
+		return
+	}
+	_ = w.file.Close()
+}
+
+// resumedQuestionJob returns the checkpointed outcome for a question, reporting
+// it to the observer as if the question had just finished.
+func resumedQuestionJob(deps questionJobDeps, index int, item question.Question) (questionJobResult, bool) {
+	if deps.checkpoint == nil {
+		return questionJobResult{}, false
+	}
+	result, ok := deps.checkpoint.resume.completedQuestion(deps.task, index, item)
+	if !ok {
+		return questionJobResult{}, false
+	}
+	if carryFrom := deps.checkpoint.resume.carryFrom; carryFrom != "" {
+		if result.CarriedFrom == "" {
+			result.CarriedFrom = carryFrom
+		}
+		deps.checkpoint.question(deps.task, index, result)
+	}
+	if deps.observer != nil {
+		options := questionEventOptions{
+			EventType: QuestionIncorrect,
+			Tokens:    result.TokensTotal,
+			WallTime:  time.Duration(result.WallTimeSeconds * float64(time.Second)),
+		}
+		switch {
+		case result.Correct:
+			options.EventType = QuestionCorrect
+		case result.ParseError != "":
+			options.EventType = QuestionParseError
+			options.Error = result.ParseError
+		}
+		deps.observer.Emit(index, options)
+	}
+	return questionJobResult{index: index, result: result, correct: result.Correct, resumed: true}, true
+}
+
+// applyPriorRun adopts the identity and task selection of the run being resumed
+// or rerun, and returns the run id to use plus the id of the run being rerun.
+func applyPriorRun(params RunParams, commit, runID string) (RunParams, string, string, error) {
+	prior, rerunOf := params.Resume, ""
+	if params.Rerun != nil {
+		prior, rerunOf = params.Rerun, params.Rerun.RunID
+	}
+	if prior == nil {
+		return params, runID, "", nil
+	}
+	if prior.Commit != commit {
+		return params, "", "", fmt.Errorf("run %s was recorded at commit %s, but the checkout is at %s; use --rev %s", prior.RunID, prior.Commit, commit, prior.Commit)
+	}
+	if len(params.Selectors) == 0 {
+		params.Selectors = prior.Selectors
+	}
+	if !params.Sampling.active() {
+		params.Sampling = prior.Sampling
+	}
+	if params.Resume != nil {
+		runID = params.Resume.RunID
+		rerunOf = params.Resume.RerunOf
+		if params.AgentOverride == "" && len(params.Agents) == 0 {
+			params.AgentOverride = params.Resume.AgentOverride
+			params.Agents = params.Resume.Agents
+		}
+	}
+	return params, runID, rerunOf, nil
+}
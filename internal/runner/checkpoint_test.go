@@ -0,0 +1,185 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"cogni/internal/agent"
+	"cogni/internal/spec"
+	"cogni/internal/testutil"
+	"cogni/internal/tools"
+	"cogni/internal/vcs"
+)
+
+// flakyProvider answers "4" but fails prompts containing failOn, counting calls.
+type flakyProvider struct {
+	failOn string
+	mu     *sync.Mutex
+	calls  *[]string
+}
+
+// Stream records the prompt and answers or fails.
+func (p flakyProvider) Stream(_ context.Context, prompt agent.Prompt) (agent.Stream, error) {
+	text := ""
+	for _, item := range prompt.InputItems {
+		if message, ok := item.Content.(agent.HistoryText); ok {
+			text += message.Text
+		}
+	}
+	p.mu.Lock()
+	*p.calls = append(*p.calls, text)
+	p.mu.Unlock()
+	if p.failOn != "" && strings.Contains(text, p.failOn) {
+		return nil, errors.New("provider unavailable")
+	}
+	return &fakeStream{events: []agent.StreamEvent{{Type: agent.StreamEventMessage, Message: "<answer>4</answer>"}}}, nil
+}
+
+// writeFlakyRepo writes a three-question spec and returns the repo and config.
+func writeFlakyRepo(t *testing.T) (string, spec.Config) {
+	t.Helper()
+	repoRoot := t.TempDir()
+	specBody := `version: 1
+questions:
+  - {id: q1, question: "What is 2+2?", answers: ["4", "5"], correct_answers: ["4"]}
+  - {id: q2, question: "What is 3+1?", answers: ["4", "5"], correct_answers: ["4"]}
+  - {id: q3, question: "What is 1+3?", answers: ["4", "5"], correct_answers: ["4"]}
+`
+	if err := os.WriteFile(filepath.Join(repoRoot, "questions.yml"), []byte(specBody), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+	cfg := spec.Config{
+		Repo:         spec.RepoConfig{OutputDir: "./out"},
+		Agents:       []spec.AgentConfig{{ID: "agent-1", Type: "builtin", Provider: "openrouter", Model: "model"}},
+		DefaultAgent: "agent-1",
+		Tasks:        []spec.TaskConfig{{ID: "task-1", Type: "question_eval", Agent: "agent-1", QuestionsFile: "questions.yml"}},
+	}
+	return repoRoot, cfg
+}
+
+// runFlaky runs cfg with provider under runID, applying resume or rerun state from params.
+func runFlaky(t *testing.T, repoRoot string, cfg spec.Config, provider flakyProvider, runID string, params RunParams) (Results, OutputPaths) {
+	t.Helper()
+	return runFlakyContext(t, testutil.Context(t, 0), repoRoot, cfg, provider, runID, params)
+}
+
+// runFlakyContext is runFlaky with a caller-controlled context.
+func runFlakyContext(t *testing.T, ctx context.Context, repoRoot string, cfg spec.Config, provider agent.Provider, runID string, params RunParams) (Results, OutputPaths) {
+	t.Helper()
+	params.RepoRoot = repoRoot
+	params.Deps = RunDependencies{
+		ProviderFactory: func(_ spec.AgentConfig, _ string) (agent.Provider, error) {
+			return provider, nil
+		},
+		ToolRunnerFactory: func(root string) (*tools.Runner, error) {
+			return tools.NewRunner(root)
+		},
+		RepoRootResolver: func(_ context.Context, root string) (string, error) {
+			return root, nil
+		},
+		RepoMetadataLoader: func(_ context.Context, root string) (vcs.Metadata, error) {
+			return vcs.Metadata{Name: filepath.Base(root), VCS: "git", Commit: "commit"}, nil
+		},
+		RunID: func() (string, error) { return runID, nil },
+		Now:   time.Now,
+	}
+	results, paths, err := RunAndWrite(ctx, cfg, params)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	return results, paths
+}
+
+// TestResumeRerunsOnlyUnfinishedQuestions verifies checkpointed questions are
+// reused and only errored ones are evaluated again under the same run ID.
+func TestResumeRerunsOnlyUnfinishedQuestions(t *testing.T) {
+	repoRoot, cfg := writeFlakyRepo(t)
+	var mu sync.Mutex
+	var firstCalls []string
+	first, paths := runFlaky(t, repoRoot, cfg, flakyProvider{failOn: "3+1", mu: &mu, calls: &firstCalls}, "run-1", RunParams{})
+	if first.Tasks[0].Status != "error" {
+		t.Fatalf("expected first run to error, got %s", first.Tasks[0].Status)
+	}
+
+	checkpoint, err := LoadCheckpoint(paths.CheckpointPath())
+	if err != nil {
+		t.Fatalf("load checkpoint: %v", err)
+	}
+	if checkpoint.RunID != "run-1" || checkpoint.Commit != "commit" {
+		t.Fatalf("unexpected checkpoint header: %+v", checkpoint)
+	}
+
+	var resumeCalls []string
+	resumed, resumedPaths := runFlaky(t, repoRoot, cfg, flakyProvider{mu: &mu, calls: &resumeCalls}, "run-2", RunParams{Resume: checkpoint})
+	if len(resumeCalls) != 1 || !strings.Contains(resumeCalls[0], "3+1") {
+		t.Fatalf("expected only q2 to be rerun, got %q", resumeCalls)
+	}
+	if resumed.RunID != "run-1" || resumedPaths.RunDir() != paths.RunDir() {
+		t.Fatalf("expected resume to keep run-1, got %s at %s", resumed.RunID, resumedPaths.RunDir())
+	}
+	if !resumed.StartedAt.Equal(checkpoint.StartedAt) {
+		t.Fatalf("expected original start time, got %s", resumed.StartedAt)
+	}
+	eval := resumed.Tasks[0].QuestionEval
+	if resumed.Tasks[0].Status != "pass" || eval.Summary.QuestionsCorrect != 3 {
+		t.Fatalf("expected all questions correct after resume, got %+v", eval.Summary)
+	}
+	for i, id := range []string{"q1", "q2", "q3"} {
+		if eval.Questions[i].ID != id {
+			t.Fatalf("expected question order preserved, got %s at %d", eval.Questions[i].ID, i)
+		}
+	}
+}
+
+// TestResumeKeepsAnswerShuffleSeed verifies a resumed run presents answers in
+// the order chosen by the original run instead of reshuffling.
+func TestResumeKeepsAnswerShuffleSeed(t *testing.T) {
+	repoRoot, cfg := writeFlakyRepo(t)
+	cfg.Tasks[0].AnswerOrder = spec.TaskAnswerOrder{Shuffle: true}
+	var mu sync.Mutex
+	var calls []string
+	first, paths := runFlaky(t, repoRoot, cfg, flakyProvider{failOn: "3+1", mu: &mu, calls: &calls}, "run-1", RunParams{})
+	firstOrder := first.Tasks[0].QuestionEval.AnswerOrder
+	if firstOrder == nil || firstOrder.Seed == 0 {
+		t.Fatalf("expected a run-derived shuffle seed, got %+v", firstOrder)
+	}
+
+	checkpoint, err := LoadCheckpoint(paths.CheckpointPath())
+	if err != nil {
+		t.Fatalf("load checkpoint: %v", err)
+	}
+	if checkpoint.AnswerSeed != firstOrder.Seed {
+		t.Fatalf("expected checkpoint seed %d, got %d", firstOrder.Seed, checkpoint.AnswerSeed)
+	}
+	checkpoint.StartedAt = checkpoint.StartedAt.Add(time.Hour)
+	resumed, _ := runFlaky(t, repoRoot, cfg, flakyProvider{mu: &mu, calls: &calls}, "run-2", RunParams{Resume: checkpoint})
+	resumedOrder := resumed.Tasks[0].QuestionEval.AnswerOrder
+	if resumedOrder == nil || resumedOrder.Seed != firstOrder.Seed {
+		t.Fatalf("expected resume to reuse seed %d, got %+v", firstOrder.Seed, resumedOrder)
+	}
+}
+
+// TestLoadCheckpointIgnoresTruncatedLine verifies a partial final record from a
+// killed process does not prevent resuming.
+func TestLoadCheckpointIgnoresTruncatedLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.jsonl")
+	body := `{"kind":"run","run_id":"run-1","commit":"abc"}
+{"kind":"question","task_id":"t","agent_id":"a","index":0,"question":{"id":"q1","question":"Q","correct":true}}
+{"kind":"question","task_id":"t","agent_id":"a","ind`
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("write checkpoint: %v", err)
+	}
+	checkpoint, err := LoadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("load checkpoint: %v", err)
+	}
+	if len(checkpoint.questions["t@a"]) != 1 {
+		t.Fatalf("expected one recorded question, got %+v", checkpoint.questions)
+	}
+}
@@ -0,0 +1,225 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"cogni/internal/agent"
+	"cogni/internal/agent/call"
+	"cogni/internal/ratelimit"
+	"cogni/internal/spec"
+	"cogni/internal/vcs"
+	"cogni/internal/workspace"
+	"cogni/pkg/ratelimiter"
+)
+
+// codeChangeDeps bundles dependencies for a code_change task.
+type codeChangeDeps struct {
+	limiter           ratelimiter.Limiter
+	toolRunnerFactory ToolRunnerFactory
+	providerFactory   ProviderFactory
+	tokenCounter      agent.TokenCounter
+	setupRunner       SetupCommandRunner
+	verbose           bool
+	verboseWriter     io.Writer
+	verboseLog        io.Writer
+	noColor           bool
+}
+
+// runCodeChangeTask gives the agent write tools in an isolated worktree, then
+// runs the task's verification commands there and scores the task pass/fail.
+func runCodeChangeTask(ctx context.Context, repoRoot string, cfg spec.Config, task taskRun, deps codeChangeDeps) TaskResult {
+	result := TaskResult{TaskID: task.Task.ID, AgentID: task.AgentID, Type: task.Task.Type}
+	fail := func(status, reason string) TaskResult {
+		result.Status = status
+		result.FailureReason = &reason
+		return result
+	}
+
+	workDir, cleanup, err := prepareCodeChangeWorkspace(ctx, repoRoot, cfg.Repo.OutputDir, task.Task)
+	if err != nil {
+		logVerbose(deps.verbose, deps.verboseWriter, deps.verboseLog, deps.noColor, styleError,
+			fmt.Sprintf("Task %s workspace error=%v", task.Task.ID, err))
+		return fail("error", "workspace_error")
+	}
+	defer cleanup()
+	if err := runSetupCommands(ctx, workDir, cfg.Repo.SetupCommands, deps.setupRunner); err != nil {
+		logVerbose(deps.verbose, deps.verboseWriter, deps.verboseLog, deps.noColor, styleError,
+			fmt.Sprintf("Task %s setup error=%v", task.Task.ID, err))
+		return fail("error", "setup_error")
+	}
+	// Diff against the tree as setup left it, so generated files are not
+	// credited to the agent.
+	baseline, err := vcs.WorktreeBaseline(ctx, workDir)
+	if err != nil {
+		return fail("error", "workspace_error")
+	}
+	toolRunner, err := deps.toolRunnerFactory(workDir)
+	if err != nil {
+		return fail("error", "workspace_error")
+	}
+	compactionConfig, err := buildCompactionConfig(task.Task, repoRoot)
+	if err != nil {
+		return fail("error", "runtime_error")
+	}
+
+	change := &CodeChangeResult{}
+	result.CodeChange = change
+	callResult, runErr := runCodeChangeAgent(ctx, cfg, task, workDir, agent.RunnerExecutor{Runner: toolRunner, AllowWrites: true}, compactionConfig, deps)
+	metrics := callResult.Metrics
+	change.TokensTotal = metrics.Tokens
+	change.WallTimeSeconds = metrics.WallTime.Seconds()
+	change.AgentSteps = metrics.Steps
+	change.ToolCalls = metrics.ToolCalls
+	change.Compactions = metrics.Compactions
+	var exhausted budgetError
+	if errors.As(runErr, &exhausted) {
+		return fail("skipped", exhausted.reason)
+	}
+	if runErr != nil {
+		change.AgentError = runErr.Error()
+	}
+
+	diff, err := vcs.WorktreeDiff(ctx, workDir, baseline)
+	if err != nil {
+		return fail("error", "workspace_error")
+	}
+	change.Diff = diff
+	change.FilesChanged = diffFiles(diff)
+	change.Verification = runVerifyCommands(ctx, workDir, task.Task.VerifyCommands)
+	logVerbose(deps.verbose, deps.verboseWriter, deps.verboseLog, deps.noColor, styleMetrics,
+		fmt.Sprintf("Task %s files_changed=%d verification_passed=%t", task.Task.ID, len(change.FilesChanged), verificationPassed(change.Verification)))
+
+	switch {
+	case runErr != nil && errors.Is(runErr, call.ErrBudgetExceeded):
+		return fail("fail", "budget_exceeded")
+	case runErr != nil:
+		return fail("error", "runtime_error")
+	case !verificationPassed(change.Verification):
+		return fail("fail", "verification_failed")
+	}
+	result.Status = "pass"
+	return result
+}
+
+// runCodeChangeAgent runs the agent once through the rate-limit scheduler.
+func runCodeChangeAgent(ctx context.Context, cfg spec.Config, task taskRun, workDir string, executor agent.ToolExecutor, compaction agent.CompactionConfig, deps codeChangeDeps) (call.CallResult, error) {
+	promptText := buildCodeChangePrompt(task.Task)
+	scheduler := ratelimiter.NewSchedulerWithContext(ctx, deps.limiter, 1, nil)
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 2*time.Second)
+		defer cancel()
+		_ = scheduler.Shutdown(shutdownCtx)
+	}()
+
+	type outcome struct {
+		result call.CallResult
+		err    error
+	}
+	done := make(chan outcome, 1)
+	scheduler.Submit(ratelimiter.Job{
+		JobID:           task.Task.ID,
+		Provider:        task.Agent.Provider,
+		Model:           task.Model,
+		Prompt:          promptText,
+		MaxOutputTokens: ratelimit.MaxOutputTokens(cfg, task.Task),
+		TenantID:        cfg.RateLimiter.Tenant,
+		Priority:        ratelimit.JobPriority(cfg),
+		Admit:           task.Budget.admit,
+		Execute: func(ctx context.Context) (uint64, error) {
+			provider, err := deps.providerFactory(task.Agent, task.Model)
+			if err != nil {
+				done <- outcome{err: err}
+				return 0, err
+			}
+			tools := append(DefaultToolDefinitions(), WriteToolDefinitions()...)
+			session := newSession(task, workDir, tools, deps.verbose)
+			callResult, runErr := call.RunCall(ctx, session, provider, executor, promptText, call.RunOptions{
+				TokenCounter: deps.tokenCounter,
+				Compaction:   compaction,
+				Limits: call.RunLimits{
+					MaxSteps:   limitOrDefault(task.Task.Budget.MaxSteps, task.Agent.MaxSteps),
+					MaxSeconds: time.Duration(task.Task.Budget.MaxSeconds) * time.Second,
+					MaxTokens:  task.Task.Budget.MaxTokens,
+				},
+				Verbose:          deps.verbose,
+				VerboseWriter:    deps.verboseWriter,
+				VerboseLogWriter: deps.verboseLog,
+				NoColor:          deps.noColor,
+			}, nil)
+			task.Budget.charge(callResult.Metrics.Tokens)
+			done <- outcome{result: callResult, err: runErr}
+			return uint64(callResult.Metrics.Tokens), runErr
+		},
+		OnCancel: func(err error) {
+			done <- outcome{err: err}
+		},
+	})
+	select {
+	case out := <-done:
+		return out.result, out.err
+	case <-ctx.Done():
+		return call.CallResult{}, ctx.Err()
+	}
+}
+
+// buildCodeChangePrompt constructs the user prompt for a code_change task.
+func buildCodeChangePrompt(task spec.TaskConfig) string {
+	var builder strings.Builder
+	builder.WriteString(strings.TrimSpace(task.Prompt))
+	builder.WriteString("\n\nMake the change directly in the repository using apply_patch or write_file.")
+	if len(task.VerifyCommands) > 0 {
+		builder.WriteString(" Your change will be checked by running:\n")
+		for _, command := range task.VerifyCommands {
+			builder.WriteString("- ")
+			builder.WriteString(command)
+			builder.WriteString("\n")
+		}
+	} else {
+		builder.WriteString("\n")
+	}
+	builder.WriteString("When you are done, reply with a short summary of the change.\n")
+	return builder.String()
+}
+
+// prepareCodeChangeWorkspace creates an isolated checkout of HEAD for the task.
+// Tasks use a git worktree unless they select copy mode.
+func prepareCodeChangeWorkspace(ctx context.Context, repoRoot, outputDir string, task spec.TaskConfig) (string, func(), error) {
+	mode, _ := workspace.ParseMode(task.Workspace)
+	if mode == workspace.ModeShared {
+		mode = workspace.ModeWorktree
+	}
+	manager, err := workspace.NewManager(ctx, repoRoot, "", mode)
+	if err != nil {
+		return "", nil, err
+	}
+	manager.ExcludeFromCopies(outputDir)
+	job, err := manager.Acquire(ctx)
+	if err != nil {
+		_ = manager.Close(context.Background())
+		return "", nil, err
+	}
+	cleanup := func() {
+		_ = manager.Close(context.Background())
+	}
+	return job.Dir, cleanup, nil
+}
+
+// diffFiles lists the paths touched by a git diff.
+func diffFiles(diff string) []string {
+	var files []string
+	for _, line := range strings.Split(diff, "\n") {
+		rest, ok := strings.CutPrefix(line, "diff --git a/")
+		if !ok {
+			continue
+		}
+		if index := strings.Index(rest, " b/"); index >= 0 {
+			files = append(files, rest[index+len(" b/"):])
+		}
+	}
+	return files
+}
@@ -0,0 +1,188 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"cogni/internal/agent"
+	"cogni/internal/spec"
+	"cogni/internal/testutil"
+	"cogni/internal/tools"
+	"cogni/internal/vcs"
+)
+
+// scriptedProvider streams a fixed list of events per turn.
+type scriptedProvider struct {
+	turns [][]agent.StreamEvent
+	index *int
+}
+
+// Stream returns the events for the next turn.
+func (p scriptedProvider) Stream(_ context.Context, _ agent.Prompt) (agent.Stream, error) {
+	if *p.index >= len(p.turns) {
+		return &fakeStream{events: []agent.StreamEvent{}}, nil
+	}
+	events := p.turns[*p.index]
+	*p.index++
+	return &fakeStream{events: events}, nil
+}
+
+// initGitRepo creates a repository with a single committed file.
+func initGitRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "README.md"), []byte("hello\n"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "-A"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, output)
+		}
+	}
+	return root
+}
+
+// runCodeChange runs a single code_change task against repoRoot.
+func runCodeChange(t *testing.T, repoRoot string, verify []string, turns [][]agent.StreamEvent) Results {
+	t.Helper()
+	return runCodeChangeWithSetup(t, repoRoot, nil, verify, turns)
+}
+
+// runCodeChangeWithSetup runs a single code_change task after the given
+// repo setup commands.
+func runCodeChangeWithSetup(t *testing.T, repoRoot string, setup, verify []string, turns [][]agent.StreamEvent) Results {
+	t.Helper()
+	cfg := spec.Config{
+		Repo:         spec.RepoConfig{OutputDir: "./out", SetupCommands: setup},
+		Agents:       []spec.AgentConfig{{ID: "agent-1", Type: "builtin", Provider: "openrouter", Model: "model"}},
+		DefaultAgent: "agent-1",
+		Tasks: []spec.TaskConfig{
+			{ID: "add-notes", Type: "code_change", Agent: "agent-1", Prompt: "Add a NOTES.md file.", VerifyCommands: verify},
+		},
+	}
+	index := 0
+	results, err := Run(testutil.Context(t, 0), cfg, RunParams{
+		RepoRoot: repoRoot,
+		Deps: RunDependencies{
+			ProviderFactory: func(_ spec.AgentConfig, _ string) (agent.Provider, error) {
+				return scriptedProvider{turns: turns, index: &index}, nil
+			},
+			ToolRunnerFactory: func(root string) (*tools.Runner, error) {
+				return tools.NewRunner(root)
+			},
+			RepoRootResolver: func(_ context.Context, root string) (string, error) {
+				return root, nil
+			},
+			RepoMetadataLoader: func(_ context.Context, root string) (vcs.Metadata, error) {
+				return vcs.Metadata{Name: filepath.Base(root), VCS: "git", Commit: "commit"}, nil
+			},
+			RunID: func() (string, error) { return "run-1", nil },
+			Now:   time.Now,
+		},
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	return results
+}
+
+// TestRunCodeChangeTask verifies the agent edits an isolated worktree, the diff is
+// captured, and verification commands decide the task status.
+func TestRunCodeChangeTask(t *testing.T) {
+	repoRoot := initGitRepo(t)
+	writeCall := agent.ToolCall{ID: "c1", Name: "write_file", Args: agent.ToolCallArgs{
+		"path":    json.RawMessage(`"NOTES.md"`),
+		"content": json.RawMessage(`"notes\n"`),
+	}}
+	turns := [][]agent.StreamEvent{
+		{{Type: agent.StreamEventToolCall, ToolCall: writeCall}},
+		{{Type: agent.StreamEventMessage, Message: "Added NOTES.md."}},
+	}
+	results := runCodeChange(t, repoRoot, []string{"test -f NOTES.md", "grep -q notes NOTES.md"}, turns)
+
+	task := results.Tasks[0]
+	if task.Status != "pass" || task.CodeChange == nil {
+		t.Fatalf("expected pass with code change result, got %+v", task)
+	}
+	if len(task.CodeChange.FilesChanged) != 1 || task.CodeChange.FilesChanged[0] != "NOTES.md" {
+		t.Fatalf("unexpected files changed: %v", task.CodeChange.FilesChanged)
+	}
+	if !strings.Contains(task.CodeChange.Diff, "+notes") {
+		t.Fatalf("expected diff to include new content, got %q", task.CodeChange.Diff)
+	}
+	if _, err := os.Stat(filepath.Join(repoRoot, "NOTES.md")); !os.IsNotExist(err) {
+		t.Fatalf("expected main checkout to be untouched, got %v", err)
+	}
+
+	paths, err := WriteRunOutputs(results, filepath.Join(repoRoot, "out"))
+	if err != nil {
+		t.Fatalf("write outputs: %v", err)
+	}
+	diff, err := os.ReadFile(filepath.Join(paths.RunDir(), "tasks", "add-notes", "diff.patch"))
+	if err != nil || !strings.Contains(string(diff), "NOTES.md") {
+		t.Fatalf("expected diff artifact, got %q (%v)", diff, err)
+	}
+	if _, err := os.Stat(filepath.Join(paths.RunDir(), "tasks", "add-notes", "verify-2.log")); err != nil {
+		t.Fatalf("expected verification log: %v", err)
+	}
+}
+
+// TestRunCodeChangeTaskExcludesSetupOutput verifies files written by setup
+// commands are not reported as the agent's changes.
+func TestRunCodeChangeTaskExcludesSetupOutput(t *testing.T) {
+	repoRoot := initGitRepo(t)
+	writeCall := agent.ToolCall{ID: "c1", Name: "write_file", Args: agent.ToolCallArgs{
+		"path":    json.RawMessage(`"NOTES.md"`),
+		"content": json.RawMessage(`"notes\n"`),
+	}}
+	turns := [][]agent.StreamEvent{
+		{{Type: agent.StreamEventToolCall, ToolCall: writeCall}},
+		{{Type: agent.StreamEventMessage, Message: "Added NOTES.md."}},
+	}
+	results := runCodeChangeWithSetup(t, repoRoot, []string{"echo generated > generated.txt"}, []string{"test -f generated.txt"}, turns)
+
+	task := results.Tasks[0]
+	if task.Status != "pass" || task.CodeChange == nil {
+		t.Fatalf("expected pass with code change result, got %+v", task)
+	}
+	if len(task.CodeChange.FilesChanged) != 1 || task.CodeChange.FilesChanged[0] != "NOTES.md" {
+		t.Fatalf("expected only the agent's file, got %v", task.CodeChange.FilesChanged)
+	}
+	if strings.Contains(task.CodeChange.Diff, "generated") {
+		t.Fatalf("expected setup output left out of the diff, got %q", task.CodeChange.Diff)
+	}
+}
+
+// TestRunCodeChangeTaskVerificationFailure verifies failing checks fail the task.
+func TestRunCodeChangeTaskVerificationFailure(t *testing.T) {
+	repoRoot := initGitRepo(t)
+	turns := [][]agent.StreamEvent{{{Type: agent.StreamEventMessage, Message: "Nothing to do."}}}
+	results := runCodeChange(t, repoRoot, []string{"test -f NOTES.md"}, turns)
+
+	task := results.Tasks[0]
+	if task.Status != "fail" || task.FailureReason == nil || *task.FailureReason != "verification_failed" {
+		t.Fatalf("expected verification_failed, got %+v", task)
+	}
+	verification := task.CodeChange.Verification
+	if len(verification) != 1 || verification[0].Passed || verification[0].ExitCode == 0 {
+		t.Fatalf("unexpected verification results: %+v", verification)
+	}
+	if len(task.CodeChange.FilesChanged) != 0 {
+		t.Fatalf("expected no files changed, got %v", task.CodeChange.FilesChanged)
+	}
+}
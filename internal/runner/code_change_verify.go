@@ -0,0 +1,60 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// runVerifyCommands runs each verification command in dir and captures its output.
+// Every command runs so the results show all failures, not just the first.
+func runVerifyCommands(ctx context.Context, dir string, commands []string) []VerificationResult {
+	results := make([]VerificationResult, 0, len(commands))
+	for _, command := range commands {
+		if strings.TrimSpace(command) == "" {
+			continue
+		}
+		results = append(results, runVerifyCommand(ctx, dir, command))
+	}
+	return results
+}
+
+// runVerifyCommand executes one verification command via the shell.
+func runVerifyCommand(ctx context.Context, dir, command string) VerificationResult {
+	start := time.Now()
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	err := cmd.Run()
+	result := VerificationResult{
+		Command:         command,
+		DurationSeconds: time.Since(start).Seconds(),
+		Output:          output.String(),
+	}
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		result.Passed = true
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	default:
+		result.ExitCode = -1
+		result.Output += err.Error() + "\n"
+	}
+	return result
+}
+
+// verificationPassed reports whether every verification command succeeded.
+func verificationPassed(results []VerificationResult) bool {
+	for _, result := range results {
+		if !result.Passed {
+			return false
+		}
+	}
+	return true
+}
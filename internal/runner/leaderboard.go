@@ -0,0 +1,76 @@
+package runner
+
+import (
+	"sort"
+
+	"cogni/internal/spec"
+)
+
+// AgentStanding aggregates one agent's results with one model in a matrix run.
+type AgentStanding struct {
+	AgentID          string `json:"agent_id"`
+	Model            string `json:"model"`
+	TasksTotal       int    `json:"tasks_total"`
+	TasksPassed      int    `json:"tasks_passed"`
+	QuestionsTotal   int    `json:"questions_total,omitempty"`
+	QuestionsCorrect int    `json:"questions_correct,omitempty"`
+	// QuestionsUnscored counts skipped and cancelled questions, which are left
+	// out of Accuracy.
+	QuestionsUnscored int     `json:"questions_unscored,omitempty"`
+	Accuracy          float64 `json:"accuracy"`
+	TokensTotal       int     `json:"tokens_total"`
+	// CostUSD is estimated from the agent's cost_per_million_tokens; nil when unpriced.
+	CostUSD         *float64 `json:"cost_usd,omitempty"`
+	WallTimeSeconds float64  `json:"wall_time_seconds"`
+}
+
+// buildLeaderboard ranks agents by accuracy, then by tokens spent. Accuracy is
+// question accuracy when the agent answered questions, otherwise task pass rate.
+// Standings are labeled with the model each task actually ran, so an agent
+// whose tasks used different models gets one standing per model.
+func buildLeaderboard(tasks []TaskResult, agents map[string]spec.AgentConfig) []AgentStanding {
+	byAgent := map[string]*AgentStanding{}
+	var order []string
+	for _, task := range tasks {
+		model := task.Model
+		if model == "" {
+			model = agents[task.AgentID].Model
+		}
+		key := task.AgentID + "\x00" + model
+		standing, ok := byAgent[key]
+		if !ok {
+			standing = &AgentStanding{AgentID: task.AgentID, Model: model}
+			byAgent[key] = standing
+			order = append(order, key)
+		}
+		summary := summarize([]TaskResult{task})
+		standing.TasksTotal++
+		standing.TasksPassed += summary.TasksPassed
+		standing.QuestionsTotal += summary.QuestionsTotal
+		standing.QuestionsCorrect += summary.QuestionsCorrect
+		standing.QuestionsUnscored += summary.QuestionsSkipped + summary.QuestionsCancelled
+		standing.TokensTotal += summary.TokensTotal
+		standing.WallTimeSeconds += task.WallTimeSeconds
+	}
+	standings := make([]AgentStanding, 0, len(order))
+	for _, key := range order {
+		standing := *byAgent[key]
+		if scored := standing.QuestionsTotal - standing.QuestionsUnscored; scored > 0 {
+			standing.Accuracy = float64(standing.QuestionsCorrect) / float64(scored)
+		} else if standing.TasksTotal > 0 {
+			standing.Accuracy = float64(standing.TasksPassed) / float64(standing.TasksTotal)
+		}
+		if price := agents[standing.AgentID].CostPerMillionTokens; price > 0 {
+			cost := float64(standing.TokensTotal) * price / 1e6
+			standing.CostUSD = &cost
+		}
+		standings = append(standings, standing)
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Accuracy != standings[j].Accuracy {
+			return standings[i].Accuracy > standings[j].Accuracy
+		}
+		return standings[i].TokensTotal < standings[j].TokensTotal
+	})
+	return standings
+}
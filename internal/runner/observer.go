@@ -18,6 +18,8 @@ const (
 	QuestionWaitingLimitDecreasing QuestionEventType = "waiting_limit_decreasing"
 	// QuestionWaitingLimiterError marks a reserve error retry.
 	QuestionWaitingLimiterError QuestionEventType = "waiting_limiter_error"
+	// QuestionWaitingRetry marks a failed attempt waiting to be retried.
+	QuestionWaitingRetry QuestionEventType = "waiting_retry"
 	// QuestionRunning marks an active model call.
 	QuestionRunning QuestionEventType = "running"
 	// QuestionParsing marks parsing the model response.
@@ -26,12 +28,16 @@ const (
 	QuestionCorrect QuestionEventType = "correct"
 	// QuestionIncorrect marks an incorrect answer.
 	QuestionIncorrect QuestionEventType = "incorrect"
+	// QuestionRecovering marks a follow-up turn asking the agent to restate an unparsable answer.
+	QuestionRecovering QuestionEventType = "recovering"
 	// QuestionParseError marks a parse failure.
 	QuestionParseError QuestionEventType = "parse_error"
 	// QuestionBudgetExceeded marks a budget exceeded failure.
 	QuestionBudgetExceeded QuestionEventType = "budget_exceeded"
 	// QuestionRuntimeError marks a runtime error.
 	QuestionRuntimeError QuestionEventType = "runtime_error"
+	// QuestionCancelled marks a question interrupted by run cancellation.
+	QuestionCancelled QuestionEventType = "cancelled"
 	// QuestionSkipped marks a question skipped due to task-level failure.
 	QuestionSkipped QuestionEventType = "skipped"
 	// QuestionToolStart marks the start of a tool call.
@@ -48,6 +54,7 @@ type QuestionEvent struct {
 	QuestionText  string
 	Type          QuestionEventType
 	RetryAfterMs  int
+	Attempt       int
 	ToolName      string
 	ToolDuration  time.Duration
 	ToolError     string
@@ -50,3 +50,11 @@ func (o OutputPaths) ReportPath() string {
 func (o OutputPaths) LogsDir() string {
 	return filepath.Join(o.RunDir(), "logs")
 }
+
+// CheckpointFileName is the incremental checkpoint log inside a run directory.
+const CheckpointFileName = "checkpoint.jsonl"
+
+// CheckpointPath returns the path to the incremental checkpoint log.
+func (o OutputPaths) CheckpointPath() string {
+	return filepath.Join(o.RunDir(), CheckpointFileName)
+}
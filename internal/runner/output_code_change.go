@@ -0,0 +1,34 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// writeCodeChangeArtifacts writes diffs and verification logs for code_change
+// tasks under <run>/tasks/<task-id>/ and records their run-relative paths.
+func writeCodeChangeArtifacts(runDir string, tasks []TaskResult) error {
+	for i := range tasks {
+		change := tasks[i].CodeChange
+		if change == nil {
+			continue
+		}
+		relDir := filepath.Join("tasks", tasks[i].TaskID)
+		if err := os.MkdirAll(filepath.Join(runDir, relDir), 0o755); err != nil {
+			return fmt.Errorf("create task dir: %w", err)
+		}
+		change.DiffPath = filepath.Join(relDir, "diff.patch")
+		if err := os.WriteFile(filepath.Join(runDir, change.DiffPath), []byte(change.Diff), 0o644); err != nil {
+			return fmt.Errorf("write diff: %w", err)
+		}
+		for index := range change.Verification {
+			verification := &change.Verification[index]
+			verification.OutputPath = filepath.Join(relDir, fmt.Sprintf("verify-%d.log", index+1))
+			if err := os.WriteFile(filepath.Join(runDir, verification.OutputPath), []byte(verification.Output), 0o644); err != nil {
+				return fmt.Errorf("write verification log: %w", err)
+			}
+		}
+	}
+	return nil
+}
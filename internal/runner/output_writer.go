@@ -19,6 +19,9 @@ func WriteRunOutputs(results Results, outputDir string) (OutputPaths, error) {
 	if err := os.MkdirAll(paths.RunDir(), 0o755); err != nil {
 		return OutputPaths{}, fmt.Errorf("create output dir: %w", err)
 	}
+	if err := writeCodeChangeArtifacts(paths.RunDir(), results.Tasks); err != nil {
+		return OutputPaths{}, err
+	}
 	if err := writeJSON(paths.ResultsPath(), results); err != nil {
 		return OutputPaths{}, err
 	}
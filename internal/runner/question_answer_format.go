@@ -0,0 +1,100 @@
+package runner
+
+import (
+	"errors"
+	"strings"
+
+	"cogni/internal/agent"
+	"cogni/internal/agent/call"
+	"cogni/internal/question"
+)
+
+// Answer formats selectable per task with answer_format.
+const (
+	answerFormatXML  = "xml"
+	answerFormatTool = "tool"
+	answerFormatJSON = "json"
+)
+
+// submitAnswerTool is the tool that ends a call with a typed answer.
+const submitAnswerTool = "submit_answer"
+
+// answerOutputSchema is the JSON schema for structured answer output.
+const answerOutputSchema = `{"type":"object","properties":{"answer":{"type":"string"}},"required":["answer"],"additionalProperties":false}`
+
+// errMissingSubmitAnswer indicates the agent finished without calling submit_answer.
+var errMissingSubmitAnswer = errors.New("missing submit_answer call")
+
+// resolveAnswerFormat returns the configured answer format, defaulting to XML.
+func resolveAnswerFormat(format string) string {
+	format = strings.TrimSpace(format)
+	if format == "" {
+		return answerFormatXML
+	}
+	return format
+}
+
+// answerFormatResult reports the answer format recorded in results; XML is omitted.
+func answerFormatResult(format string) string {
+	if format == answerFormatXML {
+		return ""
+	}
+	return format
+}
+
+// submitAnswerDefinition describes the submit_answer tool.
+func submitAnswerDefinition() agent.ToolDefinition {
+	return agent.ToolDefinition{
+		Name:        submitAnswerTool,
+		Description: "Submit the final answer to the question. Calling this tool ends your turn.",
+		Parameters: &agent.ToolSchema{
+			Type: "object",
+			Properties: map[string]agent.ToolSchema{
+				"answer": agent.StringSchema(),
+			},
+			Required:             []string{"answer"},
+			AdditionalProperties: agent.BoolPointer(false),
+		},
+	}
+}
+
+// answerToolDefinitions returns the tools offered to the agent for a format.
+func answerToolDefinitions(format string, toolDefs []agent.ToolDefinition) []agent.ToolDefinition {
+	if format != answerFormatTool {
+		return toolDefs
+	}
+	defs := make([]agent.ToolDefinition, 0, len(toolDefs)+1)
+	defs = append(defs, toolDefs...)
+	return append(defs, submitAnswerDefinition())
+}
+
+// terminalToolFor returns the tool whose call ends a question run, if any.
+func terminalToolFor(format string) string {
+	if format == answerFormatTool {
+		return submitAnswerTool
+	}
+	return ""
+}
+
+// parseAgentAnswer extracts the agent's answer using the task's answer format.
+// Tool-format runs fall back to a trailing <answer> block when the tool was not called.
+func parseAgentAnswer(format string, result call.CallResult) (question.ParsedAnswer, error) {
+	switch format {
+	case answerFormatTool:
+		if result.TerminalCall == nil {
+			if answer, err := question.ParseAnswerFromOutput(result.Output); err == nil {
+				return answer, nil
+			}
+			return question.ParsedAnswer{}, errMissingSubmitAnswer
+		}
+		text, err := result.TerminalCall.Args.RequiredString("answer")
+		if err != nil {
+			return question.ParsedAnswer{}, err
+		}
+		return question.ParseAnswerText(text)
+	case answerFormatJSON:
+		return question.ParseAnswerJSON(result.Output)
+	default:
+		return question.ParseAnswerFromOutput(result.Output)
+	}
+}
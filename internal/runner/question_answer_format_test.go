@@ -0,0 +1,133 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"cogni/internal/agent"
+	"cogni/internal/spec"
+	"cogni/internal/testutil"
+	"cogni/internal/tools"
+	"cogni/internal/vcs"
+)
+
+// promptCapturingProvider replays events and records the prompts it receives.
+type promptCapturingProvider struct {
+	events  []agent.StreamEvent
+	prompts *[]agent.Prompt
+}
+
+// Stream records the prompt and returns the configured events.
+func (p promptCapturingProvider) Stream(_ context.Context, prompt agent.Prompt) (agent.Stream, error) {
+	*p.prompts = append(*p.prompts, prompt)
+	return &fakeStream{events: p.events}, nil
+}
+
+// runAnswerFormatTask runs a single-question task with the given answer format.
+func runAnswerFormatTask(t *testing.T, format string, events []agent.StreamEvent) (QuestionEval, []agent.Prompt) {
+	t.Helper()
+	repoRoot := t.TempDir()
+	specBody := `version: 1
+questions:
+  - id: q1
+    question: "What is 2+2?"
+    answers: ["4", "5"]
+    correct_answers: ["4"]
+`
+	if err := os.WriteFile(filepath.Join(repoRoot, "questions.yml"), []byte(specBody), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+	cfg := spec.Config{
+		Repo:         spec.RepoConfig{OutputDir: "./out"},
+		Agents:       []spec.AgentConfig{{ID: "agent-1", Type: "builtin", Provider: "openrouter", Model: "model"}},
+		DefaultAgent: "agent-1",
+		Tasks: []spec.TaskConfig{
+			{ID: "task-1", Type: "question_eval", Agent: "agent-1", QuestionsFile: "questions.yml", AnswerFormat: format},
+		},
+	}
+	var prompts []agent.Prompt
+	ctx := testutil.Context(t, 0)
+	results, err := Run(ctx, cfg, RunParams{
+		RepoRoot: repoRoot,
+		Deps: RunDependencies{
+			ProviderFactory: func(_ spec.AgentConfig, _ string) (agent.Provider, error) {
+				return promptCapturingProvider{events: events, prompts: &prompts}, nil
+			},
+			ToolRunnerFactory: func(root string) (*tools.Runner, error) {
+				return tools.NewRunner(root)
+			},
+			RepoRootResolver: func(_ context.Context, root string) (string, error) {
+				return root, nil
+			},
+			RepoMetadataLoader: func(_ context.Context, root string) (vcs.Metadata, error) {
+				return vcs.Metadata{Name: filepath.Base(root), VCS: "git", Commit: "commit"}, nil
+			},
+			RunID: func() (string, error) { return "run-1", nil },
+			Now:   time.Now,
+		},
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	return *results.Tasks[0].QuestionEval, prompts
+}
+
+// TestQuestionEvalSubmitAnswerTool verifies the submit_answer tool ends the call
+// with a typed answer even when the model also produced trailing text.
+func TestQuestionEvalSubmitAnswerTool(t *testing.T) {
+	eval, prompts := runAnswerFormatTask(t, answerFormatTool, []agent.StreamEvent{
+		{Type: agent.StreamEventMessage, Message: "<answer>5</answer> and some trailing notes"},
+		{Type: agent.StreamEventToolCall, ToolCall: agent.ToolCall{ID: "c1", Name: submitAnswerTool, Args: agent.ToolCallArgs{"answer": json.RawMessage(`"4"`)}}},
+	})
+	result := eval.Questions[0]
+	if !result.Correct || result.AgentAnswer != "4" || result.ParseError != "" {
+		t.Fatalf("expected tool answer to be scored, got %+v", result)
+	}
+	if eval.AnswerFormat != answerFormatTool {
+		t.Fatalf("expected answer format recorded, got %q", eval.AnswerFormat)
+	}
+	if len(prompts) != 1 || !hasTool(prompts[0].Tools, submitAnswerTool) {
+		t.Fatalf("expected a single call offering submit_answer, got %d prompts", len(prompts))
+	}
+}
+
+// TestQuestionEvalJSONAnswer verifies JSON answers are parsed and the schema is sent.
+func TestQuestionEvalJSONAnswer(t *testing.T) {
+	eval, prompts := runAnswerFormatTask(t, answerFormatJSON, []agent.StreamEvent{
+		{Type: agent.StreamEventMessage, Message: `{"answer": "4"}`},
+	})
+	if !eval.Questions[0].Correct {
+		t.Fatalf("expected JSON answer to be scored, got %+v", eval.Questions[0])
+	}
+	if len(prompts) != 1 || prompts[0].OutputSchema != answerOutputSchema {
+		t.Fatalf("expected output schema on prompt, got %+v", prompts)
+	}
+	if hasTool(prompts[0].Tools, submitAnswerTool) {
+		t.Fatalf("did not expect submit_answer tool in json mode")
+	}
+}
+
+// TestQuestionEvalToolFormatRequiresAnswer verifies missing tool calls are parse errors.
+func TestQuestionEvalToolFormatRequiresAnswer(t *testing.T) {
+	eval, _ := runAnswerFormatTask(t, answerFormatTool, []agent.StreamEvent{
+		{Type: agent.StreamEventMessage, Message: "I think it is 4."},
+	})
+	if !strings.Contains(eval.Questions[0].ParseError, submitAnswerTool) {
+		t.Fatalf("expected missing submit_answer parse error, got %+v", eval.Questions[0])
+	}
+}
+
+// hasTool reports whether defs include a tool with the given name.
+func hasTool(defs []agent.ToolDefinition, name string) bool {
+	for _, def := range defs {
+		if def.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+package runner
+
+import (
+	"math/rand"
+	"strings"
+
+	"cogni/internal/question"
+	"cogni/internal/spec"
+)
+
+// answerLabelsLetters labels presented answer choices A, B, C, ...
+const answerLabelsLetters = "letters"
+
+// answerLayout records how a question's answer choices were presented to the agent.
+type answerLayout struct {
+	choices []string
+	order   []int
+	labels  []string
+}
+
+// resolveAnswerOrder fills in the run's shuffle seed for a task when one is
+// not configured.
+func resolveAnswerOrder(order spec.TaskAnswerOrder, runSeed int64) spec.TaskAnswerOrder {
+	order.Labels = strings.TrimSpace(order.Labels)
+	if order.Labels == "none" {
+		order.Labels = ""
+	}
+	if order.Shuffle && order.Seed == 0 {
+		order.Seed = runSeed
+	}
+	return order
+}
+
+// answerOrderResult reports the answer ordering used for a task, if any.
+func answerOrderResult(order spec.TaskAnswerOrder) *AnswerOrder {
+	if !order.Shuffle && order.Labels == "" {
+		return nil
+	}
+	return &AnswerOrder{Shuffled: order.Shuffle, Seed: order.Seed, Labels: order.Labels}
+}
+
+// buildAnswerLayout computes the presented answer order for a question.
+// Shuffles are derived from the task seed and question index so reruns with
+// the same seed present identical orderings.
+func buildAnswerLayout(item question.Question, order spec.TaskAnswerOrder, index int) answerLayout {
+	positions := make([]int, len(item.Answers))
+	for i := range positions {
+		positions[i] = i
+	}
+	if order.Shuffle {
+		rng := rand.New(rand.NewSource(order.Seed + int64(index)))
+		rng.Shuffle(len(positions), func(i, j int) {
+			positions[i], positions[j] = positions[j], positions[i]
+		})
+	}
+	layout := answerLayout{
+		choices: make([]string, len(positions)),
+		order:   positions,
+	}
+	for presented, original := range positions {
+		layout.choices[presented] = item.Answers[original]
+	}
+	if order.Labels == answerLabelsLetters {
+		layout.labels = make([]string, len(positions))
+		for i := range positions {
+			layout.labels[i] = answerLabel(i)
+		}
+	}
+	return layout
+}
+
+// answerLabel returns the letter label for a zero-based position (A..Z, AA..).
+func answerLabel(position int) string {
+	label := ""
+	for position >= 0 {
+		label = string(rune('A'+position%26)) + label
+		position = position/26 - 1
+	}
+	return label
+}
+
+// labeled reports whether answer choices are presented with labels.
+func (l answerLayout) labeled() bool {
+	return len(l.labels) > 0
+}
+
+// shuffled reports whether the presented order differs from spec order.
+func (l answerLayout) shuffled() bool {
+	for presented, original := range l.order {
+		if presented != original {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve maps a parsed answer back to its original choice text and presented
+// position. The position is -1 when the answer matches no choice.
+func (l answerLayout) resolve(answer question.ParsedAnswer) (string, int) {
+	if l.labeled() {
+		if position := l.matchLabel(answer.Normalized); position >= 0 {
+			return l.choices[position], position
+		}
+	}
+	for position, choice := range l.choices {
+		if question.NormalizeAnswerText(choice) == answer.Normalized {
+			return choice, position
+		}
+	}
+	return answer.Raw, -1
+}
+
+// matchLabel matches answers such as "b", "b)", "(b)", "b." or "b) text" to a label.
+func (l answerLayout) matchLabel(normalized string) int {
+	candidate := strings.TrimPrefix(normalized, "(")
+	for position, label := range l.labels {
+		lower := strings.ToLower(label)
+		if !strings.HasPrefix(candidate, lower) {
+			continue
+		}
+		rest := strings.TrimPrefix(candidate, lower)
+		if rest == "" {
+			return position
+		}
+		switch rest[0] {
+		case ')', '.', ':':
+			return position
+		}
+	}
+	return -1
+}
+
+// correctPosition returns the presented position of the first correct answer, or -1.
+func (l answerLayout) correctPosition(correctAnswers []string) int {
+	for position, choice := range l.choices {
+		if isCorrectAnswer(question.NormalizeAnswerText(choice), correctAnswers) {
+			return position
+		}
+	}
+	return -1
+}
+
+// summarizePositions aggregates accuracy by the presented position of the
+// correct answer and counts how often each position was chosen.
+func summarizePositions(results []QuestionResult) []PositionAccuracy {
+	maxPositions := 0
+	for _, result := range results {
+		if len(result.PresentedAnswers) > maxPositions {
+			maxPositions = len(result.PresentedAnswers)
+		}
+		if len(result.Answers) > maxPositions {
+			maxPositions = len(result.Answers)
+		}
+	}
+	if maxPositions == 0 {
+		return nil
+	}
+	stats := make([]PositionAccuracy, maxPositions)
+	for i := range stats {
+		stats[i].Position = i + 1
+	}
+	for _, result := range results {
+		if !result.scored() {
+			continue
+		}
+		if result.CorrectPosition > 0 && result.CorrectPosition <= maxPositions {
+			stat := &stats[result.CorrectPosition-1]
+			stat.Questions++
+			if result.Correct {
+				stat.Correct++
+			}
+		}
+		if result.AnswerPosition > 0 && result.AnswerPosition <= maxPositions {
+			stats[result.AnswerPosition-1].Chosen++
+		}
+	}
+	for i := range stats {
+		if stats[i].Questions > 0 {
+			stats[i].Accuracy = float64(stats[i].Correct) / float64(stats[i].Questions)
+		}
+	}
+	return stats
+}
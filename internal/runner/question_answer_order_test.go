@@ -0,0 +1,182 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"cogni/internal/agent"
+	"cogni/internal/question"
+	"cogni/internal/spec"
+	"cogni/internal/testutil"
+	"cogni/internal/tools"
+	"cogni/internal/vcs"
+)
+
+// TestBuildAnswerLayoutDeterministic verifies shuffles are reproducible from the seed.
+func TestBuildAnswerLayoutDeterministic(t *testing.T) {
+	item := question.Question{ID: "q1", Answers: []string{"a", "b", "c", "d", "e"}}
+	order := spec.TaskAnswerOrder{Shuffle: true, Seed: 42}
+	first := buildAnswerLayout(item, order, 3)
+	second := buildAnswerLayout(item, order, 3)
+	if strings.Join(first.choices, ",") != strings.Join(second.choices, ",") {
+		t.Fatalf("expected identical layouts, got %v and %v", first.choices, second.choices)
+	}
+	for presented, original := range first.order {
+		if first.choices[presented] != item.Answers[original] {
+			t.Fatalf("order mapping mismatch at %d: %v", presented, first.order)
+		}
+	}
+	unshuffled := buildAnswerLayout(item, spec.TaskAnswerOrder{}, 3)
+	if unshuffled.shuffled() {
+		t.Fatalf("expected spec order without shuffle, got %v", unshuffled.choices)
+	}
+}
+
+// TestAnswerLayoutResolveLabels verifies labeled answers map back to choices.
+func TestAnswerLayoutResolveLabels(t *testing.T) {
+	item := question.Question{Answers: []string{"red", "green", "blue"}}
+	layout := buildAnswerLayout(item, spec.TaskAnswerOrder{Labels: answerLabelsLetters}, 0)
+	cases := map[string]int{"B": 1, "(c)": 2, "a) red": 0, "blue": 2, "purple": -1}
+	for raw, want := range cases {
+		choice, position := layout.resolve(question.ParsedAnswer{Raw: raw, Normalized: question.NormalizeAnswerText(raw)})
+		if position != want {
+			t.Fatalf("answer %q: expected position %d, got %d", raw, want, position)
+		}
+		if want >= 0 && choice != item.Answers[want] {
+			t.Fatalf("answer %q: expected choice %q, got %q", raw, item.Answers[want], choice)
+		}
+	}
+	if answerLabel(26) != "AA" {
+		t.Fatalf("expected AA label, got %q", answerLabel(26))
+	}
+}
+
+// TestRunQuestionEvalShuffledLabels verifies labeled answers are scored and positions recorded.
+func TestRunQuestionEvalShuffledLabels(t *testing.T) {
+	repoRoot := t.TempDir()
+	specBody := `version: 1
+questions:
+  - id: q1
+    question: "Pick a color"
+    answers: ["red", "green", "blue", "yellow"]
+    correct_answers: ["blue"]
+`
+	if err := os.WriteFile(filepath.Join(repoRoot, "questions.yml"), []byte(specBody), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+	order := spec.TaskAnswerOrder{Shuffle: true, Seed: 7, Labels: answerLabelsLetters}
+	layout := buildAnswerLayout(question.Question{Answers: []string{"red", "green", "blue", "yellow"}}, order, 0)
+	correct := layout.correctPosition([]string{"blue"})
+	cfg := spec.Config{
+		Repo:         spec.RepoConfig{OutputDir: "./out"},
+		Agents:       []spec.AgentConfig{{ID: "agent-1", Type: "builtin", Provider: "openrouter", Model: "model"}},
+		DefaultAgent: "agent-1",
+		Tasks: []spec.TaskConfig{
+			{ID: "task-1", Type: "question_eval", Agent: "agent-1", QuestionsFile: "questions.yml", AnswerOrder: order},
+		},
+	}
+
+	ctx := testutil.Context(t, 0)
+	results, err := Run(ctx, cfg, RunParams{
+		RepoRoot: repoRoot,
+		Deps: RunDependencies{
+			ProviderFactory: func(_ spec.AgentConfig, _ string) (agent.Provider, error) {
+				return fakeProvider{message: "<answer>" + answerLabel(correct) + "</answer>"}, nil
+			},
+			ToolRunnerFactory: func(root string) (*tools.Runner, error) {
+				return tools.NewRunner(root)
+			},
+			RepoRootResolver: func(_ context.Context, root string) (string, error) {
+				return root, nil
+			},
+			RepoMetadataLoader: func(_ context.Context, root string) (vcs.Metadata, error) {
+				return vcs.Metadata{Name: filepath.Base(root), VCS: "git", Commit: "commit"}, nil
+			},
+			RunID: func() (string, error) { return "run-1", nil },
+			Now:   func() time.Time { return time.Now() },
+		},
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	eval := results.Tasks[0].QuestionEval
+	if eval.AnswerOrder == nil || eval.AnswerOrder.Seed != 7 || !eval.AnswerOrder.Shuffled {
+		t.Fatalf("expected recorded answer order, got %+v", eval.AnswerOrder)
+	}
+	result := eval.Questions[0]
+	if !result.Correct || result.ResolvedAnswer != "blue" {
+		t.Fatalf("expected labeled answer to resolve to blue, got %+v", result)
+	}
+	if result.AnswerPosition != correct+1 || result.CorrectPosition != correct+1 {
+		t.Fatalf("unexpected positions: %+v", result)
+	}
+	stat := eval.Summary.PositionAccuracy[correct]
+	if stat.Questions != 1 || stat.Correct != 1 || stat.Chosen != 1 {
+		t.Fatalf("unexpected position stats: %+v", eval.Summary.PositionAccuracy)
+	}
+}
+
+// TestRunQuestionEvalSeedsFromInjectedClock verifies unseeded shuffles use the run clock.
+func TestRunQuestionEvalSeedsFromInjectedClock(t *testing.T) {
+	repoRoot := t.TempDir()
+	specBody := `version: 1
+questions:
+  - id: q1
+    question: "Pick a color"
+    answers: ["red", "green", "blue"]
+    correct_answers: ["blue"]
+`
+	if err := os.WriteFile(filepath.Join(repoRoot, "questions.yml"), []byte(specBody), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+	clock := time.Unix(1700000000, 0)
+	run := func(order spec.TaskAnswerOrder) *QuestionEval {
+		cfg := spec.Config{
+			Repo:         spec.RepoConfig{OutputDir: "./out"},
+			Agents:       []spec.AgentConfig{{ID: "agent-1", Type: "builtin", Provider: "openrouter", Model: "model"}},
+			DefaultAgent: "agent-1",
+			Tasks: []spec.TaskConfig{
+				{ID: "task-1", Type: "question_eval", Agent: "agent-1", QuestionsFile: "questions.yml", AnswerOrder: order},
+			},
+		}
+		results, err := Run(testutil.Context(t, 0), cfg, RunParams{
+			RepoRoot: repoRoot,
+			Deps: RunDependencies{
+				ProviderFactory: func(_ spec.AgentConfig, _ string) (agent.Provider, error) {
+					return fakeProvider{message: "<answer>blue</answer>"}, nil
+				},
+				ToolRunnerFactory: func(root string) (*tools.Runner, error) {
+					return tools.NewRunner(root)
+				},
+				RepoRootResolver: func(_ context.Context, root string) (string, error) {
+					return root, nil
+				},
+				RepoMetadataLoader: func(_ context.Context, root string) (vcs.Metadata, error) {
+					return vcs.Metadata{Name: filepath.Base(root), VCS: "git", Commit: "commit"}, nil
+				},
+				RunID: func() (string, error) { return "run-1", nil },
+				Now:   func() time.Time { return clock },
+			},
+		})
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+		return results.Tasks[0].QuestionEval
+	}
+
+	shuffled := run(spec.TaskAnswerOrder{Shuffle: true})
+	if shuffled.AnswerOrder == nil || shuffled.AnswerOrder.Seed != clock.UnixNano() {
+		t.Fatalf("expected seed from injected clock, got %+v", shuffled.AnswerOrder)
+	}
+	if len(shuffled.Summary.PositionAccuracy) == 0 {
+		t.Fatalf("expected position accuracy for shuffled task")
+	}
+	plain := run(spec.TaskAnswerOrder{})
+	if plain.Summary.PositionAccuracy != nil {
+		t.Fatalf("expected no position accuracy without shuffle, got %+v", plain.Summary.PositionAccuracy)
+	}
+}
@@ -21,6 +21,7 @@ func runQuestionTask(
 	limiter ratelimiter.Limiter,
 	toolDefs []agent.ToolDefinition,
 	executor agent.ToolExecutor,
+	toolRunnerFactory ToolRunnerFactory,
 	providerFactory ProviderFactory,
 	tokenCounter agent.TokenCounter,
 	verbose bool,
@@ -28,10 +29,11 @@ func runQuestionTask(
 	verboseLogWriter io.Writer,
 	noColor bool,
 	observer RunObserver,
+	checkpoint *checkpointWriter,
 ) TaskResult {
-	result := TaskResult{TaskID: task.Task.ID, Type: task.Task.Type}
+	result := TaskResult{TaskID: task.Task.ID, AgentID: task.AgentID, Type: task.Task.Type}
 	questionsPath := resolveQuestionsFile(repoRoot, task.Task.QuestionsFile)
-	questionSpec, err := question.LoadSpec(questionsPath)
+	questionSpec, err := question.LoadSpecWithOptions(questionsPath, questionLoadOptionsAt(task.RepoRoot, repoRoot, task.Task))
 	if err != nil {
 		reason := "invalid_questions_file"
 		result.Status = "error"
@@ -39,6 +41,19 @@ func runQuestionTask(
 		return result
 	}
 
+	available := len(questionSpec.Questions)
+	questions, err := task.Filter.apply(questionSpec.Questions)
+	if err != nil {
+		reason := "invalid_selector"
+		result.Status = "error"
+		result.FailureReason = &reason
+		return result
+	}
+	if !task.Filter.active() {
+		available = 0
+	}
+	questionSpec.Questions = questions
+
 	if len(questionSpec.Questions) == 0 {
 		reason := "no_questions"
 		result.Status = "error"
@@ -54,20 +69,32 @@ func runQuestionTask(
 		return result
 	}
 
+	workspaces, workspaceErr := newQuestionWorkspaces(ctx, repoRoot, cfg.Repo.OutputDir, task.Task, toolRunnerFactory)
+	if workspaceErr != nil {
+		reason := "workspace_error"
+		result.Status = "error"
+		result.FailureReason = &reason
+		return result
+	}
+	defer workspaces.close()
+
+	answerOrder := resolveAnswerOrder(task.Task.AnswerOrder, task.AnswerSeed)
+	answerFormat := resolveAnswerFormat(task.Task.AnswerFormat)
 	jobObserver := newQuestionJobObserver(observer, task.Task.ID, questionSpec.Questions)
 	if jobObserver != nil {
 		jobObserver.EmitQueuedAll()
 	}
 
 	workers := ratelimit.ResolveTaskWorkers(cfg, task.Task)
-	scheduler := ratelimiter.NewSchedulerWithObserver(limiter, workers, jobObserver)
+	scheduler := ratelimiter.NewSchedulerWithContext(ctx, limiter, workers, jobObserver)
 	maxOutputTokens := ratelimit.MaxOutputTokens(cfg, task.Task)
 	verboseWriter, verboseLogWriter = wrapVerboseWriters(workers, verboseWriter, verboseLogWriter)
 	deps := questionJobDeps{
 		repoRoot:        repoRoot,
 		task:            task,
-		toolDefs:        toolDefs,
+		toolDefs:        taskToolDefinitions(task.Task, toolDefs),
 		executor:        executor,
+		workspaces:      workspaces,
 		providerFactory: providerFactory,
 		tokenCounter:    tokenCounter,
 		compaction:      compactionConfig,
@@ -76,56 +103,88 @@ func runQuestionTask(
 		verboseLog:      verboseLogWriter,
 		noColor:         noColor,
 		maxOutputTokens: maxOutputTokens,
+		retry:           ratelimit.RetryPolicy(cfg),
+		priority:        ratelimit.JobPriority(cfg),
+		tenant:          cfg.RateLimiter.Tenant,
 		questionTotal:   len(questionSpec.Questions),
+		answerOrder:     answerOrder,
+		answerFormat:    answerFormat,
+		parseRecovery:   task.Task.ParseRecovery,
 		observer:        jobObserver,
+		checkpoint:      checkpoint,
 	}
 
 	var (
 		questionResults []QuestionResult
-		correctCount    int
-		runtimeError    bool
-		budgetExceeded  bool
+		tally           questionTally
 	)
 	if workers <= 1 {
-		questionResults, correctCount, runtimeError, budgetExceeded = runQuestionJobsSequential(ctx, scheduler, questionSpec.Questions, deps)
+		questionResults, tally = runQuestionJobsSequential(scheduler, questionSpec.Questions, deps)
 	} else {
-		questionResults, correctCount, runtimeError, budgetExceeded = runQuestionJobsConcurrent(ctx, scheduler, questionSpec.Questions, deps)
+		questionResults, tally = runQuestionJobsConcurrent(scheduler, questionSpec.Questions, deps)
 	}
-	shutdownCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 2*time.Second)
 	defer cancel()
 	if err := scheduler.Shutdown(shutdownCtx); err != nil {
-		runtimeError = true
+		tally.runtimeError = true
 	}
 
 	total := len(questionResults)
-	accuracy := 0.0
-	if total > 0 {
-		accuracy = float64(correctCount) / float64(total)
+	summary := QuestionSummary{QuestionsTotal: total, QuestionsCorrect: tally.correct}
+	for _, questionResult := range questionResults {
+		switch {
+		case questionResult.Cancelled:
+			summary.QuestionsCancelled++
+		case questionResult.Skipped != "":
+			summary.QuestionsSkipped++
+		}
+	}
+	summary.QuestionsIncorrect = summary.QuestionsScored() - tally.correct
+	if scored := summary.QuestionsScored(); scored > 0 {
+		summary.Accuracy = float64(tally.correct) / float64(scored)
+	}
+	if answerOrder.Shuffle {
+		summary.PositionAccuracy = summarizePositions(questionResults)
 	}
 	result.QuestionEval = &QuestionEval{
-		QuestionsFile: task.Task.QuestionsFile,
-		Questions:     questionResults,
-		Summary: QuestionSummary{
-			QuestionsTotal:     total,
-			QuestionsCorrect:   correctCount,
-			QuestionsIncorrect: total - correctCount,
-			Accuracy:           accuracy,
-		},
+		QuestionsFile:      task.Task.QuestionsFile,
+		QuestionsAvailable: available,
+		AnswerOrder:        answerOrderResult(answerOrder),
+		AnswerFormat:       answerFormatResult(answerFormat),
+		Questions:          questionResults,
+		Summary:            summary,
 	}
 
-	if runtimeError {
+	if tally.cancelled {
+		reason := "cancelled"
+		result.Status = "cancelled"
+		result.FailureReason = &reason
+		return result
+	}
+	if tally.runtimeError {
 		reason := "runtime_error"
 		result.Status = "error"
 		result.FailureReason = &reason
 		return result
 	}
-	if budgetExceeded {
+	if tally.skipped > 0 {
+		// Questions skipped by an exhausted run or task budget leave the task
+		// unscored; it is skipped outright when none of them ran.
+		reason := tally.skipReason
+		result.Status = "fail"
+		if tally.skipped == total {
+			result.Status = "skipped"
+		}
+		result.FailureReason = &reason
+		return result
+	}
+	if tally.budgetExceeded {
 		reason := "budget_exceeded"
 		result.Status = "fail"
 		result.FailureReason = &reason
 		return result
 	}
-	if correctCount == total {
+	if tally.correct == total {
 		result.Status = "pass"
 		return result
 	}
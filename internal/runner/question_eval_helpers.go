@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"cogni/internal/question"
+	"cogni/internal/spec"
 )
 
 // resolveQuestionsFile resolves the questions file path against the repo root.
@@ -15,6 +16,26 @@ func resolveQuestionsFile(repoRoot, questionsFile string) string {
 	return filepath.Join(repoRoot, questionsFile)
 }
 
+// QuestionLoadOptions builds question spec load options for a task, layering
+// task question_vars over the built-in repository variables.
+func QuestionLoadOptions(repoRoot string, task spec.TaskConfig) question.LoadOptions {
+	return questionLoadOptionsAt(repoRoot, repoRoot, task)
+}
+
+// questionLoadOptionsAt builds load options for a task running in checkout,
+// a workspace of the repository at repoRoot. An empty repoRoot means the
+// task runs in the repository itself.
+func questionLoadOptionsAt(repoRoot, checkout string, task spec.TaskConfig) question.LoadOptions {
+	if strings.TrimSpace(repoRoot) == "" {
+		repoRoot = checkout
+	}
+	vars := question.BuiltinVarsAt(repoRoot, checkout)
+	for key, value := range task.QuestionVars {
+		vars[key] = value
+	}
+	return question.LoadOptions{Vars: vars}
+}
+
 // isCorrectAnswer checks whether a normalized answer matches any correct answer.
 func isCorrectAnswer(normalized string, correctAnswers []string) bool {
 	for _, candidate := range correctAnswers {
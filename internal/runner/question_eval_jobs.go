@@ -10,6 +10,7 @@ import (
 	"cogni/internal/agent"
 	"cogni/internal/agent/call"
 	"cogni/internal/question"
+	"cogni/internal/spec"
 	"cogni/pkg/ratelimiter"
 )
 
@@ -19,6 +20,7 @@ type questionJobDeps struct {
 	task            taskRun
 	toolDefs        []agent.ToolDefinition
 	executor        agent.ToolExecutor
+	workspaces      *questionWorkspaces
 	providerFactory ProviderFactory
 	tokenCounter    agent.TokenCounter
 	compaction      agent.CompactionConfig
@@ -27,8 +29,15 @@ type questionJobDeps struct {
 	verboseLog      io.Writer
 	noColor         bool
 	maxOutputTokens uint64
+	retry           ratelimiter.RetryPolicy
+	priority        ratelimiter.Priority
+	tenant          string
 	questionTotal   int
+	answerOrder     spec.TaskAnswerOrder
+	answerFormat    string
+	parseRecovery   bool
 	observer        *questionJobObserver
+	checkpoint      *checkpointWriter
 }
 
 // questionJobResult captures the outcome of a question evaluation job.
@@ -40,28 +49,84 @@ type questionJobResult struct {
 	budgetExceeded bool
 	actualTokens   uint64
 	runErr         error
+	resumed        bool
+	cancelled      bool
+	// retrying marks a failed attempt the scheduler will run again.
+	retrying bool
+}
+
+// questionTally folds job outcomes into the inputs of a task verdict.
+type questionTally struct {
+	correct        int
+	runtimeError   bool
+	budgetExceeded bool
+	cancelled      bool
+	skipped        int
+	skipReason     string
+}
+
+// add records one job outcome.
+func (t *questionTally) add(jobResult questionJobResult) {
+	if jobResult.correct {
+		t.correct++
+	}
+	if jobResult.runtimeError {
+		t.runtimeError = true
+	}
+	if jobResult.budgetExceeded {
+		t.budgetExceeded = true
+	}
+	if jobResult.cancelled {
+		t.cancelled = true
+	} else if reason := jobResult.result.Skipped; reason != "" {
+		t.skipped++
+		t.skipReason = reason
+	}
+}
+
+// finished reports whether the job produced a result worth checkpointing;
+// cancelled and skipped questions are left for a resume to run.
+func (r questionJobResult) finished() bool {
+	return !r.cancelled && r.result.Skipped == ""
 }
 
 // runQuestionJobsSequential executes questions one at a time through the scheduler.
-func runQuestionJobsSequential(ctx context.Context, sched *ratelimiter.Scheduler, questions []question.Question, deps questionJobDeps) ([]QuestionResult, int, bool, bool) {
+func runQuestionJobsSequential(sched *ratelimiter.Scheduler, questions []question.Question, deps questionJobDeps) ([]QuestionResult, questionTally) {
 	results := make([]QuestionResult, 0, len(questions))
-	correctCount := 0
-	runtimeError := false
-	budgetExceeded := false
+	var tally questionTally
 	for index, item := range questions {
-		promptText := buildQuestionPrompt(item)
+		if jobResult, ok := resumedQuestionJob(deps, index, item); ok {
+			results = append(results, jobResult.result)
+			tally.add(jobResult)
+			continue
+		}
+		layout := buildAnswerLayout(item, deps.answerOrder, index)
+		promptText := buildQuestionPrompt(item, layout, deps.answerFormat)
 		resultCh := make(chan questionJobResult, 1)
+		retriedTokens := 0
 		job := ratelimiter.Job{
 			JobID:           fmt.Sprintf("%s-%d", deps.task.Task.ID, index+1),
 			Provider:        deps.task.Agent.Provider,
 			Model:           deps.task.Model,
 			Prompt:          promptText,
 			MaxOutputTokens: deps.maxOutputTokens,
-			Execute: func(_ context.Context) (uint64, error) {
-				jobResult := executeQuestionJob(ctx, deps, index, item, promptText)
-				resultCh <- jobResult
+			Admit:           deps.task.Budget.admit,
+			Retry:           deps.retry,
+			TenantID:        deps.tenant,
+			Priority:        deps.priority,
+			Execute: func(ctx context.Context) (uint64, error) {
+				jobResult := executeQuestionJob(ctx, deps, index, item, layout, promptText)
+				deps.task.Budget.charge(jobResult.result.TokensTotal)
+				if final, ok := finalQuestionAttempt(ctx, jobResult, &retriedTokens); ok {
+					resultCh <- final
+				}
 				return jobResult.actualTokens, jobResult.runErr
 			},
+			OnCancel: func(err error) {
+				skipped := skippedQuestionJob(deps, index, item, layout, err)
+				skipped.result.TokensTotal += retriedTokens
+				resultCh <- skipped
+			},
 		}
 		if deps.observer != nil {
 			deps.observer.RegisterJob(job.JobID, index)
@@ -69,40 +134,53 @@ func runQuestionJobsSequential(ctx context.Context, sched *ratelimiter.Scheduler
 		}
 		sched.Submit(job)
 		jobResult := <-resultCh
-		results = append(results, jobResult.result)
-		if jobResult.correct {
-			correctCount++
-		}
-		if jobResult.runtimeError {
-			runtimeError = true
-		}
-		if jobResult.budgetExceeded {
-			budgetExceeded = true
+		if jobResult.finished() {
+			deps.checkpoint.question(deps.task, index, jobResult.result)
 		}
+		results = append(results, jobResult.result)
+		tally.add(jobResult)
 	}
-	return results, correctCount, runtimeError, budgetExceeded
+	return results, tally
 }
 
 // runQuestionJobsConcurrent executes question jobs concurrently and preserves ordering.
-func runQuestionJobsConcurrent(ctx context.Context, sched *ratelimiter.Scheduler, questions []question.Question, deps questionJobDeps) ([]QuestionResult, int, bool, bool) {
+func runQuestionJobsConcurrent(sched *ratelimiter.Scheduler, questions []question.Question, deps questionJobDeps) ([]QuestionResult, questionTally) {
 	results := make([]QuestionResult, len(questions))
 	resultCh := make(chan questionJobResult, len(questions))
 
 	for index, item := range questions {
+		if jobResult, ok := resumedQuestionJob(deps, index, item); ok {
+			resultCh <- jobResult
+			continue
+		}
 		idx := index
 		questionItem := item
-		promptText := buildQuestionPrompt(questionItem)
+		layout := buildAnswerLayout(questionItem, deps.answerOrder, idx)
+		promptText := buildQuestionPrompt(questionItem, layout, deps.answerFormat)
+		retriedTokens := 0
 		job := ratelimiter.Job{
 			JobID:           fmt.Sprintf("%s-%d", deps.task.Task.ID, idx+1),
 			Provider:        deps.task.Agent.Provider,
 			Model:           deps.task.Model,
 			Prompt:          promptText,
 			MaxOutputTokens: deps.maxOutputTokens,
-			Execute: func(_ context.Context) (uint64, error) {
-				jobResult := executeQuestionJob(ctx, deps, idx, questionItem, promptText)
-				resultCh <- jobResult
+			Admit:           deps.task.Budget.admit,
+			Retry:           deps.retry,
+			TenantID:        deps.tenant,
+			Priority:        deps.priority,
+			Execute: func(ctx context.Context) (uint64, error) {
+				jobResult := executeQuestionJob(ctx, deps, idx, questionItem, layout, promptText)
+				deps.task.Budget.charge(jobResult.result.TokensTotal)
+				if final, ok := finalQuestionAttempt(ctx, jobResult, &retriedTokens); ok {
+					resultCh <- final
+				}
 				return jobResult.actualTokens, jobResult.runErr
 			},
+			OnCancel: func(err error) {
+				skipped := skippedQuestionJob(deps, idx, questionItem, layout, err)
+				skipped.result.TokensTotal += retriedTokens
+				resultCh <- skipped
+			},
 		}
 		if deps.observer != nil {
 			deps.observer.RegisterJob(job.JobID, idx)
@@ -111,58 +189,73 @@ func runQuestionJobsConcurrent(ctx context.Context, sched *ratelimiter.Scheduler
 		sched.Submit(job)
 	}
 
-	correctCount := 0
-	runtimeError := false
-	budgetExceeded := false
+	var tally questionTally
 	for i := 0; i < len(questions); i++ {
 		jobResult := <-resultCh
-		results[jobResult.index] = jobResult.result
-		if jobResult.correct {
-			correctCount++
-		}
-		if jobResult.runtimeError {
-			runtimeError = true
-		}
-		if jobResult.budgetExceeded {
-			budgetExceeded = true
+		if !jobResult.resumed && jobResult.finished() {
+			deps.checkpoint.question(deps.task, jobResult.index, jobResult.result)
 		}
+		results[jobResult.index] = jobResult.result
+		tally.add(jobResult)
 	}
-	return results, correctCount, runtimeError, budgetExceeded
+	return results, tally
+}
+
+// finalQuestionAttempt stamps the attempt count on a job result and reports
+// whether it is final; an attempt the scheduler will retry is not delivered.
+// The tokens of retried attempts accumulate in retriedTokens and are added
+// to the final result, since the budget was charged for them too.
+func finalQuestionAttempt(ctx context.Context, jobResult questionJobResult, retriedTokens *int) (questionJobResult, bool) {
+	if jobResult.retrying {
+		*retriedTokens += jobResult.result.TokensTotal
+		return jobResult, false
+	}
+	jobResult.result.TokensTotal += *retriedTokens
+	if attempt := ratelimiter.AttemptFromContext(ctx); attempt > 1 {
+		jobResult.result.Attempts = attempt
+	}
+	return jobResult, true
 }
 
 // executeQuestionJob runs a single question evaluation and returns its outcome.
-func executeQuestionJob(ctx context.Context, deps questionJobDeps, index int, item question.Question, promptText string) questionJobResult {
+func executeQuestionJob(ctx context.Context, deps questionJobDeps, index int, item question.Question, layout answerLayout, promptText string) questionJobResult {
 	logVerbose(deps.verbose, deps.verboseWriter, deps.verboseLog, deps.noColor, styleTask,
 		fmt.Sprintf("Task %s question %d/%d agent=%s model=%s", deps.task.Task.ID, index+1, deps.questionTotal, deps.task.AgentID, deps.task.Model))
 	if deps.observer != nil {
 		deps.observer.Emit(index, questionEventOptions{EventType: QuestionRunning})
 	}
+	if err := ctx.Err(); err != nil {
+		return cancelledQuestionJob(deps, index, buildQuestionResult(item, layout, call.RunMetrics{}, err), err)
+	}
 	provider, err := deps.providerFactory(deps.task.Agent, deps.task.Model)
 	if err != nil {
-		result := buildQuestionResult(item, call.RunMetrics{}, err)
+		result := buildQuestionResult(item, layout, call.RunMetrics{}, err)
 		if deps.observer != nil {
 			deps.observer.Emit(index, questionEventOptions{EventType: QuestionRuntimeError, Error: err.Error()})
 		}
 		return questionJobResult{index: index, result: result, runtimeError: true, actualTokens: 0, runErr: err}
 	}
-	session := newSession(deps.task, deps.repoRoot, deps.toolDefs, deps.verbose)
-	toolExecutor := deps.executor
+	workDir, executor, release, err := deps.workspaces.acquire(ctx, deps.repoRoot, deps.executor)
+	if err != nil {
+		result := buildQuestionResult(item, layout, call.RunMetrics{}, err)
+		if ctx.Err() != nil {
+			return cancelledQuestionJob(deps, index, result, err)
+		}
+		if deps.observer != nil {
+			deps.observer.Emit(index, questionEventOptions{EventType: QuestionRuntimeError, Error: err.Error()})
+		}
+		return questionJobResult{index: index, result: result, runtimeError: true, actualTokens: 0, runErr: err}
+	}
+	defer release()
+	session := newSession(deps.task, workDir, answerToolDefinitions(deps.answerFormat, deps.toolDefs), deps.verbose)
+	if deps.answerFormat == answerFormatJSON {
+		session.Ctx.OutputSchema = answerOutputSchema
+	}
+	toolExecutor := executor
 	if deps.observer != nil {
-		toolExecutor = newObservedToolExecutor(deps.observer, index, deps.executor)
+		toolExecutor = newObservedToolExecutor(deps.observer, index, executor)
 	}
-	callResult, runErr := call.RunCall(ctx, session, provider, toolExecutor, promptText, call.RunOptions{
-		TokenCounter: deps.tokenCounter,
-		Compaction:   deps.compaction,
-		Limits: call.RunLimits{
-			MaxSteps:   limitOrDefault(deps.task.Task.Budget.MaxSteps, deps.task.Agent.MaxSteps),
-			MaxSeconds: time.Duration(deps.task.Task.Budget.MaxSeconds) * time.Second,
-			MaxTokens:  deps.task.Task.Budget.MaxTokens,
-		},
-		Verbose:          deps.verbose,
-		VerboseWriter:    deps.verboseWriter,
-		VerboseLogWriter: deps.verboseLog,
-		NoColor:          deps.noColor,
-	}, nil)
+	callResult, runErr := call.RunCall(ctx, session, provider, toolExecutor, promptText, questionRunOptions(deps), nil)
 	metrics := callResult.Metrics
 	if runErr != nil {
 		logVerbose(deps.verbose, deps.verboseWriter, deps.verboseLog, deps.noColor, styleError,
@@ -174,13 +267,20 @@ func executeQuestionJob(ctx context.Context, deps questionJobDeps, index int, it
 	if deps.observer != nil {
 		deps.observer.Emit(index, questionEventOptions{EventType: QuestionParsing})
 	}
-	result := buildQuestionResult(item, metrics, runErr)
+	result := buildQuestionResult(item, layout, metrics, runErr)
 	jobResult := questionJobResult{
 		index:        index,
 		result:       result,
 		actualTokens: uint64(metrics.Tokens),
 		runErr:       runErr,
 	}
+	if runErr != nil && ratelimiter.WillRetry(ctx, runErr) {
+		jobResult.retrying = true
+		return jobResult
+	}
+	if runErr != nil && ctx.Err() != nil {
+		return cancelledQuestionJob(deps, index, jobResult.result, runErr)
+	}
 	if runErr != nil {
 		if errors.Is(runErr, call.ErrBudgetExceeded) {
 			jobResult.budgetExceeded = true
@@ -206,7 +306,14 @@ func executeQuestionJob(ctx context.Context, deps questionJobDeps, index int, it
 		return jobResult
 	}
 
-	answer, parseErr := question.ParseAnswerFromOutput(callResult.Output)
+	answer, parseErr := parseAgentAnswer(deps.answerFormat, callResult)
+	if parseErr != nil && deps.parseRecovery {
+		var recovery *ParseRecovery
+		answer, recovery, metrics, parseErr = recoverParseError(ctx, deps, index, session, provider, toolExecutor, parseErr, metrics)
+		jobResult.result.ParseRecovery = recovery
+		jobResult.result.applyMetrics(metrics)
+		jobResult.actualTokens = uint64(metrics.Tokens)
+	}
 	if parseErr != nil {
 		jobResult.result.ParseError = parseErr.Error()
 		if deps.observer != nil {
@@ -220,7 +327,14 @@ func executeQuestionJob(ctx context.Context, deps questionJobDeps, index int, it
 		return jobResult
 	}
 	jobResult.result.AgentAnswer = answer.Raw
-	if isCorrectAnswer(answer.Normalized, item.CorrectAnswers) {
+	resolved, position := layout.resolve(answer)
+	if position >= 0 {
+		jobResult.result.AnswerPosition = position + 1
+	}
+	if layout.labeled() && position >= 0 {
+		jobResult.result.ResolvedAnswer = resolved
+	}
+	if isCorrectAnswer(question.NormalizeAnswerText(resolved), item.CorrectAnswers) {
 		jobResult.result.Correct = true
 		jobResult.correct = true
 		if deps.observer != nil {
@@ -242,23 +356,83 @@ func executeQuestionJob(ctx context.Context, deps questionJobDeps, index int, it
 	return jobResult
 }
 
+// cancelledQuestionJob records a question interrupted by run cancellation.
+// It is neither a runtime error nor checkpointed, so a resume reruns it.
+func cancelledQuestionJob(deps questionJobDeps, index int, result QuestionResult, err error) questionJobResult {
+	result.Cancelled = true
+	if deps.observer != nil {
+		deps.observer.Emit(index, questionEventOptions{
+			EventType: QuestionCancelled,
+			Error:     err.Error(),
+			Tokens:    result.TokensTotal,
+		})
+	}
+	return questionJobResult{index: index, result: result, actualTokens: uint64(result.TokensTotal), runErr: err, cancelled: true}
+}
+
+// skippedQuestionJob records a question that never ran because the run was
+// cancelled or a budget was exhausted, with the reason.
+func skippedQuestionJob(deps questionJobDeps, index int, item question.Question, layout answerLayout, err error) questionJobResult {
+	reason := skipReason(err)
+	result := buildQuestionResult(item, layout, call.RunMetrics{}, nil)
+	result.Skipped = reason
+	if deps.observer != nil {
+		deps.observer.Emit(index, questionEventOptions{EventType: QuestionSkipped, Error: reason})
+	}
+	var exhausted budgetError
+	return questionJobResult{index: index, result: result, cancelled: !errors.As(err, &exhausted)}
+}
+
+// questionRunOptions builds the call options for a question run.
+func questionRunOptions(deps questionJobDeps) call.RunOptions {
+	return call.RunOptions{
+		TokenCounter: deps.tokenCounter,
+		Compaction:   deps.compaction,
+		Limits: call.RunLimits{
+			MaxSteps:   limitOrDefault(deps.task.Task.Budget.MaxSteps, deps.task.Agent.MaxSteps),
+			MaxSeconds: time.Duration(deps.task.Task.Budget.MaxSeconds) * time.Second,
+			MaxTokens:  deps.task.Task.Budget.MaxTokens,
+		},
+		TerminalTool:     terminalToolFor(deps.answerFormat),
+		Verbose:          deps.verbose,
+		VerboseWriter:    deps.verboseWriter,
+		VerboseLogWriter: deps.verboseLog,
+		NoColor:          deps.noColor,
+	}
+}
+
 // buildQuestionResult assembles a QuestionResult from metrics and errors.
-func buildQuestionResult(item question.Question, metrics call.RunMetrics, runErr error) QuestionResult {
+func buildQuestionResult(item question.Question, layout answerLayout, metrics call.RunMetrics, runErr error) QuestionResult {
 	result := QuestionResult{
-		ID:                item.ID,
-		Question:          item.Prompt,
-		Answers:           item.Answers,
-		CorrectAnswers:    item.CorrectAnswers,
-		Correct:           false,
-		TokensTotal:       metrics.Tokens,
-		WallTimeSeconds:   metrics.WallTime.Seconds(),
-		AgentSteps:        metrics.Steps,
-		ToolCalls:         metrics.ToolCalls,
-		Compactions:       metrics.Compactions,
-		LastSummaryTokens: metrics.LastSummaryTokens,
+		ID:             item.ID,
+		Question:       item.Prompt,
+		Answers:        item.Answers,
+		CorrectAnswers: item.CorrectAnswers,
+		Correct:        false,
+	}
+	result.applyMetrics(metrics)
+	if layout.shuffled() || layout.labeled() {
+		result.PresentedAnswers = layout.choices
+	}
+	if position := layout.correctPosition(item.CorrectAnswers); position >= 0 {
+		result.CorrectPosition = position + 1
 	}
 	if runErr != nil {
 		result.RunError = runErr.Error()
+		result.FailureReason = QuestionStatusRuntimeError
+		if errors.Is(runErr, call.ErrBudgetExceeded) {
+			result.FailureReason = QuestionStatusBudgetExceeded
+		}
 	}
 	return result
 }
+
+// applyMetrics copies effort metrics into the result.
+func (r *QuestionResult) applyMetrics(metrics call.RunMetrics) {
+	r.TokensTotal = metrics.Tokens
+	r.WallTimeSeconds = metrics.WallTime.Seconds()
+	r.AgentSteps = metrics.Steps
+	r.ToolCalls = metrics.ToolCalls
+	r.Compactions = metrics.Compactions
+	r.LastSummaryTokens = metrics.LastSummaryTokens
+}
@@ -13,6 +13,7 @@ import (
 type questionEventOptions struct {
 	EventType    QuestionEventType
 	RetryAfterMs int
+	Attempt      int
 	ToolName     string
 	ToolDuration time.Duration
 	ToolError    string
@@ -84,6 +85,7 @@ func (o *questionJobObserver) Emit(index int, opts questionEventOptions) {
 		QuestionText:  item.Prompt,
 		Type:          opts.EventType,
 		RetryAfterMs:  opts.RetryAfterMs,
+		Attempt:       opts.Attempt,
 		ToolName:      opts.ToolName,
 		ToolDuration:  opts.ToolDuration,
 		ToolError:     opts.ToolError,
@@ -123,6 +125,19 @@ func (o *questionJobObserver) OnReserveError(job ratelimiter.Job, err error) {
 	})
 }
 
+// OnExecuteRetry reports a failed attempt the scheduler will retry.
+func (o *questionJobObserver) OnExecuteRetry(job ratelimiter.Job, err error, delay time.Duration) {
+	opts := questionEventOptions{
+		EventType:    QuestionWaitingRetry,
+		RetryAfterMs: int(delay.Milliseconds()),
+		Attempt:      job.Attempt,
+	}
+	if err != nil {
+		opts.Error = err.Error()
+	}
+	o.emitByJob(job.JobID, opts)
+}
+
 // emitByJob resolves a job id to its question index and emits an event.
 func (o *questionJobObserver) emitByJob(jobID string, opts questionEventOptions) {
 	if o == nil {
@@ -0,0 +1,114 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cogni/internal/agent"
+	"cogni/internal/agent/call"
+	"cogni/internal/question"
+)
+
+// parseRecoveryMaxSteps bounds the follow-up turn; restating an answer needs no exploration.
+const parseRecoveryMaxSteps = 2
+
+// recoverParseError sends one follow-up user message to the same session asking
+// the agent to restate its final answer, and returns the re-parsed answer with
+// the combined metrics of both turns.
+func recoverParseError(
+	ctx context.Context,
+	deps questionJobDeps,
+	index int,
+	session *agent.Session,
+	provider agent.Provider,
+	executor agent.ToolExecutor,
+	parseErr error,
+	metrics call.RunMetrics,
+) (question.ParsedAnswer, *ParseRecovery, call.RunMetrics, error) {
+	recovery := &ParseRecovery{OriginalError: parseErr.Error(), OriginalOutput: lastAssistantText(session)}
+	if deps.observer != nil {
+		deps.observer.Emit(index, questionEventOptions{
+			EventType: QuestionRecovering,
+			Error:     parseErr.Error(),
+			Tokens:    metrics.Tokens,
+			WallTime:  metrics.WallTime,
+		})
+	}
+	logVerbose(deps.verbose, deps.verboseWriter, deps.verboseLog, deps.noColor, styleTask,
+		fmt.Sprintf("Task %s question %d parse error=%v; asking agent to restate answer", deps.task.Task.ID, index+1, parseErr))
+
+	opts := questionRunOptions(deps)
+	opts.Limits.MaxSteps = parseRecoveryMaxSteps
+	callResult, runErr := call.RunCall(ctx, session, provider, executor, buildRecoveryPrompt(deps.answerFormat, parseErr), opts, nil)
+	combined := combineMetrics(metrics, callResult.Metrics)
+	recovery.Tokens = combined.Tokens - metrics.Tokens
+	if deps.observer != nil {
+		deps.observer.Emit(index, questionEventOptions{EventType: QuestionParsing})
+	}
+	if runErr != nil {
+		recovery.Error = runErr.Error()
+		return question.ParsedAnswer{}, recovery, combined, parseErr
+	}
+	answer, err := parseAgentAnswer(deps.answerFormat, callResult)
+	if err != nil {
+		recovery.Error = err.Error()
+		return question.ParsedAnswer{}, recovery, combined, err
+	}
+	recovery.Recovered = true
+	return answer, recovery, combined, nil
+}
+
+// buildRecoveryPrompt asks the agent to restate its answer in the task's format.
+func buildRecoveryPrompt(format string, parseErr error) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Your previous response could not be parsed (%v).\n", parseErr))
+	switch format {
+	case answerFormatTool:
+		builder.WriteString("Call the submit_answer tool with your final answer now.\n")
+	case answerFormatJSON:
+		builder.WriteString("Restate your final answer as a JSON object of the form {\"answer\": \"...\"} and nothing else.\n")
+	default:
+		builder.WriteString("Restate your final answer now. End your response with <answer>...</answer> and add no text after </answer>.\n")
+	}
+	return builder.String()
+}
+
+// combineMetrics merges the metrics of a follow-up turn into the first turn's
+// metrics. Tokens come from the follow-up because it counts the whole history.
+func combineMetrics(first, second call.RunMetrics) call.RunMetrics {
+	combined := first
+	combined.Steps += second.Steps
+	combined.WallTime += second.WallTime
+	combined.Compactions += second.Compactions
+	if second.Tokens > combined.Tokens {
+		combined.Tokens = second.Tokens
+	}
+	if second.LastSummaryTokens > 0 {
+		combined.LastSummaryTokens = second.LastSummaryTokens
+	}
+	if len(second.ToolCalls) > 0 {
+		combined.ToolCalls = make(map[string]int, len(first.ToolCalls)+len(second.ToolCalls))
+		for name, count := range first.ToolCalls {
+			combined.ToolCalls[name] += count
+		}
+		for name, count := range second.ToolCalls {
+			combined.ToolCalls[name] += count
+		}
+	}
+	return combined
+}
+
+// lastAssistantText returns the most recent assistant message in the session.
+func lastAssistantText(session *agent.Session) string {
+	for i := len(session.History) - 1; i >= 0; i-- {
+		item := session.History[i]
+		if item.Role != "assistant" {
+			continue
+		}
+		if text, ok := item.Content.(agent.HistoryText); ok {
+			return text.Text
+		}
+	}
+	return ""
+}
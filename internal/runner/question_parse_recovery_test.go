@@ -0,0 +1,109 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cogni/internal/agent"
+	"cogni/internal/spec"
+	"cogni/internal/testutil"
+	"cogni/internal/tools"
+	"cogni/internal/vcs"
+)
+
+// runParseRecoveryTask runs a single-question task with parse recovery enabled.
+func runParseRecoveryTask(t *testing.T, provider sequenceProvider, observer RunObserver) QuestionResult {
+	t.Helper()
+	repoRoot := t.TempDir()
+	specBody := `version: 1
+questions:
+  - id: q1
+    question: "What is 2+2?"
+    answers: ["4", "5"]
+    correct_answers: ["4"]
+`
+	if err := os.WriteFile(filepath.Join(repoRoot, "questions.yml"), []byte(specBody), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+	cfg := spec.Config{
+		Repo:         spec.RepoConfig{OutputDir: "./out"},
+		Agents:       []spec.AgentConfig{{ID: "agent-1", Type: "builtin", Provider: "openrouter", Model: "model"}},
+		DefaultAgent: "agent-1",
+		Tasks: []spec.TaskConfig{
+			{ID: "task-1", Type: "question_eval", Agent: "agent-1", QuestionsFile: "questions.yml", ParseRecovery: true},
+		},
+	}
+	ctx := testutil.Context(t, 0)
+	results, err := Run(ctx, cfg, RunParams{
+		RepoRoot: repoRoot,
+		Observer: observer,
+		Deps: RunDependencies{
+			ProviderFactory: func(_ spec.AgentConfig, _ string) (agent.Provider, error) {
+				return provider, nil
+			},
+			ToolRunnerFactory: func(root string) (*tools.Runner, error) {
+				return tools.NewRunner(root)
+			},
+			RepoRootResolver: func(_ context.Context, root string) (string, error) {
+				return root, nil
+			},
+			RepoMetadataLoader: func(_ context.Context, root string) (vcs.Metadata, error) {
+				return vcs.Metadata{Name: filepath.Base(root), VCS: "git", Commit: "commit"}, nil
+			},
+			RunID: func() (string, error) { return "run-1", nil },
+			Now:   time.Now,
+		},
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	return results.Tasks[0].QuestionEval.Questions[0]
+}
+
+// TestParseRecoveryRestatesAnswer verifies a follow-up turn recovers a parse error
+// and both the original error and the recovery are recorded.
+func TestParseRecoveryRestatesAnswer(t *testing.T) {
+	calls := 0
+	provider := sequenceProvider{responses: []string{"<answer>4</answer> because of the code", "<answer>4</answer>"}, index: &calls}
+	observer := &recordingObserver{}
+	result := runParseRecoveryTask(t, provider, observer)
+	if calls != 2 {
+		t.Fatalf("expected 2 model calls, got %d", calls)
+	}
+	if !result.Correct || result.ParseError != "" {
+		t.Fatalf("expected recovered answer to be scored, got %+v", result)
+	}
+	recovery := result.ParseRecovery
+	if recovery == nil || !recovery.Recovered || recovery.OriginalError == "" || recovery.OriginalOutput == "" {
+		t.Fatalf("expected recorded recovery, got %+v", recovery)
+	}
+	if result.AgentSteps != 2 {
+		t.Fatalf("expected steps from both turns, got %d", result.AgentSteps)
+	}
+	assertSequence(t, observer.eventsForQuestion(0), []QuestionEventType{
+		QuestionQueued,
+		QuestionScheduled,
+		QuestionReserving,
+		QuestionRunning,
+		QuestionParsing,
+		QuestionRecovering,
+		QuestionParsing,
+		QuestionCorrect,
+	})
+}
+
+// TestParseRecoveryFailureKeepsParseError verifies unrecovered answers stay parse errors.
+func TestParseRecoveryFailureKeepsParseError(t *testing.T) {
+	calls := 0
+	provider := sequenceProvider{responses: []string{"no tags", "still no tags"}, index: &calls}
+	result := runParseRecoveryTask(t, provider, nil)
+	if result.Correct || result.ParseError == "" {
+		t.Fatalf("expected parse error, got %+v", result)
+	}
+	if result.ParseRecovery == nil || result.ParseRecovery.Recovered || result.ParseRecovery.Error == "" {
+		t.Fatalf("expected failed recovery to be recorded, got %+v", result.ParseRecovery)
+	}
+}
@@ -7,17 +7,38 @@ import (
 )
 
 // buildQuestionPrompt constructs the prompt for a single question evaluation.
-func buildQuestionPrompt(item question.Question) string {
+func buildQuestionPrompt(item question.Question, layout answerLayout, format string) string {
 	var builder strings.Builder
 	builder.WriteString("Answer the question about the repository.\n")
-	builder.WriteString("You may include reasoning, but the final output must end with:\n")
-	builder.WriteString("<answer>...</answer>\n")
-	builder.WriteString("Do not add any text after </answer>.\n\n")
-	builder.WriteString("Question:\n")
+	switch format {
+	case answerFormatTool:
+		builder.WriteString("When you have decided, call the submit_answer tool with your answer. Calling it ends your turn.\n")
+		if layout.labeled() {
+			builder.WriteString("Submit the label of the chosen answer (for example A).\n")
+		}
+	case answerFormatJSON:
+		builder.WriteString("Respond with a JSON object of the form {\"answer\": \"...\"}.\n")
+		if layout.labeled() {
+			builder.WriteString("Use the label of the chosen answer as the answer value (for example A).\n")
+		}
+	default:
+		builder.WriteString("You may include reasoning, but the final output must end with:\n")
+		builder.WriteString("<answer>...</answer>\n")
+		builder.WriteString("Do not add any text after </answer>.\n")
+		if layout.labeled() {
+			builder.WriteString("Answer with the label of the chosen answer (for example <answer>A</answer>).\n")
+		}
+	}
+	builder.WriteString("\nQuestion:\n")
 	builder.WriteString(item.Prompt)
 	builder.WriteString("\n\nAnswer choices:\n")
-	for _, answer := range item.Answers {
-		builder.WriteString("- ")
+	for position, answer := range layout.choices {
+		if layout.labeled() {
+			builder.WriteString(layout.labels[position])
+			builder.WriteString(") ")
+		} else {
+			builder.WriteString("- ")
+		}
 		builder.WriteString(answer)
 		builder.WriteString("\n")
 	}
@@ -0,0 +1,169 @@
+package runner
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cogni/internal/agent"
+	"cogni/internal/spec"
+	"cogni/internal/testutil"
+	"cogni/internal/tools"
+	"cogni/internal/vcs"
+)
+
+// overloadedProvider fails its first calls with a provider status error.
+type overloadedProvider struct {
+	failures int
+	status   int
+	calls    *int
+}
+
+// Stream fails until failures calls have been made, then answers.
+func (p overloadedProvider) Stream(_ context.Context, _ agent.Prompt) (agent.Stream, error) {
+	*p.calls++
+	if *p.calls <= p.failures {
+		return nil, &agent.ProviderError{Provider: "openrouter", StatusCode: p.status, Body: "overloaded"}
+	}
+	return &fakeStream{events: []agent.StreamEvent{{Type: agent.StreamEventMessage, Message: "<answer>4</answer>"}}}, nil
+}
+
+// runRetryTask runs a single-question task against provider with two attempts allowed.
+func runRetryTask(t *testing.T, provider agent.Provider, observer RunObserver) TaskResult {
+	t.Helper()
+	repoRoot := t.TempDir()
+	specBody := `version: 1
+questions:
+  - id: q1
+    question: "What is 2+2?"
+    answers: ["4", "5"]
+    correct_answers: ["4"]
+`
+	if err := os.WriteFile(filepath.Join(repoRoot, "questions.yml"), []byte(specBody), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+	cfg := spec.Config{
+		Repo:         spec.RepoConfig{OutputDir: "./out"},
+		Agents:       []spec.AgentConfig{{ID: "agent-1", Type: "builtin", Provider: "openrouter", Model: "model"}},
+		DefaultAgent: "agent-1",
+		RateLimiter:  spec.RateLimiterConfig{Retry: spec.RetryConfig{MaxAttempts: 2, BaseDelayMs: 1, MaxDelayMs: 5}},
+		Tasks: []spec.TaskConfig{
+			{ID: "task-1", Type: "question_eval", Agent: "agent-1", QuestionsFile: "questions.yml"},
+		},
+	}
+	results, err := Run(testutil.Context(t, 5*time.Second), cfg, RunParams{
+		RepoRoot: repoRoot,
+		Observer: observer,
+		Deps: RunDependencies{
+			ProviderFactory: func(_ spec.AgentConfig, _ string) (agent.Provider, error) {
+				return provider, nil
+			},
+			ToolRunnerFactory: func(root string) (*tools.Runner, error) {
+				return tools.NewRunner(root)
+			},
+			RepoRootResolver: func(_ context.Context, root string) (string, error) {
+				return root, nil
+			},
+			RepoMetadataLoader: func(_ context.Context, root string) (vcs.Metadata, error) {
+				return vcs.Metadata{Name: filepath.Base(root), VCS: "git", Commit: "commit"}, nil
+			},
+			RunID: func() (string, error) { return "run-1", nil },
+			Now:   time.Now,
+		},
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	return results.Tasks[0]
+}
+
+// TestQuestionRetriesTransientProviderError verifies a 503 is retried and the attempts recorded.
+func TestQuestionRetriesTransientProviderError(t *testing.T) {
+	calls := 0
+	observer := &recordingObserver{}
+	task := runRetryTask(t, overloadedProvider{failures: 1, status: http.StatusServiceUnavailable, calls: &calls}, observer)
+	result := task.QuestionEval.Questions[0]
+	if calls != 2 || !result.Correct || result.RunError != "" || result.Attempts != 2 {
+		t.Fatalf("expected a correct second attempt, got calls=%d result=%+v", calls, result)
+	}
+	if task.Status != "pass" {
+		t.Fatalf("expected task to pass, got %s", task.Status)
+	}
+	assertSequence(t, observer.eventsForQuestion(0), []QuestionEventType{
+		QuestionQueued,
+		QuestionScheduled,
+		QuestionReserving,
+		QuestionRunning,
+		QuestionWaitingRetry,
+		QuestionReserving,
+		QuestionRunning,
+		QuestionParsing,
+		QuestionCorrect,
+	})
+}
+
+// TestQuestionRetryGivesUpAfterMaxAttempts verifies exhausted retries become a runtime error.
+func TestQuestionRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	calls := 0
+	task := runRetryTask(t, overloadedProvider{failures: 5, status: http.StatusTooManyRequests, calls: &calls}, nil)
+	result := task.QuestionEval.Questions[0]
+	if calls != 2 || result.RunError == "" || result.Attempts != 2 {
+		t.Fatalf("expected runtime error after 2 attempts, got calls=%d result=%+v", calls, result)
+	}
+}
+
+// TestQuestionDoesNotRetryClientError verifies non-transient provider errors fail immediately.
+func TestQuestionDoesNotRetryClientError(t *testing.T) {
+	calls := 0
+	task := runRetryTask(t, overloadedProvider{failures: 5, status: http.StatusBadRequest, calls: &calls}, nil)
+	result := task.QuestionEval.Questions[0]
+	if calls != 1 || result.RunError == "" || result.Attempts != 0 {
+		t.Fatalf("expected one failed attempt, got calls=%d result=%+v", calls, result)
+	}
+}
+
+// failingStream yields a partial message, then fails with a provider error.
+type failingStream struct {
+	sent bool
+	err  error
+}
+
+// Recv returns one message event, then the configured error.
+func (s *failingStream) Recv() (agent.StreamEvent, error) {
+	if !s.sent {
+		s.sent = true
+		return agent.StreamEvent{Type: agent.StreamEventMessage, Message: "partial answer that was cut off"}, nil
+	}
+	return agent.StreamEvent{}, s.err
+}
+
+// midStreamFailureProvider fails its first stream after some output, then answers.
+type midStreamFailureProvider struct {
+	calls *int
+}
+
+// Stream returns a failing stream on the first call and an answer afterwards.
+func (p midStreamFailureProvider) Stream(_ context.Context, _ agent.Prompt) (agent.Stream, error) {
+	*p.calls++
+	if *p.calls == 1 {
+		return &failingStream{err: &agent.ProviderError{Provider: "openrouter", StatusCode: http.StatusServiceUnavailable, Body: "overloaded"}}, nil
+	}
+	return &fakeStream{events: []agent.StreamEvent{{Type: agent.StreamEventMessage, Message: "<answer>4</answer>"}}}, nil
+}
+
+// TestQuestionRetryCountsFailedAttemptTokens verifies tokens spent on a retried attempt reach the final result.
+func TestQuestionRetryCountsFailedAttemptTokens(t *testing.T) {
+	calls := 0
+	single := runRetryTask(t, overloadedProvider{calls: &calls}, nil).QuestionEval.Questions[0]
+	calls = 0
+	retried := runRetryTask(t, midStreamFailureProvider{calls: &calls}, nil).QuestionEval.Questions[0]
+	if calls != 2 || !retried.Correct || retried.Attempts != 2 {
+		t.Fatalf("expected a correct second attempt, got calls=%d result=%+v", calls, retried)
+	}
+	if retried.TokensTotal <= single.TokensTotal {
+		t.Fatalf("expected the failed attempt's tokens added to %d, got %d", single.TokensTotal, retried.TokensTotal)
+	}
+}
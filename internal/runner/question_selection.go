@@ -0,0 +1,153 @@
+package runner
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"cogni/internal/question"
+)
+
+// QuestionSampling trims every question task to a subset for quick smoke runs.
+// Sample draws that many questions at random using Seed; Limit then keeps the
+// first N of whatever remains. Zero disables either step.
+type QuestionSampling struct {
+	Limit  int   `json:"limit,omitempty"`
+	Sample int   `json:"sample,omitempty"`
+	Seed   int64 `json:"seed,omitempty"`
+}
+
+// active reports whether sampling changes the question set.
+func (s QuestionSampling) active() bool {
+	return s.Limit > 0 || s.Sample > 0
+}
+
+// Validate rejects negative counts.
+func (s QuestionSampling) Validate() error {
+	if s.Limit < 0 {
+		return fmt.Errorf("limit must be >= 0")
+	}
+	if s.Sample < 0 {
+		return fmt.Errorf("sample must be >= 0")
+	}
+	return nil
+}
+
+// RunSelection records how a partial run narrowed its questions, so it is not
+// mistaken for a full run when compared.
+type RunSelection struct {
+	Selectors []string `json:"selectors,omitempty"`
+	QuestionSampling
+}
+
+// newRunSelection describes the selection, or returns nil for a full run.
+func newRunSelection(selectors []TaskSelector, sampling QuestionSampling) *RunSelection {
+	partial := sampling.active()
+	for _, selector := range selectors {
+		partial = partial || selector.selectsQuestions()
+	}
+	if !partial {
+		return nil
+	}
+	selection := &RunSelection{QuestionSampling: sampling}
+	for _, selector := range selectors {
+		selection.Selectors = append(selection.Selectors, selector.String())
+	}
+	return selection
+}
+
+// questionFilter narrows the questions of a single task run.
+type questionFilter struct {
+	ids      []string
+	tags     map[string]struct{}
+	sampling QuestionSampling
+}
+
+// newQuestionFilter merges the selectors for one task. A selector without a
+// question filter selects every question, which wins over narrower ones.
+func newQuestionFilter(selectors []TaskSelector, taskID string, sampling QuestionSampling) questionFilter {
+	filter := questionFilter{sampling: sampling}
+	var ids []string
+	tags := map[string]struct{}{}
+	for _, selector := range selectors {
+		if selector.TaskID != taskID {
+			continue
+		}
+		if !selector.selectsQuestions() {
+			return filter
+		}
+		ids = append(ids, selector.QuestionIDs...)
+		for _, tag := range selector.Tags {
+			tags[tag] = struct{}{}
+		}
+	}
+	filter.ids = ids
+	if len(tags) > 0 {
+		filter.tags = tags
+	}
+	return filter
+}
+
+// active reports whether the filter can drop questions.
+func (f questionFilter) active() bool {
+	return len(f.ids) > 0 || len(f.tags) > 0 || f.sampling.active()
+}
+
+// apply returns the selected questions in their original order. Unknown
+// question IDs are an error so a typo does not silently run nothing.
+func (f questionFilter) apply(questions []question.Question) ([]question.Question, error) {
+	if !f.active() {
+		return questions, nil
+	}
+	selected := questions
+	if len(f.ids) > 0 || len(f.tags) > 0 {
+		wanted := make(map[string]struct{}, len(f.ids))
+		for _, id := range f.ids {
+			wanted[id] = struct{}{}
+		}
+		selected = nil
+		found := map[string]struct{}{}
+		for _, item := range questions {
+			_, byID := wanted[item.ID]
+			if byID {
+				found[item.ID] = struct{}{}
+			}
+			if byID || f.hasTag(item) {
+				selected = append(selected, item)
+			}
+		}
+		var missing []string
+		for _, id := range f.ids {
+			if _, ok := found[id]; !ok {
+				missing = append(missing, id)
+			}
+		}
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("unknown question ids: %s", strings.Join(missing, ", "))
+		}
+	}
+	if f.sampling.Sample > 0 && f.sampling.Sample < len(selected) {
+		picks := rand.New(rand.NewSource(f.sampling.Seed)).Perm(len(selected))[:f.sampling.Sample]
+		sort.Ints(picks)
+		sampled := make([]question.Question, 0, len(picks))
+		for _, index := range picks {
+			sampled = append(sampled, selected[index])
+		}
+		selected = sampled
+	}
+	if f.sampling.Limit > 0 && f.sampling.Limit < len(selected) {
+		selected = selected[:f.sampling.Limit]
+	}
+	return selected, nil
+}
+
+// hasTag reports whether the question carries any of the selected tags.
+func (f questionFilter) hasTag(item question.Question) bool {
+	for _, tag := range item.Tags {
+		if _, ok := f.tags[tag]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package runner
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"cogni/internal/question"
+)
+
+// TestQuestionFilterApply verifies id, tag, sample and limit selection.
+func TestQuestionFilterApply(t *testing.T) {
+	questions := []question.Question{
+		{ID: "q1", Tags: []string{"concurrency"}},
+		{ID: "q2"},
+		{ID: "q3", Tags: []string{"io", "concurrency"}},
+		{ID: "q4"},
+		{ID: "q5"},
+	}
+	ids := func(items []question.Question) string {
+		var out []string
+		for _, item := range items {
+			out = append(out, item.ID)
+		}
+		return strings.Join(out, ",")
+	}
+	cases := []struct {
+		name      string
+		selectors []TaskSelector
+		sampling  QuestionSampling
+		want      string
+	}{
+		{name: "all", selectors: []TaskSelector{{TaskID: "core"}}, want: "q1,q2,q3,q4,q5"},
+		{name: "ids", selectors: []TaskSelector{{TaskID: "core", QuestionIDs: []string{"q4", "q2"}}}, want: "q2,q4"},
+		{name: "tags", selectors: []TaskSelector{{TaskID: "core", Tags: []string{"concurrency"}}}, want: "q1,q3"},
+		{name: "union", selectors: []TaskSelector{{TaskID: "core", Tags: []string{"io"}}, {TaskID: "core", QuestionIDs: []string{"q5"}}}, want: "q3,q5"},
+		{name: "plain wins", selectors: []TaskSelector{{TaskID: "core", QuestionIDs: []string{"q5"}}, {TaskID: "core"}}, want: "q1,q2,q3,q4,q5"},
+		{name: "other task", selectors: []TaskSelector{{TaskID: "other", QuestionIDs: []string{"q5"}}}, want: "q1,q2,q3,q4,q5"},
+		{name: "limit", sampling: QuestionSampling{Limit: 2}, want: "q1,q2"},
+	}
+	for _, tc := range cases {
+		got, err := newQuestionFilter(tc.selectors, "core", tc.sampling).apply(questions)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if ids(got) != tc.want {
+			t.Fatalf("%s: expected %s, got %s", tc.name, tc.want, ids(got))
+		}
+	}
+
+	sampling := QuestionSampling{Sample: 3, Seed: 7}
+	first, _ := newQuestionFilter(nil, "core", sampling).apply(questions)
+	second, _ := newQuestionFilter(nil, "core", sampling).apply(questions)
+	if len(first) != 3 || ids(first) != ids(second) {
+		t.Fatalf("expected a stable sample of 3, got %s and %s", ids(first), ids(second))
+	}
+
+	if _, err := newQuestionFilter([]TaskSelector{{TaskID: "core", QuestionIDs: []string{"q9"}}}, "core", QuestionSampling{}).apply(questions); err == nil {
+		t.Fatalf("expected error for unknown question id")
+	}
+}
+
+// TestRunRecordsQuestionSelection verifies a partial run evaluates only the
+// selected questions and says so in its results.
+func TestRunRecordsQuestionSelection(t *testing.T) {
+	repoRoot, cfg := writeFlakyRepo(t)
+	var mu sync.Mutex
+	var calls []string
+	results, _ := runFlaky(t, repoRoot, cfg, flakyProvider{mu: &mu, calls: &calls}, "run-1", RunParams{
+		Selectors: []TaskSelector{{TaskID: "task-1", QuestionIDs: []string{"q2"}}},
+	})
+	if len(calls) != 1 || !strings.Contains(calls[0], "3+1") {
+		t.Fatalf("expected only q2 to be evaluated, got %q", calls)
+	}
+	eval := results.Tasks[0].QuestionEval
+	if eval.Summary.QuestionsTotal != 1 || eval.QuestionsAvailable != 3 {
+		t.Fatalf("expected 1 of 3 questions, got %d of %d", eval.Summary.QuestionsTotal, eval.QuestionsAvailable)
+	}
+	if results.Selection == nil || len(results.Selection.Selectors) != 1 || results.Selection.Selectors[0] != "task-1#q2" {
+		t.Fatalf("expected selection to be recorded, got %+v", results.Selection)
+	}
+
+	full, _ := runFlaky(t, repoRoot, cfg, flakyProvider{mu: &mu, calls: &calls}, "run-2", RunParams{})
+	if full.Selection != nil || full.Tasks[0].QuestionEval.QuestionsAvailable != 0 {
+		t.Fatalf("expected a full run to record no selection, got %+v", full.Selection)
+	}
+}
@@ -0,0 +1,128 @@
+package runner
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Question statuses used to select questions for a rerun.
+const (
+	QuestionStatusCorrect        = "correct"
+	QuestionStatusIncorrect      = "incorrect"
+	QuestionStatusParseError     = "parse_error"
+	QuestionStatusRuntimeError   = "runtime_error"
+	QuestionStatusBudgetExceeded = "budget_exceeded"
+	QuestionStatusCancelled      = "cancelled"
+	QuestionStatusSkipped        = "skipped"
+)
+
+// DefaultRerunStatuses selects questions that failed or did not produce a
+// usable answer.
+var DefaultRerunStatuses = []string{QuestionStatusRuntimeError, QuestionStatusBudgetExceeded, QuestionStatusParseError, QuestionStatusIncorrect, QuestionStatusCancelled, QuestionStatusSkipped}
+
+// QuestionStatus classifies a question result for rerun selection.
+func QuestionStatus(result QuestionResult) string {
+	switch {
+	case result.Skipped != "":
+		return QuestionStatusSkipped
+	case result.Cancelled:
+		return QuestionStatusCancelled
+	case result.FailureReason == QuestionStatusBudgetExceeded:
+		return QuestionStatusBudgetExceeded
+	case result.RunError != "":
+		return QuestionStatusRuntimeError
+	case result.ParseError != "":
+		return QuestionStatusParseError
+	case result.Correct:
+		return QuestionStatusCorrect
+	default:
+		return QuestionStatusIncorrect
+	}
+}
+
+// RerunCheckpoint prepares a rerun of prior: questions whose status is in
+// statuses are evaluated again and all others are carried over into the new
+// run. It also returns how many questions will be rerun. Only question_eval
+// tasks take part; one that recorded no question results cannot be split and
+// is reported as an error.
+func RerunCheckpoint(prior Results, statuses []string) (*Checkpoint, int, error) {
+	selected := map[string]struct{}{}
+	for _, status := range statuses {
+		switch status = strings.TrimSpace(status); status {
+		case QuestionStatusCorrect, QuestionStatusIncorrect, QuestionStatusParseError, QuestionStatusRuntimeError, QuestionStatusBudgetExceeded, QuestionStatusCancelled, QuestionStatusSkipped:
+			selected[status] = struct{}{}
+		case "":
+		default:
+			return nil, 0, fmt.Errorf("unknown question status %q", status)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, 0, fmt.Errorf("at least one question status is required")
+	}
+	checkpoint := &Checkpoint{
+		RunID:     prior.RunID,
+		Commit:    prior.Repo.Commit,
+		carryFrom: prior.RunID,
+		questions: map[string]map[int]QuestionResult{},
+	}
+	// Question indices refer to the prior run's selection, so the rerun must
+	// narrow each task the same way.
+	var priorSelectors []TaskSelector
+	if prior.Selection != nil {
+		checkpoint.Sampling = prior.Selection.QuestionSampling
+		parsed, err := ParseSelectors(prior.Selection.Selectors)
+		if err != nil {
+			return nil, 0, fmt.Errorf("run %s selection: %w", prior.RunID, err)
+		}
+		priorSelectors = parsed
+	}
+	rerun := 0
+	for _, task := range prior.Tasks {
+		if task.QuestionEval == nil {
+			if task.Type == "question_eval" {
+				return nil, 0, fmt.Errorf("run %s task %s has no question results (%s); run it again in full", prior.RunID, checkpointKey(task.TaskID, task.AgentID), taskStatusDetail(task))
+			}
+			continue
+		}
+		checkpoint.Selectors = append(checkpoint.Selectors, rerunSelectors(priorSelectors, task)...)
+		carried := map[int]QuestionResult{}
+		for index, result := range task.QuestionEval.Questions {
+			if _, ok := selected[QuestionStatus(result)]; ok {
+				rerun++
+				continue
+			}
+			carried[index] = result
+		}
+		checkpoint.questions[checkpointKey(task.TaskID, task.AgentID)] = carried
+	}
+	if len(checkpoint.Selectors) == 0 {
+		return nil, 0, fmt.Errorf("run %s has no question_eval tasks", prior.RunID)
+	}
+	return checkpoint, rerun, nil
+}
+
+// rerunSelectors selects a prior task run with the question filters it was run with.
+func rerunSelectors(prior []TaskSelector, task TaskResult) []TaskSelector {
+	var selectors []TaskSelector
+	for _, selector := range prior {
+		if selector.TaskID != task.TaskID {
+			continue
+		}
+		if !selector.selectsQuestions() {
+			return []TaskSelector{{TaskID: task.TaskID, AgentID: task.AgentID}}
+		}
+		selectors = append(selectors, TaskSelector{TaskID: task.TaskID, AgentID: task.AgentID, QuestionIDs: selector.QuestionIDs, Tags: selector.Tags})
+	}
+	if len(selectors) == 0 {
+		return []TaskSelector{{TaskID: task.TaskID, AgentID: task.AgentID}}
+	}
+	return selectors
+}
+
+// taskStatusDetail describes why a task recorded no question results.
+func taskStatusDetail(task TaskResult) string {
+	if task.FailureReason != nil {
+		return task.Status + ": " + *task.FailureReason
+	}
+	return task.Status
+}
@@ -0,0 +1,84 @@
+package runner
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestQuestionStatus verifies question results are classified for rerun selection.
+func TestQuestionStatus(t *testing.T) {
+	cases := map[string]QuestionResult{
+		QuestionStatusCorrect:        {Correct: true},
+		QuestionStatusIncorrect:      {},
+		QuestionStatusParseError:     {ParseError: "missing <answer>"},
+		QuestionStatusRuntimeError:   {RunError: "provider unavailable: budget_exceeded", FailureReason: QuestionStatusRuntimeError},
+		QuestionStatusBudgetExceeded: {RunError: "step limit", FailureReason: QuestionStatusBudgetExceeded},
+		QuestionStatusSkipped:        {Skipped: "budget_exceeded"},
+		QuestionStatusCancelled:      {Cancelled: true, RunError: "context canceled"},
+	}
+	for want, result := range cases {
+		if got := QuestionStatus(result); got != want {
+			t.Fatalf("expected %s, got %s", want, got)
+		}
+	}
+}
+
+// TestRerunFailedQuestions verifies only selected questions are evaluated and
+// the new run records which results were carried over.
+func TestRerunFailedQuestions(t *testing.T) {
+	repoRoot, cfg := writeFlakyRepo(t)
+	var mu sync.Mutex
+	var firstCalls []string
+	first, _ := runFlaky(t, repoRoot, cfg, flakyProvider{failOn: "3+1", mu: &mu, calls: &firstCalls}, "run-1", RunParams{})
+
+	checkpoint, rerun, err := RerunCheckpoint(first, DefaultRerunStatuses)
+	if err != nil {
+		t.Fatalf("rerun checkpoint: %v", err)
+	}
+	if rerun != 1 {
+		t.Fatalf("expected one question selected, got %d", rerun)
+	}
+
+	var rerunCalls []string
+	second, _ := runFlaky(t, repoRoot, cfg, flakyProvider{mu: &mu, calls: &rerunCalls}, "run-2", RunParams{Rerun: checkpoint})
+	if len(rerunCalls) != 1 || !strings.Contains(rerunCalls[0], "3+1") {
+		t.Fatalf("expected only q2 to be rerun, got %q", rerunCalls)
+	}
+	if second.RunID != "run-2" || second.RerunOf != "run-1" {
+		t.Fatalf("expected new run-2 rerunning run-1, got %s/%s", second.RunID, second.RerunOf)
+	}
+	questions := second.Tasks[0].QuestionEval.Questions
+	if questions[0].CarriedFrom != "run-1" || questions[1].CarriedFrom != "" || questions[2].CarriedFrom != "run-1" {
+		t.Fatalf("unexpected provenance: %q %q %q", questions[0].CarriedFrom, questions[1].CarriedFrom, questions[2].CarriedFrom)
+	}
+	if second.Tasks[0].Status != "pass" || second.Summary.QuestionsCorrect != 3 {
+		t.Fatalf("expected merged run to pass, got %+v", second.Summary)
+	}
+
+	if _, _, err := RerunCheckpoint(first, []string{"flaky"}); err == nil {
+		t.Fatalf("expected unknown status error")
+	}
+}
+
+// TestRerunCheckpointRejectsTaskWithoutQuestions verifies a question task that
+// recorded no results is reported instead of silently left out of the rerun.
+func TestRerunCheckpointRejectsTaskWithoutQuestions(t *testing.T) {
+	reason := "invalid_questions_file"
+	prior := Results{
+		RunID: "run-1",
+		Tasks: []TaskResult{
+			{TaskID: "ok", AgentID: "a", Type: "question_eval", Status: "pass", QuestionEval: &QuestionEval{Questions: []QuestionResult{{Correct: true}}}},
+			{TaskID: "broken", AgentID: "a", Type: "question_eval", Status: "error", FailureReason: &reason},
+			{TaskID: "change", AgentID: "a", Type: "code_change", Status: "pass"},
+		},
+	}
+	_, _, err := RerunCheckpoint(prior, DefaultRerunStatuses)
+	if err == nil || !strings.Contains(err.Error(), "broken@a") || !strings.Contains(err.Error(), reason) {
+		t.Fatalf("expected error naming broken@a, got %v", err)
+	}
+	prior.Tasks = append(prior.Tasks[:1], prior.Tasks[2])
+	if _, _, err := RerunCheckpoint(prior, DefaultRerunStatuses); err != nil {
+		t.Fatalf("expected code_change task to be ignored, got %v", err)
+	}
+}
@@ -4,13 +4,19 @@ import "time"
 
 // Results captures the output of a cogni run.
 type Results struct {
-	RunID      string       `json:"run_id"`
-	Repo       RepoMetadata `json:"repo"`
-	Agents     []AgentInfo  `json:"agents"`
-	StartedAt  time.Time    `json:"started_at"`
-	FinishedAt time.Time    `json:"finished_at"`
-	Tasks      []TaskResult `json:"tasks"`
-	Summary    RunSummary   `json:"summary"`
+	RunID      string        `json:"run_id"`
+	Repo       RepoMetadata  `json:"repo"`
+	Agents     []AgentInfo   `json:"agents"`
+	StartedAt  time.Time     `json:"started_at"`
+	FinishedAt time.Time     `json:"finished_at"`
+	Tasks      []TaskResult  `json:"tasks"`
+	Summary    RunSummary    `json:"summary"`
+	RerunOf    string        `json:"rerun_of,omitempty"`
+	Selection  *RunSelection `json:"selection,omitempty"`
+	// Incomplete marks a run that was cancelled before all work finished.
+	Incomplete bool `json:"incomplete,omitempty"`
+	// Leaderboard ranks agents when a matrix run evaluated several of them.
+	Leaderboard []AgentStanding `json:"leaderboard,omitempty"`
 }
 
 // RepoMetadata describes repository state at run time.
@@ -35,11 +41,16 @@ type AgentInfo struct {
 
 // TaskResult records outcomes for a task.
 type TaskResult struct {
-	TaskID        string        `json:"task_id"`
-	Type          string        `json:"type"`
-	Status        string        `json:"status"`
-	FailureReason *string       `json:"failure_reason"`
-	QuestionEval  *QuestionEval `json:"question_eval,omitempty"`
+	TaskID        string            `json:"task_id"`
+	AgentID       string            `json:"agent_id,omitempty"`
+	Model         string            `json:"model,omitempty"`
+	Type          string            `json:"type"`
+	Status        string            `json:"status"`
+	FailureReason *string           `json:"failure_reason"`
+	QuestionEval  *QuestionEval     `json:"question_eval,omitempty"`
+	CodeChange    *CodeChangeResult `json:"code_change,omitempty"`
+	// WallTimeSeconds is the task's elapsed time, including queueing for the limiter.
+	WallTimeSeconds float64 `json:"wall_time_seconds,omitempty"`
 }
 
 // RunSummary aggregates run-level metrics.
@@ -52,5 +63,7 @@ type RunSummary struct {
 	QuestionsTotal     int     `json:"questions_total,omitempty"`
 	QuestionsCorrect   int     `json:"questions_correct,omitempty"`
 	QuestionsIncorrect int     `json:"questions_incorrect,omitempty"`
+	QuestionsSkipped   int     `json:"questions_skipped,omitempty"`
+	QuestionsCancelled int     `json:"questions_cancelled,omitempty"`
 	QuestionAccuracy   float64 `json:"question_accuracy,omitempty"`
 }
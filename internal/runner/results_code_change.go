@@ -0,0 +1,36 @@
+package runner
+
+// CodeChangeResult records the outcome of a code_change task.
+type CodeChangeResult struct {
+	Diff            string               `json:"-"`
+	DiffPath        string               `json:"diff_path,omitempty"`
+	FilesChanged    []string             `json:"files_changed"`
+	AgentError      string               `json:"agent_error,omitempty"`
+	TokensTotal     int                  `json:"tokens_total,omitempty"`
+	WallTimeSeconds float64              `json:"wall_time_seconds,omitempty"`
+	AgentSteps      int                  `json:"agent_steps,omitempty"`
+	ToolCalls       map[string]int       `json:"tool_calls,omitempty"`
+	Compactions     int                  `json:"compactions,omitempty"`
+	Verification    []VerificationResult `json:"verification"`
+}
+
+// VerificationResult records a single verification command run.
+type VerificationResult struct {
+	Command         string  `json:"command"`
+	ExitCode        int     `json:"exit_code"`
+	Passed          bool    `json:"passed"`
+	DurationSeconds float64 `json:"duration_seconds"`
+	Output          string  `json:"-"`
+	OutputPath      string  `json:"output_path,omitempty"`
+}
+
+// VerificationPassed counts the verification commands that succeeded.
+func (result CodeChangeResult) VerificationPassed() int {
+	passed := 0
+	for _, verification := range result.Verification {
+		if verification.Passed {
+			passed++
+		}
+	}
+	return passed
+}
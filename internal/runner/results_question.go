@@ -2,9 +2,21 @@ package runner
 
 // QuestionEval contains per-question evaluation results.
 type QuestionEval struct {
-	QuestionsFile string           `json:"questions_file"`
-	Questions     []QuestionResult `json:"questions"`
-	Summary       QuestionSummary  `json:"summary"`
+	QuestionsFile string `json:"questions_file"`
+	// QuestionsAvailable is the size of the question bank when selectors or
+	// sampling evaluated only part of it.
+	QuestionsAvailable int              `json:"questions_available,omitempty"`
+	AnswerOrder        *AnswerOrder     `json:"answer_order,omitempty"`
+	AnswerFormat       string           `json:"answer_format,omitempty"`
+	Questions          []QuestionResult `json:"questions"`
+	Summary            QuestionSummary  `json:"summary"`
+}
+
+// AnswerOrder records how answer choices were ordered and labeled for a task.
+type AnswerOrder struct {
+	Shuffled bool   `json:"shuffled"`
+	Seed     int64  `json:"seed,omitempty"`
+	Labels   string `json:"labels,omitempty"`
 }
 
 // QuestionResult records evaluation results for a single question.
@@ -13,22 +25,70 @@ type QuestionResult struct {
 	Question          string         `json:"question"`
 	Answers           []string       `json:"answers,omitempty"`
 	CorrectAnswers    []string       `json:"correct_answers,omitempty"`
+	PresentedAnswers  []string       `json:"presented_answers,omitempty"`
+	CorrectPosition   int            `json:"correct_position,omitempty"`
 	AgentAnswer       string         `json:"agent_answer,omitempty"`
+	ResolvedAnswer    string         `json:"resolved_answer,omitempty"`
+	AnswerPosition    int            `json:"answer_position,omitempty"`
 	Correct           bool           `json:"correct"`
 	ParseError        string         `json:"parse_error,omitempty"`
+	ParseRecovery     *ParseRecovery `json:"parse_recovery,omitempty"`
 	RunError          string         `json:"run_error,omitempty"`
+	FailureReason     string         `json:"failure_reason,omitempty"`
 	TokensTotal       int            `json:"tokens_total,omitempty"`
 	WallTimeSeconds   float64        `json:"wall_time_seconds,omitempty"`
 	AgentSteps        int            `json:"agent_steps,omitempty"`
 	ToolCalls         map[string]int `json:"tool_calls,omitempty"`
 	Compactions       int            `json:"compactions,omitempty"`
 	LastSummaryTokens int            `json:"last_summary_tokens,omitempty"`
+	CarriedFrom       string         `json:"carried_from,omitempty"`
+	// Attempts counts provider attempts when the scheduler retried the question.
+	Attempts int `json:"attempts,omitempty"`
+	// Cancelled marks a question interrupted mid-run; Skipped gives the reason
+	// a question never ran.
+	Cancelled bool   `json:"cancelled,omitempty"`
+	Skipped   string `json:"skipped,omitempty"`
+}
+
+// ParseRecovery records a follow-up turn asking the agent to restate an unparsable answer.
+type ParseRecovery struct {
+	OriginalError  string `json:"original_error"`
+	OriginalOutput string `json:"original_output,omitempty"`
+	Recovered      bool   `json:"recovered"`
+	Error          string `json:"error,omitempty"`
+	Tokens         int    `json:"tokens,omitempty"`
 }
 
 // QuestionSummary aggregates accuracy metrics for a question evaluation.
+// QuestionsTotal counts every question; skipped and cancelled ones are not
+// scored, so they are left out of QuestionsIncorrect and Accuracy.
 type QuestionSummary struct {
-	QuestionsTotal     int     `json:"questions_total"`
-	QuestionsCorrect   int     `json:"questions_correct"`
-	QuestionsIncorrect int     `json:"questions_incorrect"`
-	Accuracy           float64 `json:"accuracy"`
+	QuestionsTotal     int                `json:"questions_total"`
+	QuestionsCorrect   int                `json:"questions_correct"`
+	QuestionsIncorrect int                `json:"questions_incorrect"`
+	QuestionsSkipped   int                `json:"questions_skipped,omitempty"`
+	QuestionsCancelled int                `json:"questions_cancelled,omitempty"`
+	Accuracy           float64            `json:"accuracy"`
+	PositionAccuracy   []PositionAccuracy `json:"position_accuracy,omitempty"`
+}
+
+// QuestionsScored counts the questions that count toward Accuracy.
+func (s QuestionSummary) QuestionsScored() int {
+	return s.QuestionsTotal - s.QuestionsSkipped - s.QuestionsCancelled
+}
+
+// scored reports whether the question ran to an outcome that counts toward accuracy.
+func (r QuestionResult) scored() bool {
+	return !r.Cancelled && r.Skipped == ""
+}
+
+// PositionAccuracy aggregates outcomes by presented answer position (1-based).
+// Questions counts questions whose correct answer was shown at the position;
+// Chosen counts how often the agent picked the position.
+type PositionAccuracy struct {
+	Position  int     `json:"position"`
+	Questions int     `json:"questions"`
+	Correct   int     `json:"correct"`
+	Accuracy  float64 `json:"accuracy"`
+	Chosen    int     `json:"chosen"`
 }
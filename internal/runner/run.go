@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -23,17 +24,31 @@ func Run(ctx context.Context, cfg spec.Config, params RunParams) (Results, error
 	if err != nil {
 		return Results{}, err
 	}
+	// workRoot is the checkout agents see; it differs from repoRoot for --rev runs.
+	workRoot := repoRoot
+	if rev := strings.TrimSpace(params.Rev); rev != "" {
+		checkout, cleanup, err := checkoutRevision(ctx, repoRoot, rev)
+		if err != nil {
+			return Results{}, fmt.Errorf("checkout revision %q: %w", rev, err)
+		}
+		defer cleanup()
+		workRoot = checkout
+		cfg = pinTaskPaths(cfg, repoRoot)
+	}
 	metadataLoader := params.Deps.RepoMetadataLoader
 	if metadataLoader == nil {
 		metadataLoader = loadRepoMetadata
 	}
-	repoMeta, err := metadataLoader(ctx, repoRoot)
+	repoMeta, err := metadataLoader(ctx, workRoot)
 	if err != nil {
 		return Results{}, err
 	}
+	if workRoot != repoRoot {
+		repoMeta.Name = filepath.Base(repoRoot)
+	}
 
 	setupRunner := params.Deps.SetupRunner
-	if err := runSetupCommands(ctx, repoRoot, cfg.Repo.SetupCommands, setupRunner); err != nil {
+	if err := runSetupCommands(ctx, workRoot, cfg.Repo.SetupCommands, setupRunner); err != nil {
 		return Results{}, err
 	}
 
@@ -41,6 +56,10 @@ func Run(ctx context.Context, cfg spec.Config, params RunParams) (Results, error
 	if err != nil {
 		return Results{}, err
 	}
+	params, runID, rerunOf, err := applyPriorRun(params, repoMeta.Commit, runID)
+	if err != nil {
+		return Results{}, err
+	}
 	observer := params.Observer
 	if observer != nil {
 		observer.OnRunStart(runID, repoMeta.Name)
@@ -50,8 +69,36 @@ func Run(ctx context.Context, cfg spec.Config, params RunParams) (Results, error
 		now = time.Now
 	}
 	startedAt := now()
+	if params.Resume != nil && !params.Resume.StartedAt.IsZero() {
+		startedAt = params.Resume.StartedAt
+	}
+	answerSeed := startedAt.UnixNano()
+	if params.Resume != nil && params.Resume.AnswerSeed != 0 {
+		answerSeed = params.Resume.AnswerSeed
+	}
+	var checkpoint *checkpointWriter
+	if strings.TrimSpace(params.CheckpointDir) != "" {
+		paths, err := NewOutputPaths(params.CheckpointDir, repoMeta.Commit, runID)
+		if err != nil {
+			return Results{}, err
+		}
+		previous := params.Resume
+		if previous == nil {
+			previous = params.Rerun
+		}
+		checkpoint, err = openCheckpointWriter(paths.CheckpointPath(), previous)
+		if err != nil {
+			return Results{}, err
+		}
+		defer checkpoint.close()
+		if params.Resume == nil {
+			checkpoint.header(runID, repoMeta.Commit, startedAt, params, rerunOf, answerSeed)
+		}
+	} else if params.Resume != nil || params.Rerun != nil {
+		return Results{}, fmt.Errorf("resuming or rerunning a run requires an output directory")
+	}
 
-	taskRuns, err := planTaskRuns(cfg, params.Selectors, params.AgentOverride)
+	taskRuns, err := planTaskRuns(cfg, params)
 	if err != nil {
 		return Results{}, err
 	}
@@ -82,36 +129,77 @@ func Run(ctx context.Context, cfg spec.Config, params RunParams) (Results, error
 		return Results{}, err
 	}
 
-	toolRunner, err := toolRunnerFactory(repoRoot)
+	toolRunner, err := toolRunnerFactory(workRoot)
 	if err != nil {
 		return Results{}, err
 	}
 	executor := agent.RunnerExecutor{Runner: toolRunner}
 
-	toolDefs := defaultToolDefinitions()
-	taskResults := make([]TaskResult, 0, len(taskRuns))
+	toolDefs := DefaultToolDefinitions()
 	usedAgents := map[string]spec.AgentConfig{}
+	for _, taskRun := range taskRuns {
+		usedAgents[taskRun.Agent.ID] = taskRun.Agent
+	}
 	verboseWriter := params.VerboseWriter
 	if params.Verbose && verboseWriter == nil {
 		verboseWriter = os.Stdout
 	}
 	verboseLogWriter := params.VerboseLogWriter
+	matrix := len(matrixAgents(cfg, params)) > 0
+	if matrix {
+		verboseWriter, verboseLogWriter = wrapVerboseWriters(2, verboseWriter, verboseLogWriter)
+	}
+	// runBudget is shared by every task and question job of the run.
+	runBudget := newSpendTracker("run", cfg.Budget, now)
 
-	for _, taskRun := range taskRuns {
-		usedAgents[taskRun.Agent.ID] = taskRun.Agent
+	runTask := func(taskRun taskRun) (TaskResult, error) {
+		if ctx.Err() != nil {
+			return skippedTaskResult(taskRun, "cancelled"), nil
+		}
+		taskRun.Budget = newTaskBudget(runBudget, taskRun, now)
+		taskRun.AnswerSeed = answerSeed
+		taskRun.RepoRoot = repoRoot
+		if reason := taskRun.Budget.exhausted(); reason != "" {
+			return skippedTaskResult(taskRun, reason), nil
+		}
 		if observer != nil {
 			observer.OnTaskStart(taskRun.Task.ID, taskRun.Task.Type, taskRun.Task.QuestionsFile, taskRun.AgentID, taskRun.Model)
 		}
+		taskStart := now()
+		var result TaskResult
 		switch taskRun.Task.Type {
 		case "question_eval":
-			result := runQuestionTask(ctx, repoRoot, cfg, taskRun, limiter, toolDefs, executor, providerFactory, tokenCounter, params.Verbose, verboseWriter, verboseLogWriter, params.NoColor, observer)
-			taskResults = append(taskResults, result)
-			if observer != nil {
-				observer.OnTaskEnd(taskRun.Task.ID, result.Status, result.FailureReason)
-			}
+			result = runQuestionTask(ctx, workRoot, cfg, taskRun, limiter, toolDefs, executor, toolRunnerFactory, providerFactory, tokenCounter, params.Verbose, verboseWriter, verboseLogWriter, params.NoColor, observer, checkpoint)
+		case "code_change":
+			result = runCodeChangeTask(ctx, workRoot, cfg, taskRun, codeChangeDeps{
+				limiter:           limiter,
+				toolRunnerFactory: toolRunnerFactory,
+				providerFactory:   providerFactory,
+				tokenCounter:      tokenCounter,
+				setupRunner:       setupRunner,
+				verbose:           params.Verbose,
+				verboseWriter:     verboseWriter,
+				verboseLog:        verboseLogWriter,
+				noColor:           params.NoColor,
+			})
 		default:
-			return Results{}, fmt.Errorf("unsupported task type %q", taskRun.Task.Type)
+			return TaskResult{}, fmt.Errorf("unsupported task type %q", taskRun.Task.Type)
+		}
+		result.Model = taskRun.Model
+		result.WallTimeSeconds = now().Sub(taskStart).Seconds()
+		if ctx.Err() != nil && result.Status != "pass" {
+			reason := "cancelled"
+			result.Status = "cancelled"
+			result.FailureReason = &reason
+		}
+		if observer != nil {
+			observer.OnTaskEnd(taskRun.Task.ID, result.Status, result.FailureReason)
 		}
+		return result, nil
+	}
+	taskResults, err := runTaskCells(taskRuns, matrix, cfg.Matrix.ProviderConcurrency, runTask)
+	if err != nil {
+		return Results{}, err
 	}
 
 	agents := make([]AgentInfo, 0, len(usedAgents))
@@ -136,6 +224,12 @@ func Run(ctx context.Context, cfg spec.Config, params RunParams) (Results, error
 		FinishedAt: finishedAt,
 		Tasks:      taskResults,
 		Summary:    summarize(taskResults),
+		RerunOf:    rerunOf,
+		Selection:  newRunSelection(params.Selectors, params.Sampling),
+		Incomplete: ctx.Err() != nil,
+	}
+	if matrix {
+		results.Leaderboard = buildLeaderboard(taskResults, usedAgents)
 	}
 	if observer != nil {
 		observer.OnRunEnd(results)
@@ -155,18 +249,24 @@ func RunAndWrite(ctx context.Context, cfg spec.Config, params RunParams) (Result
 		return Results{}, OutputPaths{}, err
 	}
 	params.RepoRoot = repoRoot
-	results, err := Run(ctx, cfg, params)
-	if err != nil {
-		return Results{}, OutputPaths{}, err
-	}
 	outputDir := params.OutputDir
 	if strings.TrimSpace(outputDir) == "" {
 		outputDir = cfg.Repo.OutputDir
 	}
 	outputDir = resolveOutputDir(repoRoot, outputDir)
+	params.CheckpointDir = outputDir
+	results, err := Run(ctx, cfg, params)
+	if err != nil {
+		return Results{}, OutputPaths{}, err
+	}
 	paths, err := WriteRunOutputs(results, outputDir)
 	if err != nil {
 		return results, OutputPaths{}, err
 	}
 	return results, paths, nil
 }
+
+// skippedTaskResult records a task that never started, with the reason.
+func skippedTaskResult(task taskRun, reason string) TaskResult {
+	return TaskResult{TaskID: task.Task.ID, AgentID: task.AgentID, Model: task.Model, Type: task.Task.Type, Status: "skipped", FailureReason: &reason}
+}
@@ -0,0 +1,85 @@
+package runner
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"cogni/internal/agent"
+	"cogni/internal/testutil"
+)
+
+// cancellingProvider cancels the run when it sees a prompt containing cancelOn.
+type cancellingProvider struct {
+	flakyProvider
+	cancelOn string
+	cancel   context.CancelFunc
+}
+
+// Stream cancels the run on the matching prompt and otherwise answers.
+func (p cancellingProvider) Stream(ctx context.Context, prompt agent.Prompt) (agent.Stream, error) {
+	stream, err := p.flakyProvider.Stream(ctx, prompt)
+	p.mu.Lock()
+	last := (*p.calls)[len(*p.calls)-1]
+	p.mu.Unlock()
+	if strings.Contains(last, p.cancelOn) {
+		p.cancel()
+		return nil, ctx.Err()
+	}
+	return stream, err
+}
+
+// TestRunCancelWritesPartialResults verifies a cancelled run marks the
+// in-flight question cancelled, skips queued ones, flags the results
+// incomplete, and checkpoints only finished questions so resume reruns the rest.
+func TestRunCancelWritesPartialResults(t *testing.T) {
+	repoRoot, cfg := writeFlakyRepo(t)
+	var mu sync.Mutex
+	var calls []string
+	ctx, cancel := context.WithCancel(testutil.Context(t, 0))
+	defer cancel()
+	provider := cancellingProvider{
+		flakyProvider: flakyProvider{mu: &mu, calls: &calls},
+		cancelOn:      "3+1",
+		cancel:        cancel,
+	}
+	results, paths := runFlakyContext(t, ctx, repoRoot, cfg, provider, "run-1", RunParams{})
+	if !results.Incomplete {
+		t.Fatalf("expected results flagged incomplete")
+	}
+	task := results.Tasks[0]
+	if task.Status != "cancelled" || task.FailureReason == nil || *task.FailureReason != "cancelled" {
+		t.Fatalf("expected cancelled task, got %s (%v)", task.Status, task.FailureReason)
+	}
+	questions := task.QuestionEval.Questions
+	if len(questions) != 3 || !questions[0].Correct {
+		t.Fatalf("expected q1 answered, got %+v", questions)
+	}
+	if !questions[1].Cancelled {
+		t.Fatalf("expected q2 cancelled, got %+v", questions[1])
+	}
+	if questions[2].Skipped != "cancelled" {
+		t.Fatalf("expected q3 skipped, got %+v", questions[2])
+	}
+	if len(calls) != 2 {
+		t.Fatalf("expected q3 never sent to the provider, got %q", calls)
+	}
+	summary := task.QuestionEval.Summary
+	if summary.QuestionsCancelled != 1 || summary.QuestionsSkipped != 1 || summary.QuestionsIncorrect != 0 || summary.Accuracy != 1 {
+		t.Fatalf("expected cancelled and skipped questions left out of accuracy, got %+v", summary)
+	}
+
+	checkpoint, err := LoadCheckpoint(paths.CheckpointPath())
+	if err != nil {
+		t.Fatalf("load checkpoint: %v", err)
+	}
+	var resumeCalls []string
+	resumed, _ := runFlaky(t, repoRoot, cfg, flakyProvider{mu: &mu, calls: &resumeCalls}, "run-2", RunParams{Resume: checkpoint})
+	if len(resumeCalls) != 2 {
+		t.Fatalf("expected q2 and q3 to be rerun, got %q", resumeCalls)
+	}
+	if resumed.Incomplete || resumed.Tasks[0].Status != "pass" {
+		t.Fatalf("expected resumed run to pass, got %s", resumed.Tasks[0].Status)
+	}
+}
@@ -0,0 +1,87 @@
+package runner
+
+import (
+	"strings"
+	"sync"
+
+	"cogni/internal/spec"
+)
+
+// matrixAgents returns the agents every task should run against: --agents
+// when given, otherwise the config matrix. An empty result disables the
+// matrix, as does an --agent override.
+func matrixAgents(cfg spec.Config, params RunParams) []string {
+	if params.AgentOverride != "" {
+		return nil
+	}
+	agents := params.Agents
+	if len(agents) == 0 {
+		agents = cfg.Matrix.Agents
+	}
+	var unique []string
+	for _, agentID := range agents {
+		if agentID = strings.TrimSpace(agentID); agentID != "" && !containsString(unique, agentID) {
+			unique = append(unique, agentID)
+		}
+	}
+	return unique
+}
+
+// runTaskCells executes task runs and returns results in plan order. Matrix
+// runs queue cells per provider and drain the queues concurrently, running up
+// to perProvider cells of one provider at a time; other runs are sequential.
+func runTaskCells(runs []taskRun, matrix bool, perProvider int, run func(taskRun) (TaskResult, error)) ([]TaskResult, error) {
+	results := make([]TaskResult, len(runs))
+	if !matrix {
+		for i, cell := range runs {
+			result, err := run(cell)
+			if err != nil {
+				return nil, err
+			}
+			results[i] = result
+		}
+		return results, nil
+	}
+	if perProvider <= 0 {
+		perProvider = 1
+	}
+	var providers []string
+	queues := map[string]chan int{}
+	for i, cell := range runs {
+		provider := cell.Agent.Provider
+		if _, ok := queues[provider]; !ok {
+			providers = append(providers, provider)
+			queues[provider] = make(chan int, len(runs))
+		}
+		queues[provider] <- i
+	}
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for _, provider := range providers {
+		queue := queues[provider]
+		close(queue)
+		for worker := 0; worker < perProvider; worker++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range queue {
+					result, err := run(runs[i])
+					mu.Lock()
+					if err != nil && firstErr == nil {
+						firstErr = err
+					}
+					results[i] = result
+					mu.Unlock()
+				}
+			}()
+		}
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return results, nil
+}
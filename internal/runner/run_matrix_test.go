@@ -0,0 +1,104 @@
+package runner
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"cogni/internal/spec"
+)
+
+// TestPlanTaskRunsMatrix verifies tasks expand once per matrix or selector agent.
+func TestPlanTaskRunsMatrix(t *testing.T) {
+	cfg := spec.Config{
+		Agents:       []spec.AgentConfig{{ID: "a", Model: "model-a"}, {ID: "b", Model: "model-b"}},
+		DefaultAgent: "a",
+		Tasks:        []spec.TaskConfig{{ID: "t1", Type: "question_eval"}, {ID: "t2", Type: "question_eval"}},
+		Matrix:       spec.MatrixConfig{Agents: []string{"a", "b"}},
+	}
+	cells := func(runs []taskRun) string {
+		var out []string
+		for _, run := range runs {
+			out = append(out, run.Task.ID+"@"+run.AgentID)
+		}
+		return strings.Join(out, " ")
+	}
+	cases := []struct {
+		name   string
+		params RunParams
+		want   string
+	}{
+		{name: "config matrix", want: "t1@a t1@b t2@a t2@b"},
+		{name: "agents flag", params: RunParams{Agents: []string{"b"}}, want: "t1@b t2@b"},
+		{name: "agent override", params: RunParams{AgentOverride: "a"}, want: "t1@a t2@a"},
+		{name: "selectors", params: RunParams{Selectors: []TaskSelector{{TaskID: "t2", AgentID: "b"}, {TaskID: "t2", AgentID: "a"}}}, want: "t2@b t2@a"},
+	}
+	for _, tc := range cases {
+		runs, err := planTaskRuns(cfg, tc.params)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if got := cells(runs); got != tc.want {
+			t.Fatalf("%s: expected %q, got %q", tc.name, tc.want, got)
+		}
+	}
+	cfg.Tasks[0].Model = "task-model"
+	runs, err := planTaskRuns(cfg, RunParams{})
+	if err != nil {
+		t.Fatalf("plan with task model: %v", err)
+	}
+	if runs[0].Model != "model-a" || runs[1].Model != "model-b" {
+		t.Fatalf("expected matrix agents to keep their models, got %q and %q", runs[0].Model, runs[1].Model)
+	}
+	runs, err = planTaskRuns(cfg, RunParams{AgentOverride: "a"})
+	if err != nil || runs[0].Model != "task-model" {
+		t.Fatalf("expected task model outside a matrix, got %+v (%v)", runs, err)
+	}
+	if _, err := planTaskRuns(cfg, RunParams{AgentOverride: "a", Agents: []string{"b"}}); err == nil {
+		t.Fatalf("expected error combining an override with matrix agents")
+	}
+}
+
+// TestMatrixRunBuildsLeaderboard verifies a matrix run evaluates every agent
+// and ranks them with cost estimates.
+func TestMatrixRunBuildsLeaderboard(t *testing.T) {
+	repoRoot, cfg := writeFlakyRepo(t)
+	cfg.Agents = []spec.AgentConfig{
+		{ID: "agent-1", Type: "builtin", Provider: "provider-1", Model: "model-1", CostPerMillionTokens: 2},
+		{ID: "agent-2", Type: "builtin", Provider: "provider-2", Model: "model-2"},
+	}
+	cfg.Tasks[0].Agent = ""
+	cfg.Tasks[0].Model = "task-model"
+	var mu sync.Mutex
+	var calls []string
+	results, _ := runFlaky(t, repoRoot, cfg, flakyProvider{mu: &mu, calls: &calls}, "run-1", RunParams{Agents: []string{"agent-1", "agent-2"}})
+	if len(results.Tasks) != 2 || results.Tasks[0].AgentID != "agent-1" || results.Tasks[1].AgentID != "agent-2" {
+		t.Fatalf("expected one task result per agent in plan order, got %+v", results.Tasks)
+	}
+	if len(calls) != 6 {
+		t.Fatalf("expected 3 questions per agent, got %d calls", len(calls))
+	}
+	if len(results.Leaderboard) != 2 {
+		t.Fatalf("expected two standings, got %+v", results.Leaderboard)
+	}
+	for _, standing := range results.Leaderboard {
+		if standing.Model != "model-"+strings.TrimPrefix(standing.AgentID, "agent-") {
+			t.Fatalf("expected the model each agent ran, got %+v", standing)
+		}
+		if standing.QuestionsTotal != 3 || standing.Accuracy != 1 {
+			t.Fatalf("unexpected standing: %+v", standing)
+		}
+		priced := standing.AgentID == "agent-1"
+		if (standing.CostUSD != nil) != priced {
+			t.Fatalf("expected cost only for the priced agent, got %+v", standing)
+		}
+		if priced && *standing.CostUSD != float64(standing.TokensTotal)*2/1e6 {
+			t.Fatalf("unexpected cost %v for %d tokens", *standing.CostUSD, standing.TokensTotal)
+		}
+	}
+
+	single, _ := runFlaky(t, repoRoot, cfg, flakyProvider{mu: &mu, calls: &calls}, "run-2", RunParams{})
+	if single.Leaderboard != nil {
+		t.Fatalf("expected no leaderboard without a matrix, got %+v", single.Leaderboard)
+	}
+}
@@ -8,24 +8,32 @@ import (
 	"cogni/internal/spec"
 )
 
-// planTaskRuns resolves tasks, agents, and models into runnable units.
-func planTaskRuns(cfg spec.Config, selectors []TaskSelector, agentOverride string) ([]taskRun, error) {
+// planTaskRuns resolves tasks, agents, and models into runnable units, along
+// with the question subset each question task should evaluate. A task runs once
+// per agent when selectors name several agents for it or a matrix is active.
+func planTaskRuns(cfg spec.Config, params RunParams) ([]taskRun, error) {
+	selectors := params.Selectors
 	if err := ValidateSelectors(cfg, selectors); err != nil {
 		return nil, err
 	}
+	if err := params.Sampling.Validate(); err != nil {
+		return nil, err
+	}
+	if params.AgentOverride != "" && len(params.Agents) > 0 {
+		return nil, fmt.Errorf("an agent override cannot be combined with a list of matrix agents")
+	}
 	agentByID := make(map[string]spec.AgentConfig, len(cfg.Agents))
 	for _, agentConfig := range cfg.Agents {
 		agentByID[agentConfig.ID] = agentConfig
 	}
 
-	selectedAgent := map[string]string{}
-	if len(selectors) > 0 {
-		for _, selector := range selectors {
-			if selector.AgentID != "" {
-				selectedAgent[selector.TaskID] = selector.AgentID
-			}
+	selectedAgents := map[string][]string{}
+	for _, selector := range selectors {
+		if selector.AgentID != "" && !containsString(selectedAgents[selector.TaskID], selector.AgentID) {
+			selectedAgents[selector.TaskID] = append(selectedAgents[selector.TaskID], selector.AgentID)
 		}
 	}
+	matrix := matrixAgents(cfg, params)
 
 	selectedIDs := make([]string, 0, len(selectors))
 	for _, selector := range selectors {
@@ -38,30 +46,49 @@ func planTaskRuns(cfg spec.Config, selectors []TaskSelector, agentOverride strin
 
 	runs := make([]taskRun, 0, len(orderedTasks))
 	for _, task := range orderedTasks {
-		agentID := agentOverride
-		if agentID == "" {
-			if selectorAgent, ok := selectedAgent[task.ID]; ok {
-				agentID = selectorAgent
-			} else if task.Agent != "" {
-				agentID = task.Agent
-			} else {
-				agentID = cfg.DefaultAgent
-			}
-		}
-		agentConfig, ok := agentByID[agentID]
-		if !ok {
-			return nil, fmt.Errorf("unknown agent id %q", agentID)
+		var agentIDs []string
+		matrixCell := false
+		switch {
+		case params.AgentOverride != "":
+			agentIDs = []string{params.AgentOverride}
+		case len(selectedAgents[task.ID]) > 0:
+			agentIDs = selectedAgents[task.ID]
+		case len(matrix) > 0:
+			agentIDs = matrix
+			matrixCell = true
+		case task.Agent != "":
+			agentIDs = []string{task.Agent}
+		default:
+			agentIDs = []string{cfg.DefaultAgent}
 		}
-		model := task.Model
-		if strings.TrimSpace(model) == "" {
-			model = agentConfig.Model
+		for _, agentID := range agentIDs {
+			agentConfig, ok := agentByID[agentID]
+			if !ok {
+				return nil, fmt.Errorf("unknown agent id %q", agentID)
+			}
+			// Matrix cells compare agents, so each keeps its own model.
+			model := task.Model
+			if matrixCell || strings.TrimSpace(model) == "" {
+				model = agentConfig.Model
+			}
+			runs = append(runs, taskRun{
+				Task:    task,
+				Agent:   agentConfig,
+				Model:   model,
+				AgentID: agentID,
+				Filter:  newQuestionFilter(selectors, task.ID, params.Sampling),
+			})
 		}
-		runs = append(runs, taskRun{
-			Task:    task,
-			Agent:   agentConfig,
-			Model:   model,
-			AgentID: agentID,
-		})
 	}
 	return runs, nil
 }
+
+// containsString reports whether values includes value.
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
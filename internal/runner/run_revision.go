@@ -0,0 +1,43 @@
+package runner
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"cogni/internal/spec"
+	"cogni/internal/workspace"
+)
+
+// checkoutRevision materializes rev into a temporary worktree for a run and
+// returns its directory plus a cleanup func that removes it.
+func checkoutRevision(ctx context.Context, repoRoot, rev string) (string, func(), error) {
+	manager, err := workspace.NewManager(ctx, repoRoot, rev, workspace.ModeWorktree)
+	if err != nil {
+		return "", nil, err
+	}
+	job, err := manager.Acquire(ctx)
+	if err != nil {
+		_ = manager.Close(context.Background())
+		return "", nil, err
+	}
+	cleanup := func() {
+		_ = manager.Close(context.Background())
+	}
+	return job.Dir, cleanup, nil
+}
+
+// pinTaskPaths resolves task file references against the invoking checkout, so
+// a revision run scores the old code with the current question banks and prompts.
+func pinTaskPaths(cfg spec.Config, repoRoot string) spec.Config {
+	tasks := make([]spec.TaskConfig, len(cfg.Tasks))
+	for i, task := range cfg.Tasks {
+		task.QuestionsFile = resolveQuestionsFile(repoRoot, task.QuestionsFile)
+		if promptFile := task.Compaction.SummaryPromptFile; strings.TrimSpace(promptFile) != "" && !filepath.IsAbs(promptFile) {
+			task.Compaction.SummaryPromptFile = filepath.Join(repoRoot, promptFile)
+		}
+		tasks[i] = task
+	}
+	cfg.Tasks = tasks
+	return cfg
+}
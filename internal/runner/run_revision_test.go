@@ -0,0 +1,173 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"cogni/internal/agent"
+	"cogni/internal/spec"
+	"cogni/internal/testutil"
+	"cogni/internal/tools"
+)
+
+// readmeAnsweringProvider reads README.md and answers hello only if it says so.
+type readmeAnsweringProvider struct{}
+
+// Stream requests README.md, then answers from the tool output.
+func (readmeAnsweringProvider) Stream(_ context.Context, prompt agent.Prompt) (agent.Stream, error) {
+	for _, item := range prompt.InputItems {
+		if output, ok := item.Content.(agent.ToolOutput); ok {
+			answer := "goodbye"
+			if strings.Contains(output.Result.Output, "hello") {
+				answer = "hello"
+			}
+			return &fakeStream{events: []agent.StreamEvent{{Type: agent.StreamEventMessage, Message: "<answer>" + answer + "</answer>"}}}, nil
+		}
+	}
+	call := agent.ToolCall{ID: "r1", Name: "read_file", Args: agent.ToolCallArgs{"path": json.RawMessage(`"README.md"`)}}
+	return &fakeStream{events: []agent.StreamEvent{{Type: agent.StreamEventToolCall, ToolCall: call}}}, nil
+}
+
+// gitOutput runs git in dir and returns trimmed stdout.
+func gitOutput(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v: %s", args, err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// TestRunAtRevision verifies --rev evaluates an older commit in a temporary
+// worktree with current questions and leaves the working tree alone.
+func TestRunAtRevision(t *testing.T) {
+	repoRoot := initGitRepo(t)
+	oldCommit := gitOutput(t, repoRoot, "rev-parse", "HEAD")
+	if err := os.WriteFile(filepath.Join(repoRoot, "README.md"), []byte("goodbye\n"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	gitOutput(t, repoRoot, "commit", "-q", "-am", "second")
+	// Questions and local edits exist only in the working tree.
+	specBody := `version: 1
+questions:
+  - {id: q1, question: "First word of README.md?", answers: ["hello", "goodbye"], correct_answers: ["hello"]}
+`
+	if err := os.WriteFile(filepath.Join(repoRoot, "questions.yml"), []byte(specBody), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repoRoot, "README.md"), []byte("local\n"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	cfg := spec.Config{
+		Repo:         spec.RepoConfig{OutputDir: "./out", SetupCommands: []string{"touch setup-ran"}},
+		Agents:       []spec.AgentConfig{{ID: "agent-1", Type: "builtin", Provider: "openrouter", Model: "model"}},
+		DefaultAgent: "agent-1",
+		Tasks:        []spec.TaskConfig{{ID: "task-1", Type: "question_eval", Agent: "agent-1", QuestionsFile: "questions.yml"}},
+	}
+	results, paths, err := RunAndWrite(testutil.Context(t, 0), cfg, RunParams{
+		RepoRoot: repoRoot,
+		Rev:      oldCommit,
+		Deps: RunDependencies{
+			ProviderFactory: func(_ spec.AgentConfig, _ string) (agent.Provider, error) {
+				return readmeAnsweringProvider{}, nil
+			},
+			ToolRunnerFactory: func(root string) (*tools.Runner, error) {
+				return tools.NewRunner(root)
+			},
+			RunID: func() (string, error) { return "run-1", nil },
+			Now:   time.Now,
+		},
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if results.Repo.Commit != oldCommit || results.Repo.Dirty {
+		t.Fatalf("expected clean metadata for %s, got %+v", oldCommit, results.Repo)
+	}
+	if results.Repo.Name != filepath.Base(repoRoot) {
+		t.Fatalf("expected repo name %q, got %q", filepath.Base(repoRoot), results.Repo.Name)
+	}
+	if results.Tasks[0].Status != "pass" {
+		t.Fatalf("expected agent to see the old README, got %+v", results.Tasks[0].QuestionEval.Questions)
+	}
+	if paths.Commit != oldCommit || !strings.HasPrefix(paths.RunDir(), filepath.Join(repoRoot, "out")) {
+		t.Fatalf("unexpected output paths: %+v", paths)
+	}
+	if _, err := os.Stat(filepath.Join(repoRoot, "setup-ran")); !os.IsNotExist(err) {
+		t.Fatalf("expected setup commands to run in the worktree, got %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(repoRoot, "README.md")); string(data) != "local\n" {
+		t.Fatalf("expected working tree to be untouched, got %q", data)
+	}
+	if worktrees := gitOutput(t, repoRoot, "worktree", "list"); strings.Count(worktrees, "\n") != 0 {
+		t.Fatalf("expected temporary worktree to be removed, got %q", worktrees)
+	}
+}
+
+// TestRunAtRevisionRendersRepoVars verifies --rev prompts name the invoking
+// repository, not the temporary worktree, while module_path comes from the
+// checked-out revision.
+func TestRunAtRevisionRendersRepoVars(t *testing.T) {
+	repoRoot := initGitRepo(t)
+	if err := os.WriteFile(filepath.Join(repoRoot, "go.mod"), []byte("module example.com/old\n"), 0o644); err != nil {
+		t.Fatalf("write go.mod: %v", err)
+	}
+	gitOutput(t, repoRoot, "add", "-A")
+	gitOutput(t, repoRoot, "commit", "-q", "-m", "old module")
+	oldCommit := gitOutput(t, repoRoot, "rev-parse", "HEAD")
+	if err := os.WriteFile(filepath.Join(repoRoot, "go.mod"), []byte("module example.com/new\n"), 0o644); err != nil {
+		t.Fatalf("write go.mod: %v", err)
+	}
+	gitOutput(t, repoRoot, "commit", "-q", "-am", "new module")
+	specBody := `version: 1
+questions:
+  - {id: q1, question: "In {{repo_name}} at {{repo_root}}, module {{module_path}}: 2+2?", answers: ["4", "5"], correct_answers: ["4"]}
+`
+	if err := os.WriteFile(filepath.Join(repoRoot, "questions.yml"), []byte(specBody), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+
+	cfg := spec.Config{
+		Repo:         spec.RepoConfig{OutputDir: "./out"},
+		Agents:       []spec.AgentConfig{{ID: "agent-1", Type: "builtin", Provider: "openrouter", Model: "model"}},
+		DefaultAgent: "agent-1",
+		Tasks:        []spec.TaskConfig{{ID: "task-1", Type: "question_eval", Agent: "agent-1", QuestionsFile: "questions.yml"}},
+	}
+	var calls []string
+	provider := flakyProvider{mu: &sync.Mutex{}, calls: &calls}
+	_, err := Run(testutil.Context(t, 0), cfg, RunParams{
+		RepoRoot: repoRoot,
+		Rev:      oldCommit,
+		Deps: RunDependencies{
+			ProviderFactory: func(_ spec.AgentConfig, _ string) (agent.Provider, error) {
+				return provider, nil
+			},
+			ToolRunnerFactory: func(root string) (*tools.Runner, error) {
+				return tools.NewRunner(root)
+			},
+			RunID: func() (string, error) { return "run-1", nil },
+			Now:   time.Now,
+		},
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(calls) == 0 {
+		t.Fatalf("expected the question to be asked")
+	}
+	want := fmt.Sprintf("In %s at %s, module example.com/old: 2+2?", filepath.Base(repoRoot), repoRoot)
+	if !strings.Contains(calls[0], want) {
+		t.Fatalf("expected prompt to contain %q, got %q", want, calls[0])
+	}
+}
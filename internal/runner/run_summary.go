@@ -19,13 +19,18 @@ func summarize(tasks []TaskResult) RunSummary {
 			summary.QuestionsTotal += task.QuestionEval.Summary.QuestionsTotal
 			summary.QuestionsCorrect += task.QuestionEval.Summary.QuestionsCorrect
 			summary.QuestionsIncorrect += task.QuestionEval.Summary.QuestionsIncorrect
+			summary.QuestionsSkipped += task.QuestionEval.Summary.QuestionsSkipped
+			summary.QuestionsCancelled += task.QuestionEval.Summary.QuestionsCancelled
+		}
+		if task.CodeChange != nil {
+			summary.TokensTotal += task.CodeChange.TokensTotal
 		}
 	}
 	if summary.TasksTotal > 0 {
 		summary.PassRate = float64(summary.TasksPassed) / float64(summary.TasksTotal)
 	}
-	if summary.QuestionsTotal > 0 {
-		summary.QuestionAccuracy = float64(summary.QuestionsCorrect) / float64(summary.QuestionsTotal)
+	if scored := summary.QuestionsTotal - summary.QuestionsSkipped - summary.QuestionsCancelled; scored > 0 {
+		summary.QuestionAccuracy = float64(summary.QuestionsCorrect) / float64(scored)
 	}
 	return summary
 }
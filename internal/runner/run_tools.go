@@ -2,8 +2,8 @@ package runner
 
 import "cogni/internal/agent"
 
-// defaultToolDefinitions returns built-in tool definitions.
-func defaultToolDefinitions() []agent.ToolDefinition {
+// DefaultToolDefinitions returns the built-in read-only repository tool definitions.
+func DefaultToolDefinitions() []agent.ToolDefinition {
 	disallowExtras := agent.BoolPointer(false)
 	return []agent.ToolDefinition{
 		{
@@ -61,3 +61,35 @@ func defaultToolDefinitions() []agent.ToolDefinition {
 		},
 	}
 }
+
+// WriteToolDefinitions returns the tools that let an agent modify repository files.
+func WriteToolDefinitions() []agent.ToolDefinition {
+	disallowExtras := agent.BoolPointer(false)
+	return []agent.ToolDefinition{
+		{
+			Name:        "apply_patch",
+			Description: "Apply a unified diff (as produced by `git diff`) to the repository",
+			Parameters: &agent.ToolSchema{
+				Type: "object",
+				Properties: map[string]agent.ToolSchema{
+					"patch": agent.StringSchema(),
+				},
+				Required:             []string{"patch"},
+				AdditionalProperties: disallowExtras,
+			},
+		},
+		{
+			Name:        "write_file",
+			Description: "Create or overwrite a file in the repository with the given content",
+			Parameters: &agent.ToolSchema{
+				Type: "object",
+				Properties: map[string]agent.ToolSchema{
+					"path":    agent.StringSchema(),
+					"content": agent.StringSchema(),
+				},
+				Required:             []string{"path", "content"},
+				AdditionalProperties: disallowExtras,
+			},
+		},
+	}
+}
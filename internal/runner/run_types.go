@@ -47,16 +47,29 @@ type RunDependencies struct {
 
 // RunParams configures a run invocation.
 type RunParams struct {
-	RepoRoot         string
-	OutputDir        string
-	AgentOverride    string
+	RepoRoot      string
+	OutputDir     string
+	Rev           string
+	AgentOverride string
+	// Agents runs every selected task once per agent (a matrix run),
+	// overriding the config matrix.
+	Agents           []string
 	Selectors        []TaskSelector
+	Sampling         QuestionSampling
 	Verbose          bool
 	VerboseWriter    io.Writer
 	VerboseLogWriter io.Writer
 	NoColor          bool
 	Observer         RunObserver
-	Deps             RunDependencies
+	// CheckpointDir is the output root under which per-question results are
+	// checkpointed as they finish; empty disables checkpointing.
+	CheckpointDir string
+	// Resume continues a checkpointed run, reusing its completed questions.
+	Resume *Checkpoint
+	// Rerun starts a new run seeded with the questions carried over from an
+	// earlier run (see RerunCheckpoint).
+	Rerun *Checkpoint
+	Deps  RunDependencies
 }
 
 // taskRun couples a task with its resolved agent and model.
@@ -65,4 +78,11 @@ type taskRun struct {
 	Agent   spec.AgentConfig
 	Model   string
 	AgentID string
+	Filter  questionFilter
+	Budget  taskBudget
+	// AnswerSeed shuffles answers for tasks that shuffle without a seed.
+	AnswerSeed int64
+	// RepoRoot is the invoking repository, which question variables name
+	// even when a --rev run works in another checkout.
+	RepoRoot string
 }
@@ -7,13 +7,17 @@ import (
 	"cogni/internal/spec"
 )
 
-// TaskSelector chooses a task and optional agent override.
+// TaskSelector chooses a task, an optional agent override, and optionally a
+// subset of the task's questions by ID or tag.
 type TaskSelector struct {
-	TaskID  string
-	AgentID string
+	TaskID      string
+	AgentID     string
+	QuestionIDs []string
+	Tags        []string
 }
 
-// ParseSelectors parses selector strings of the form task@agent.
+// ParseSelectors parses selector strings of the form task@agent, task#q1,q2@agent
+// or task[tag=a,b]@agent.
 func ParseSelectors(inputs []string) ([]TaskSelector, error) {
 	selectors := make([]TaskSelector, 0, len(inputs))
 	for _, input := range inputs {
@@ -25,11 +29,10 @@ func ParseSelectors(inputs []string) ([]TaskSelector, error) {
 			return nil, fmt.Errorf("invalid selector %q", input)
 		}
 		parts := strings.SplitN(trimmed, "@", 2)
-		taskID := strings.TrimSpace(parts[0])
-		if taskID == "" {
+		selector, ok := parseTaskPart(strings.TrimSpace(parts[0]))
+		if !ok {
 			return nil, fmt.Errorf("invalid selector %q", input)
 		}
-		selector := TaskSelector{TaskID: taskID}
 		if len(parts) == 2 {
 			agentID := strings.TrimSpace(parts[1])
 			if agentID == "" {
@@ -42,23 +45,88 @@ func ParseSelectors(inputs []string) ([]TaskSelector, error) {
 	return selectors, nil
 }
 
+// parseTaskPart parses the task portion of a selector with its optional
+// #question-ids or [tag=...] suffix.
+func parseTaskPart(value string) (TaskSelector, bool) {
+	var selector TaskSelector
+	if open := strings.Index(value, "["); open >= 0 {
+		if !strings.HasSuffix(value, "]") {
+			return selector, false
+		}
+		filter := strings.TrimSpace(value[open+1 : len(value)-1])
+		tags, ok := strings.CutPrefix(filter, "tag=")
+		if !ok {
+			return selector, false
+		}
+		selector.Tags = splitSelectorList(tags)
+		if len(selector.Tags) == 0 {
+			return selector, false
+		}
+		value = value[:open]
+	}
+	if taskID, ids, ok := strings.Cut(value, "#"); ok {
+		selector.QuestionIDs = splitSelectorList(ids)
+		if len(selector.QuestionIDs) == 0 || len(selector.Tags) > 0 {
+			return selector, false
+		}
+		value = taskID
+	}
+	selector.TaskID = strings.TrimSpace(value)
+	return selector, selector.TaskID != ""
+}
+
+// splitSelectorList splits a comma-separated selector list, dropping blanks.
+func splitSelectorList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// String renders the selector in the syntax accepted by ParseSelectors.
+func (s TaskSelector) String() string {
+	value := s.TaskID
+	if len(s.QuestionIDs) > 0 {
+		value += "#" + strings.Join(s.QuestionIDs, ",")
+	}
+	if len(s.Tags) > 0 {
+		value += "[tag=" + strings.Join(s.Tags, ",") + "]"
+	}
+	if s.AgentID != "" {
+		value += "@" + s.AgentID
+	}
+	return value
+}
+
+// selectsQuestions reports whether the selector narrows the task's questions.
+func (s TaskSelector) selectsQuestions() bool {
+	return len(s.QuestionIDs) > 0 || len(s.Tags) > 0
+}
+
 // ValidateSelectors ensures selectors reference existing tasks and agents.
 func ValidateSelectors(cfg spec.Config, selectors []TaskSelector) error {
 	if len(selectors) == 0 {
 		return nil
 	}
-	taskIDs := make(map[string]struct{}, len(cfg.Tasks))
+	taskTypes := make(map[string]string, len(cfg.Tasks))
 	for _, task := range cfg.Tasks {
-		taskIDs[task.ID] = struct{}{}
+		taskTypes[task.ID] = task.Type
 	}
 	agentIDs := make(map[string]struct{}, len(cfg.Agents))
 	for _, agent := range cfg.Agents {
 		agentIDs[agent.ID] = struct{}{}
 	}
 	for _, selector := range selectors {
-		if _, ok := taskIDs[selector.TaskID]; !ok {
+		taskType, ok := taskTypes[selector.TaskID]
+		if !ok {
 			return fmt.Errorf("unknown task id %q", selector.TaskID)
 		}
+		if selector.selectsQuestions() && taskType != "question_eval" {
+			return fmt.Errorf("selector %q selects questions but task %q is not a question_eval task", selector.String(), selector.TaskID)
+		}
 		if selector.AgentID != "" {
 			if _, ok := agentIDs[selector.AgentID]; !ok {
 				return fmt.Errorf("unknown agent id %q", selector.AgentID)
@@ -49,3 +49,41 @@ func TestValidateSelectors(t *testing.T) {
 		t.Fatalf("expected agent error")
 	}
 }
+
+// TestParseQuestionSelectors verifies question id and tag selectors round-trip.
+func TestParseQuestionSelectors(t *testing.T) {
+	inputs := []string{"core#q12", "core#q1,q2@agent-1", "core[tag=concurrency]", "core[tag=a,b]@agent-1"}
+	selectors, err := ParseSelectors(inputs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := selectors[1]; got.TaskID != "core" || got.AgentID != "agent-1" || len(got.QuestionIDs) != 2 || got.QuestionIDs[1] != "q2" {
+		t.Fatalf("unexpected selector: %+v", got)
+	}
+	if got := selectors[2]; got.TaskID != "core" || len(got.Tags) != 1 || got.Tags[0] != "concurrency" {
+		t.Fatalf("unexpected selector: %+v", got)
+	}
+	for i, selector := range selectors {
+		if selector.String() != inputs[i] {
+			t.Fatalf("expected %q to round-trip, got %q", inputs[i], selector.String())
+		}
+	}
+	for _, input := range []string{"core#", "core[tag=]", "core[kind=x]", "core[tag=x", "core#q1[tag=x]", "#q1"} {
+		if _, err := ParseSelectors([]string{input}); err == nil {
+			t.Fatalf("expected error for %q", input)
+		}
+	}
+}
+
+// TestValidateQuestionSelectors verifies question filters require a question_eval task.
+func TestValidateQuestionSelectors(t *testing.T) {
+	cfg := spec.Config{
+		Tasks: []spec.TaskConfig{{ID: "qa", Type: "question_eval"}, {ID: "fix", Type: "code_change"}},
+	}
+	if err := ValidateSelectors(cfg, []TaskSelector{{TaskID: "qa", QuestionIDs: []string{"q1"}}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ValidateSelectors(cfg, []TaskSelector{{TaskID: "fix", Tags: []string{"x"}}}); err == nil {
+		t.Fatalf("expected error for code_change task")
+	}
+}
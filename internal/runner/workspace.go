@@ -0,0 +1,68 @@
+package runner
+
+import (
+	"context"
+
+	"cogni/internal/agent"
+	"cogni/internal/spec"
+	"cogni/internal/workspace"
+)
+
+// questionWorkspaces gives each question job its own checkout when a task
+// isolates workspaces, so concurrent jobs can use write tools safely.
+type questionWorkspaces struct {
+	manager           *workspace.Manager
+	toolRunnerFactory ToolRunnerFactory
+	writeTools        bool
+}
+
+// newQuestionWorkspaces prepares per-job workspaces for an isolated task.
+// It returns nil when the task shares the repository root.
+func newQuestionWorkspaces(ctx context.Context, repoRoot, outputDir string, task spec.TaskConfig, factory ToolRunnerFactory) (*questionWorkspaces, error) {
+	mode, _ := workspace.ParseMode(task.Workspace)
+	if mode == workspace.ModeShared {
+		return nil, nil
+	}
+	manager, err := workspace.NewManager(ctx, repoRoot, "", mode)
+	if err != nil {
+		return nil, err
+	}
+	manager.ExcludeFromCopies(outputDir)
+	return &questionWorkspaces{manager: manager, toolRunnerFactory: factory, writeTools: task.WriteTools}, nil
+}
+
+// acquire returns the directory and executor for one job plus a release func.
+func (w *questionWorkspaces) acquire(ctx context.Context, repoRoot string, shared agent.ToolExecutor) (string, agent.ToolExecutor, func(), error) {
+	if w == nil {
+		return repoRoot, shared, func() {}, nil
+	}
+	job, err := w.manager.Acquire(ctx)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	release := func() { _ = job.Release(context.Background()) }
+	toolRunner, err := w.toolRunnerFactory(job.Dir)
+	if err != nil {
+		release()
+		return "", nil, nil, err
+	}
+	return job.Dir, agent.RunnerExecutor{Runner: toolRunner, AllowWrites: w.writeTools}, release, nil
+}
+
+// close removes any remaining job workspaces.
+func (w *questionWorkspaces) close() {
+	if w == nil {
+		return
+	}
+	_ = w.manager.Close(context.Background())
+}
+
+// taskToolDefinitions adds write tools to the read-only set when a task enables them.
+func taskToolDefinitions(task spec.TaskConfig, toolDefs []agent.ToolDefinition) []agent.ToolDefinition {
+	if !task.WriteTools {
+		return toolDefs
+	}
+	combined := make([]agent.ToolDefinition, 0, len(toolDefs)+2)
+	combined = append(combined, toolDefs...)
+	return append(combined, WriteToolDefinitions()...)
+}
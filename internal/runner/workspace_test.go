@@ -0,0 +1,95 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"cogni/internal/agent"
+	"cogni/internal/spec"
+	"cogni/internal/testutil"
+	"cogni/internal/tools"
+	"cogni/internal/vcs"
+)
+
+// scratchWritingProvider writes scratch.txt on the first turn and answers
+// correctly only when the write succeeded.
+type scratchWritingProvider struct{}
+
+// Stream emits a write_file call or an answer depending on prior tool output.
+func (scratchWritingProvider) Stream(_ context.Context, prompt agent.Prompt) (agent.Stream, error) {
+	for _, item := range prompt.InputItems {
+		output, ok := item.Content.(agent.ToolOutput)
+		if !ok {
+			continue
+		}
+		answer := "5"
+		if strings.Contains(output.Result.Output, "wrote") {
+			answer = "4"
+		}
+		return &fakeStream{events: []agent.StreamEvent{{Type: agent.StreamEventMessage, Message: "<answer>" + answer + "</answer>"}}}, nil
+	}
+	call := agent.ToolCall{ID: "w1", Name: "write_file", Args: agent.ToolCallArgs{
+		"path":    json.RawMessage(`"scratch.txt"`),
+		"content": json.RawMessage(`"scratch"`),
+	}}
+	return &fakeStream{events: []agent.StreamEvent{{Type: agent.StreamEventToolCall, ToolCall: call}}}, nil
+}
+
+// TestQuestionEvalIsolatedWorkspaces verifies concurrent questions with write
+// tools run in per-job workspaces that leave the repository untouched.
+func TestQuestionEvalIsolatedWorkspaces(t *testing.T) {
+	repoRoot := initGitRepo(t)
+	specBody := `version: 1
+questions:
+  - {id: q1, question: "What is 2+2?", answers: ["4", "5"], correct_answers: ["4"]}
+  - {id: q2, question: "What is 3+1?", answers: ["4", "5"], correct_answers: ["4"]}
+  - {id: q3, question: "What is 1+3?", answers: ["4", "5"], correct_answers: ["4"]}
+`
+	if err := os.WriteFile(filepath.Join(repoRoot, "questions.yml"), []byte(specBody), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+	cfg := spec.Config{
+		Repo:         spec.RepoConfig{OutputDir: "./out"},
+		RateLimiter:  spec.RateLimiterConfig{Mode: "disabled", Workers: 3},
+		Agents:       []spec.AgentConfig{{ID: "agent-1", Type: "builtin", Provider: "openrouter", Model: "model"}},
+		DefaultAgent: "agent-1",
+		Tasks: []spec.TaskConfig{{
+			ID: "task-1", Type: "question_eval", Agent: "agent-1", QuestionsFile: "questions.yml",
+			Concurrency: 3, Workspace: "worktree", WriteTools: true,
+		}},
+	}
+	results, err := Run(testutil.Context(t, 0), cfg, RunParams{
+		RepoRoot: repoRoot,
+		Deps: RunDependencies{
+			ProviderFactory: func(_ spec.AgentConfig, _ string) (agent.Provider, error) {
+				return scratchWritingProvider{}, nil
+			},
+			ToolRunnerFactory: func(root string) (*tools.Runner, error) {
+				return tools.NewRunner(root)
+			},
+			RepoRootResolver: func(_ context.Context, root string) (string, error) {
+				return root, nil
+			},
+			RepoMetadataLoader: func(_ context.Context, root string) (vcs.Metadata, error) {
+				return vcs.Metadata{Name: filepath.Base(root), VCS: "git", Commit: "commit"}, nil
+			},
+			RunID: func() (string, error) { return "run-1", nil },
+			Now:   time.Now,
+		},
+	})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	summary := results.Tasks[0].QuestionEval.Summary
+	if summary.QuestionsCorrect != 3 {
+		t.Fatalf("expected every write to succeed, got %+v", results.Tasks[0].QuestionEval.Questions)
+	}
+	if _, err := os.Stat(filepath.Join(repoRoot, "scratch.txt")); !os.IsNotExist(err) {
+		t.Fatalf("expected repository to be untouched, got %v", err)
+	}
+}
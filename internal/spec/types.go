@@ -10,6 +10,14 @@ type Config struct {
 	DefaultAgent string            `yaml:"default_agent"`
 	RateLimiter  RateLimiterConfig `yaml:"rate_limiter"`
 	Tasks        []TaskConfig      `yaml:"tasks"`
+	Matrix       MatrixConfig      `yaml:"matrix"`
+	Budget       AggregateBudget   `yaml:"budget"`
+}
+
+// MatrixConfig runs every task against several agents in one invocation.
+type MatrixConfig struct {
+	Agents              []string `yaml:"agents"`
+	ProviderConcurrency int      `yaml:"provider_concurrency"`
 }
 
 // RepoConfig describes repository-level settings.
@@ -26,18 +34,29 @@ type AgentConfig struct {
 	Model       string  `yaml:"model"`
 	MaxSteps    int     `yaml:"max_steps"`
 	Temperature float64 `yaml:"temperature"`
+	// CostPerMillionTokens is a blended price used to estimate run cost.
+	CostPerMillionTokens float64 `yaml:"cost_per_million_tokens"`
 }
 
 // TaskConfig configures a single evaluation task.
 type TaskConfig struct {
-	ID            string         `yaml:"id"`
-	Type          string         `yaml:"type"`
-	Agent         string         `yaml:"agent"`
-	Model         string         `yaml:"model"`
-	QuestionsFile string         `yaml:"questions_file"`
-	Budget        TaskBudget     `yaml:"budget"`
-	Compaction    TaskCompaction `yaml:"compaction"`
-	Concurrency   int            `yaml:"concurrency"`
+	ID             string            `yaml:"id"`
+	Type           string            `yaml:"type"`
+	Agent          string            `yaml:"agent"`
+	Model          string            `yaml:"model"`
+	QuestionsFile  string            `yaml:"questions_file"`
+	Budget         TaskBudget        `yaml:"budget"`
+	TotalBudget    AggregateBudget   `yaml:"total_budget"`
+	Compaction     TaskCompaction    `yaml:"compaction"`
+	Concurrency    int               `yaml:"concurrency"`
+	AnswerOrder    TaskAnswerOrder   `yaml:"answer_order"`
+	QuestionVars   map[string]string `yaml:"question_vars"`
+	AnswerFormat   string            `yaml:"answer_format"`
+	ParseRecovery  bool              `yaml:"parse_recovery"`
+	Prompt         string            `yaml:"prompt"`
+	VerifyCommands []string          `yaml:"verify_commands"`
+	Workspace      string            `yaml:"workspace"`
+	WriteTools     bool              `yaml:"write_tools"`
 }
 
 // TaskBudget limits resource usage for a task.
@@ -47,6 +66,21 @@ type TaskBudget struct {
 	MaxSteps   int `yaml:"max_steps"`
 }
 
+// AggregateBudget caps the combined spend of a run, or of one task across all
+// of its questions. Zero disables a limit.
+type AggregateBudget struct {
+	MaxTokens  int     `yaml:"max_tokens"`
+	MaxCostUSD float64 `yaml:"max_cost_usd"`
+	MaxSeconds int     `yaml:"max_seconds"`
+}
+
+// TaskAnswerOrder configures how answer choices are presented for a task.
+type TaskAnswerOrder struct {
+	Shuffle bool   `yaml:"shuffle"`
+	Seed    int64  `yaml:"seed"`
+	Labels  string `yaml:"labels"`
+}
+
 // TaskCompaction configures history compaction for a task.
 type TaskCompaction struct {
 	MaxTokens             int    `yaml:"max_tokens"`
@@ -66,6 +100,45 @@ type RateLimiterConfig struct {
 	RequestTimeoutMs int                      `yaml:"request_timeout_ms"`
 	MaxOutputTokens  uint64                   `yaml:"max_output_tokens"`
 	Batch            BatchConfig              `yaml:"batch"`
+	// TokenEnv names the environment variable holding a bearer token for
+	// an authenticated ratelimiterd (remote mode).
+	TokenEnv string               `yaml:"token_env"`
+	TLS      RateLimiterTLSConfig `yaml:"tls"`
+	Adaptive AdaptiveConfig       `yaml:"adaptive"`
+	Retry    RetryConfig          `yaml:"retry"`
+	// Priority ranks this run's jobs: low, normal (default), or high.
+	// ratelimiterd leaves headroom for higher priorities.
+	Priority string `yaml:"priority"`
+	// Tenant identifies this run's team for fair queuing between tenants.
+	Tenant string `yaml:"tenant"`
+}
+
+// RetryConfig retries question attempts that fail with transient provider
+// errors (429, 5xx, dropped connections). MaxAttempts 1 disables retries.
+type RetryConfig struct {
+	MaxAttempts int `yaml:"max_attempts"`
+	BaseDelayMs int `yaml:"base_delay_ms"`
+	MaxDelayMs  int `yaml:"max_delay_ms"`
+}
+
+// AdaptiveConfig feeds provider 429s and rate-limit headers back into the
+// limiter. Remote mode only forwards feedback; ratelimiterd owns the tuning.
+// Zero tuning fields use the adaptive package defaults.
+type AdaptiveConfig struct {
+	Enabled            bool    `yaml:"enabled"`
+	BackoffFactor      float64 `yaml:"backoff_factor"`
+	MinFraction        float64 `yaml:"min_fraction"`
+	RecoveryStep       float64 `yaml:"recovery_step"`
+	RecoveryIntervalMs int     `yaml:"recovery_interval_ms"`
+	ProviderLimitTTLMs int     `yaml:"provider_limit_ttl_ms"`
+}
+
+// RateLimiterTLSConfig configures HTTPS to ratelimiterd. CertFile and KeyFile
+// present a client certificate for mTLS.
+type RateLimiterTLSConfig struct {
+	CAFile   string `yaml:"ca_file"`
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
 }
 
 // BatchConfig configures request batching for the limiter client.
@@ -37,6 +37,7 @@ func NewRunner(root string) (*Runner, error) {
 		Limits:   DefaultLimits(),
 		clock:    time.Now,
 		rgRunner: execRGRunner{},
+		patcher:  execPatchApplier{},
 		fs:       osFileSystem{},
 	}, nil
 }
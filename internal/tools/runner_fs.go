@@ -3,6 +3,7 @@ package tools
 import (
 	"io"
 	"os"
+	"path/filepath"
 )
 
 // fileSystem abstracts filesystem access for tool runners.
@@ -11,6 +12,9 @@ type fileSystem interface {
 	Stat(name string) (os.FileInfo, error)
 	Lstat(name string) (os.FileInfo, error)
 	ReadDir(name string) ([]os.DirEntry, error)
+	EvalSymlinks(name string) (string, error)
+	MkdirAll(name string, perm os.FileMode) error
+	WriteFile(name string, data []byte, perm os.FileMode) error
 }
 
 // osFileSystem implements fileSystem using the OS.
@@ -35,3 +39,18 @@ func (osFileSystem) Lstat(name string) (os.FileInfo, error) {
 func (osFileSystem) ReadDir(name string) ([]os.DirEntry, error) {
 	return os.ReadDir(name)
 }
+
+// EvalSymlinks resolves symlinks in a path.
+func (osFileSystem) EvalSymlinks(name string) (string, error) {
+	return filepath.EvalSymlinks(name)
+}
+
+// MkdirAll creates a directory and any missing parents.
+func (osFileSystem) MkdirAll(name string, perm os.FileMode) error {
+	return os.MkdirAll(name, perm)
+}
+
+// WriteFile creates or truncates a file with the given contents.
+func (osFileSystem) WriteFile(name string, data []byte, perm os.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
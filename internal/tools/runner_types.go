@@ -49,11 +49,23 @@ type ReadFileArgs struct {
 	EndLine   *int
 }
 
+// ApplyPatchArgs configures apply_patch tool execution.
+type ApplyPatchArgs struct {
+	Patch string
+}
+
+// WriteFileArgs configures write_file tool execution.
+type WriteFileArgs struct {
+	Path    string
+	Content string
+}
+
 // Runner executes repository tools within a repo root.
 type Runner struct {
 	Root     string
 	Limits   Limits
 	clock    func() time.Time
 	rgRunner rgRunner
+	patcher  patchApplier
 	fs       fileSystem
 }
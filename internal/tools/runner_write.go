@@ -0,0 +1,145 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// patchApplier applies unified diffs within a directory.
+type patchApplier interface {
+	Apply(ctx context.Context, dir string, patch string) (string, error)
+}
+
+// execPatchApplier applies patches with `git apply`, which also works outside
+// git repositories and refuses paths that escape the directory.
+type execPatchApplier struct{}
+
+// Apply runs git apply with the patch on stdin and returns its summary output.
+func (execPatchApplier) Apply(ctx context.Context, dir string, patch string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "apply", "--whitespace=nowarn", "--recount", "--verbose", "-")
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(patch)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("patch did not apply: %s", msg)
+	}
+	return strings.TrimSpace(stderr.String() + stdout.String()), nil
+}
+
+// ApplyPatch executes the apply_patch tool.
+func (r *Runner) ApplyPatch(ctx context.Context, args ApplyPatchArgs) CallResult {
+	start := r.clock()
+	output, err := r.applyPatch(ctx, args)
+	end := r.clock()
+	return r.finalize("apply_patch", start, end, output, false, err)
+}
+
+// applyPatch validates and applies a unified diff under the repo root.
+func (r *Runner) applyPatch(ctx context.Context, args ApplyPatchArgs) (string, error) {
+	if strings.TrimSpace(args.Patch) == "" {
+		return "", fmt.Errorf("patch is required")
+	}
+	patch := args.Patch
+	if !strings.HasSuffix(patch, "\n") {
+		patch += "\n"
+	}
+	output, err := r.patcher.Apply(ctx, r.Root, patch)
+	if err != nil {
+		return "", err
+	}
+	if output == "" {
+		output = "patch applied"
+	}
+	return output, nil
+}
+
+// WriteFile executes the write_file tool.
+func (r *Runner) WriteFile(ctx context.Context, args WriteFileArgs) CallResult {
+	start := r.clock()
+	output, err := r.writeFile(ctx, args)
+	end := r.clock()
+	return r.finalize("write_file", start, end, output, false, err)
+}
+
+// writeFile creates or replaces a file under the repo root. The write must
+// land inside the root after resolving symlinks, may not go through a
+// symlink at the final path, and may not touch .git, whose hooks run during
+// verification.
+func (r *Runner) writeFile(ctx context.Context, args WriteFileArgs) (string, error) {
+	_ = ctx
+	rel, abs, err := resolvePath(r.Root, args.Path)
+	if err != nil {
+		return "", err
+	}
+	if inGitDir(rel) {
+		return "", fmt.Errorf("writing under .git is not allowed: %s", rel)
+	}
+	if err := r.checkWriteParent(rel, filepath.Dir(abs)); err != nil {
+		return "", err
+	}
+	if info, err := r.fs.Lstat(abs); err == nil {
+		if info.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("%s is a symlink", rel)
+		}
+		if info.IsDir() {
+			return "", fmt.Errorf("%s is a directory", rel)
+		}
+	}
+	if err := r.fs.MkdirAll(filepath.Dir(abs), 0o755); err != nil {
+		return "", fmt.Errorf("create directory for %s: %w", rel, err)
+	}
+	if err := r.fs.WriteFile(abs, []byte(args.Content), 0o644); err != nil {
+		return "", fmt.Errorf("write %s: %w", rel, err)
+	}
+	return fmt.Sprintf("wrote %d bytes to %s", len(args.Content), rel), nil
+}
+
+// checkWriteParent resolves the deepest existing ancestor of dir through
+// symlinks and requires it to stay under the root and outside .git. Missing
+// directories below it are created fresh, so they cannot be symlinks.
+func (r *Runner) checkWriteParent(rel, dir string) error {
+	root, err := r.fs.EvalSymlinks(r.Root)
+	if err != nil {
+		return fmt.Errorf("resolve root: %w", err)
+	}
+	existing := dir
+	for {
+		if _, err := r.fs.Lstat(existing); err == nil {
+			break
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			break
+		}
+		existing = parent
+	}
+	resolved, err := r.fs.EvalSymlinks(existing)
+	if err != nil {
+		return fmt.Errorf("resolve directory for %s: %w", rel, err)
+	}
+	inside, err := filepath.Rel(root, resolved)
+	if err != nil || inside == ".." || strings.HasPrefix(inside, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("path %q escapes root through a symlink", rel)
+	}
+	if inGitDir(inside) {
+		return fmt.Errorf("writing under .git is not allowed: %s", rel)
+	}
+	return nil
+}
+
+// inGitDir reports whether a root-relative path is .git or inside it.
+func inGitDir(rel string) bool {
+	first := strings.SplitN(filepath.ToSlash(rel), "/", 2)[0]
+	return strings.EqualFold(first, ".git")
+}
@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cogni/internal/testutil"
+)
+
+// TestApplyPatchUpdatesFiles verifies unified diffs are applied under the root.
+func TestApplyPatchUpdatesFiles(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	runner, err := NewRunner(root)
+	if err != nil {
+		t.Fatalf("new runner: %v", err)
+	}
+	ctx := testutil.Context(t, 0)
+	patch := `--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+ package main
+ 
+-func main() {}
++func main() { println("hi") }
+`
+	result := runner.ApplyPatch(ctx, ApplyPatchArgs{Patch: patch})
+	if result.Error != "" {
+		t.Fatalf("apply patch: %s", result.Error)
+	}
+	data, err := os.ReadFile(filepath.Join(root, "main.go"))
+	if err != nil || !strings.Contains(string(data), `println("hi")`) {
+		t.Fatalf("expected patched file, got %q (%v)", data, err)
+	}
+
+	escape := "--- a/../outside.go\n+++ b/../outside.go\n@@ -0,0 +1 @@\n+package outside\n"
+	if result := runner.ApplyPatch(ctx, ApplyPatchArgs{Patch: escape}); result.Error == "" {
+		t.Fatalf("expected patch outside root to be rejected")
+	}
+}
+
+// TestWriteFileCreatesParents verifies write_file creates files and rejects escapes.
+func TestWriteFileCreatesParents(t *testing.T) {
+	root := t.TempDir()
+	runner, err := NewRunner(root)
+	if err != nil {
+		t.Fatalf("new runner: %v", err)
+	}
+	ctx := testutil.Context(t, 0)
+	result := runner.WriteFile(ctx, WriteFileArgs{Path: "pkg/new.go", Content: "package pkg\n"})
+	if result.Error != "" {
+		t.Fatalf("write file: %s", result.Error)
+	}
+	if data, err := os.ReadFile(filepath.Join(root, "pkg", "new.go")); err != nil || string(data) != "package pkg\n" {
+		t.Fatalf("unexpected file contents %q (%v)", data, err)
+	}
+	if result := runner.WriteFile(ctx, WriteFileArgs{Path: "../escape.go", Content: "x"}); result.Error == "" {
+		t.Fatalf("expected escape to be rejected")
+	}
+}
+
+// TestWriteFileRejectsSymlinkEscapesAndGitDir verifies writes cannot leave the root or touch .git.
+func TestWriteFileRejectsSymlinkEscapesAndGitDir(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	if err := os.Symlink(outside, filepath.Join(root, "linked")); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+	target := filepath.Join(outside, "target.txt")
+	if err := os.WriteFile(target, []byte("original"), 0o644); err != nil {
+		t.Fatalf("write target: %v", err)
+	}
+	if err := os.Symlink(target, filepath.Join(root, "alias.txt")); err != nil {
+		t.Fatalf("symlink file: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, ".git", "hooks"), 0o755); err != nil {
+		t.Fatalf("mkdir .git: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(root, ".git", "hooks"), filepath.Join(root, "hooks")); err != nil {
+		t.Fatalf("symlink hooks: %v", err)
+	}
+	runner, err := NewRunner(root)
+	if err != nil {
+		t.Fatalf("new runner: %v", err)
+	}
+	ctx := testutil.Context(t, 0)
+	for _, path := range []string{"linked/escape.txt", "linked/nested/escape.txt", "alias.txt", ".git/hooks/pre-commit", "hooks/pre-commit"} {
+		if result := runner.WriteFile(ctx, WriteFileArgs{Path: path, Content: "x"}); result.Error == "" {
+			t.Fatalf("expected write to %s to be rejected", path)
+		}
+	}
+	if data, err := os.ReadFile(target); err != nil || string(data) != "original" {
+		t.Fatalf("expected outside file untouched, got %q (%v)", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(outside, "nested")); !os.IsNotExist(err) {
+		t.Fatalf("expected no directories created outside the root, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, ".git", "hooks", "pre-commit")); !os.IsNotExist(err) {
+		t.Fatalf("expected no git hook written, got %v", err)
+	}
+}
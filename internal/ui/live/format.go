@@ -72,8 +72,15 @@ func formatPrimaryStatus(row QuestionRow) string {
 		return "waiting limit decreasing"
 	case runner.QuestionWaitingLimiterError:
 		return "waiting limiter error"
+	case runner.QuestionWaitingRetry:
+		if row.RetryAfterMs > 0 {
+			return "retrying (" + formatRetryAfter(row.RetryAfterMs) + ")"
+		}
+		return "retrying"
 	case runner.QuestionParseError:
 		return "parse error"
+	case runner.QuestionRecovering:
+		return "recovering answer"
 	case runner.QuestionBudgetExceeded:
 		return "budget exceeded"
 	case runner.QuestionRuntimeError:
@@ -100,6 +107,8 @@ func statusLabel(status runner.QuestionEventType) string {
 		return "correct"
 	case runner.QuestionIncorrect:
 		return "incorrect"
+	case runner.QuestionCancelled:
+		return "cancelled"
 	case runner.QuestionSkipped:
 		return "skipped"
 	default:
@@ -208,15 +217,17 @@ func statusStyle(status runner.QuestionEventType) lipgloss.Style {
 		color = lipgloss.Color("196")
 	case runner.QuestionWaitingRateLimit,
 		runner.QuestionWaitingLimitDecreasing,
-		runner.QuestionWaitingLimiterError:
+		runner.QuestionWaitingLimiterError,
+		runner.QuestionWaitingRetry:
 		color = lipgloss.Color("39")
 	case runner.QuestionRunning:
 		color = lipgloss.Color("33")
-	case runner.QuestionParsing:
+	case runner.QuestionParsing, runner.QuestionRecovering:
 		color = lipgloss.Color("201")
 	case runner.QuestionQueued,
 		runner.QuestionScheduled,
 		runner.QuestionReserving,
+		runner.QuestionCancelled,
 		runner.QuestionSkipped:
 		color = lipgloss.Color("246")
 	}
@@ -74,7 +74,8 @@ func applyQuestionEvent(state State, event runner.QuestionEvent) State {
 		row.RetryAfterMs = event.RetryAfterMs
 		if event.Type == runner.QuestionWaitingRateLimit ||
 			event.Type == runner.QuestionWaitingLimitDecreasing ||
-			event.Type == runner.QuestionWaitingLimiterError {
+			event.Type == runner.QuestionWaitingLimiterError ||
+			event.Type == runner.QuestionWaitingRetry {
 			row.RetryCount++
 		}
 		if event.Type == runner.QuestionRunning && row.StartedAt.IsZero() {
@@ -100,6 +101,7 @@ func isTerminalStatus(status runner.QuestionEventType) bool {
 		runner.QuestionParseError,
 		runner.QuestionBudgetExceeded,
 		runner.QuestionRuntimeError,
+		runner.QuestionCancelled,
 		runner.QuestionSkipped:
 		return true
 	default:
@@ -120,9 +122,10 @@ func recount(rows []QuestionRow) StatusCounts {
 			counts.Reserving++
 		case runner.QuestionWaitingRateLimit,
 			runner.QuestionWaitingLimitDecreasing,
-			runner.QuestionWaitingLimiterError:
+			runner.QuestionWaitingLimiterError,
+			runner.QuestionWaitingRetry:
 			counts.Waiting++
-		case runner.QuestionRunning:
+		case runner.QuestionRunning, runner.QuestionRecovering:
 			counts.Running++
 		case runner.QuestionParsing:
 			counts.Parsing++
@@ -141,6 +144,9 @@ func recount(rows []QuestionRow) StatusCounts {
 		case runner.QuestionRuntimeError:
 			counts.Done++
 			counts.RuntimeError++
+		case runner.QuestionCancelled:
+			counts.Done++
+			counts.Cancelled++
 		case runner.QuestionSkipped:
 			counts.Done++
 			counts.Skipped++
@@ -174,6 +180,8 @@ func formatLastEvent(event runner.QuestionEvent) string {
 		return fmt.Sprintf("Q%d budget exceeded", event.QuestionIndex+1)
 	case runner.QuestionParseError:
 		return fmt.Sprintf("Q%d parse error: %s", event.QuestionIndex+1, event.Error)
+	case runner.QuestionRecovering:
+		return fmt.Sprintf("Q%d parse error: %s (asking agent to restate answer)", event.QuestionIndex+1, event.Error)
 	}
 	if event.Type == runner.QuestionCorrect || event.Type == runner.QuestionIncorrect {
 		return fmt.Sprintf("Q%d completed", event.QuestionIndex+1)
@@ -1,6 +1,7 @@
 package live
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -68,6 +69,25 @@ func TestReduceTerminalErrors(t *testing.T) {
 	})
 }
 
+// TestReduceRecoveringIsActive verifies parse recovery keeps a question running.
+func TestReduceRecoveringIsActive(t *testing.T) {
+	runWithTimeout(t, time.Second, func() {
+		state := State{}
+		state = Reduce(state, event(0, runner.QuestionRunning, "", time.Now()))
+		state = Reduce(state, event(0, runner.QuestionRecovering, "missing <answer> block", time.Now()))
+		if state.Rows[0].Status != runner.QuestionRecovering || state.Counts.Running != 1 || state.Counts.Done != 0 {
+			t.Fatalf("expected recovering question to count as running, got %+v", state.Counts)
+		}
+		if !strings.Contains(state.LastEvent, "restate answer") {
+			t.Fatalf("expected recovery footer, got %q", state.LastEvent)
+		}
+		state = Reduce(state, event(0, runner.QuestionCorrect, "", time.Now()))
+		if state.Counts.Correct != 1 || state.Rows[0].Error != "" {
+			t.Fatalf("expected recovered question to finish correct, got %+v", state.Rows[0])
+		}
+	})
+}
+
 // TestReduceToolStatus verifies tool activity updates are stored.
 func TestReduceToolStatus(t *testing.T) {
 	runWithTimeout(t, time.Second, func() {
@@ -39,6 +39,7 @@ func renderSummary(state State, noColor bool) string {
 		" ParseErr: " + fmtInt(counts.ParseError) +
 		" Budget: " + fmtInt(counts.BudgetExceeded) +
 		" Error: " + fmtInt(counts.RuntimeError) +
+		" Cancelled: " + fmtInt(counts.Cancelled) +
 		" Skipped: " + fmtInt(counts.Skipped)
 	return stylize(line, noColor, lipgloss.Color("242"))
 }
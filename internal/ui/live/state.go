@@ -46,6 +46,7 @@ type StatusCounts struct {
 	ParseError     int
 	BudgetExceeded int
 	RuntimeError   int
+	Cancelled      int
 	Skipped        int
 }
 
@@ -0,0 +1,125 @@
+package vcs
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// AddWorktree checks out ref into a new detached worktree at path.
+func AddWorktree(ctx context.Context, repoRoot, path, ref string) error {
+	return defaultClient.AddWorktree(ctx, repoRoot, path, ref)
+}
+
+// RemoveWorktree deletes a worktree created by AddWorktree, discarding changes.
+func RemoveWorktree(ctx context.Context, repoRoot, path string) error {
+	return defaultClient.RemoveWorktree(ctx, repoRoot, path)
+}
+
+// WorktreeBaseline records the current state of a worktree, including new
+// files, for WorktreeDiff to diff against.
+func WorktreeBaseline(ctx context.Context, dir string) (string, error) {
+	return defaultClient.WorktreeBaseline(ctx, dir)
+}
+
+// WorktreeDiff returns all changes in a worktree relative to base, or HEAD
+// when base is empty, including new files.
+func WorktreeDiff(ctx context.Context, dir, base string) (string, error) {
+	return defaultClient.WorktreeDiff(ctx, dir, base)
+}
+
+// CheckoutDetached force-checks out ref as a detached HEAD in dir.
+func CheckoutDetached(ctx context.Context, dir, ref string) error {
+	return defaultClient.CheckoutDetached(ctx, dir, ref)
+}
+
+// AttachSharedRepo gives dir its own git directory that borrows objects from
+// repoRoot, so a copied tree can be checked out and diffed without copying
+// repoRoot's .git.
+func AttachSharedRepo(ctx context.Context, repoRoot, dir string) error {
+	return defaultClient.AttachSharedRepo(ctx, repoRoot, dir)
+}
+
+// AddWorktree checks out ref into a new detached worktree using a client runner.
+func (c Client) AddWorktree(ctx context.Context, repoRoot, path, ref string) error {
+	if strings.TrimSpace(path) == "" {
+		return fmt.Errorf("worktree path is empty")
+	}
+	if strings.TrimSpace(ref) == "" {
+		ref = "HEAD"
+	}
+	if _, err := c.runner.Run(ctx, repoRoot, "worktree", "add", "--detach", path, ref); err != nil {
+		return fmt.Errorf("add worktree: %w", err)
+	}
+	return nil
+}
+
+// RemoveWorktree deletes a worktree and prunes its metadata using a client runner.
+func (c Client) RemoveWorktree(ctx context.Context, repoRoot, path string) error {
+	if _, err := c.runner.Run(ctx, repoRoot, "worktree", "remove", "--force", path); err != nil {
+		return fmt.Errorf("remove worktree: %w", err)
+	}
+	if _, err := c.runner.Run(ctx, repoRoot, "worktree", "prune"); err != nil {
+		return fmt.Errorf("prune worktrees: %w", err)
+	}
+	return nil
+}
+
+// WorktreeBaseline stages every change in dir and returns the ID of a tree
+// object holding it. Worktrees are disposable, so staging has no lasting
+// effect.
+func (c Client) WorktreeBaseline(ctx context.Context, dir string) (string, error) {
+	if _, err := c.runner.Run(ctx, dir, "add", "-A"); err != nil {
+		return "", fmt.Errorf("stage changes: %w", err)
+	}
+	tree, err := c.runner.Run(ctx, dir, "write-tree")
+	if err != nil {
+		return "", fmt.Errorf("write baseline tree: %w", err)
+	}
+	return strings.TrimSpace(tree), nil
+}
+
+// WorktreeDiff stages every change in dir and returns the binary-safe diff
+// against base, a commit or tree, defaulting to HEAD.
+func (c Client) WorktreeDiff(ctx context.Context, dir, base string) (string, error) {
+	if strings.TrimSpace(base) == "" {
+		base = "HEAD"
+	}
+	if _, err := c.runner.Run(ctx, dir, "add", "-A"); err != nil {
+		return "", fmt.Errorf("stage changes: %w", err)
+	}
+	diff, err := c.runner.Run(ctx, dir, "diff", "--cached", "--binary", base)
+	if err != nil {
+		return "", fmt.Errorf("diff worktree: %w", err)
+	}
+	if diff != "" {
+		diff += "\n"
+	}
+	return diff, nil
+}
+
+// CheckoutDetached force-checks out ref in dir using a client runner.
+// Untracked and ignored files are left in place.
+func (c Client) CheckoutDetached(ctx context.Context, dir, ref string) error {
+	if strings.TrimSpace(ref) == "" {
+		return fmt.Errorf("ref is empty")
+	}
+	if _, err := c.runner.Run(ctx, dir, "checkout", "--quiet", "--detach", "--force", ref); err != nil {
+		return fmt.Errorf("checkout %q: %w", ref, err)
+	}
+	return nil
+}
+
+// AttachSharedRepo clones repoRoot's refs into dir/.git with shared objects
+// and turns it into a non-bare repository, using a client runner.
+func (c Client) AttachSharedRepo(ctx context.Context, repoRoot, dir string) error {
+	gitDir := filepath.Join(dir, ".git")
+	if _, err := c.runner.Run(ctx, repoRoot, "clone", "--quiet", "--bare", "--shared", repoRoot, gitDir); err != nil {
+		return fmt.Errorf("attach git dir: %w", err)
+	}
+	if _, err := c.runner.Run(ctx, dir, "config", "core.bare", "false"); err != nil {
+		return fmt.Errorf("attach git dir: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package vcs
+
+import (
+	"testing"
+
+	"cogni/internal/testutil"
+)
+
+// TestWorktreeCommands verifies worktree lifecycle and diff git invocations.
+func TestWorktreeCommands(t *testing.T) {
+	fake := &fakeGitRunner{responses: map[string]string{
+		"worktree add --detach /tmp/wt HEAD":    "",
+		"worktree remove --force /tmp/wt":       "",
+		"worktree prune":                        "",
+		"add -A":                                "",
+		"diff --cached --binary HEAD":           "diff --git a/x b/x",
+		"write-tree":                            "4b825dc\n",
+		"diff --cached --binary 4b825dc":        "diff --git a/y b/y",
+		"checkout --quiet --detach --force abc": "",
+	}}
+	client := NewClient(fake)
+	ctx := testutil.Context(t, 0)
+	if err := client.AddWorktree(ctx, "/repo", "/tmp/wt", ""); err != nil {
+		t.Fatalf("add worktree: %v", err)
+	}
+	diff, err := client.WorktreeDiff(ctx, "/tmp/wt", "")
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if diff != "diff --git a/x b/x\n" {
+		t.Fatalf("unexpected diff %q", diff)
+	}
+	base, err := client.WorktreeBaseline(ctx, "/tmp/wt")
+	if err != nil || base != "4b825dc" {
+		t.Fatalf("unexpected baseline %q (%v)", base, err)
+	}
+	diff, err = client.WorktreeDiff(ctx, "/tmp/wt", base)
+	if err != nil || diff != "diff --git a/y b/y\n" {
+		t.Fatalf("unexpected diff against baseline %q (%v)", diff, err)
+	}
+	if err := client.RemoveWorktree(ctx, "/repo", "/tmp/wt"); err != nil {
+		t.Fatalf("remove worktree: %v", err)
+	}
+	if err := client.CheckoutDetached(ctx, "/tmp/copy", "abc"); err != nil {
+		t.Fatalf("checkout: %v", err)
+	}
+	if err := client.AddWorktree(ctx, "/repo", "", "HEAD"); err == nil {
+		t.Fatalf("expected empty path error")
+	}
+}
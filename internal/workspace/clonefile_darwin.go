@@ -0,0 +1,10 @@
+//go:build darwin
+
+package workspace
+
+import "golang.org/x/sys/unix"
+
+// cloneTree performs a copy-on-write clone of a directory tree on macOS.
+func cloneTree(src, dst string) error {
+	return unix.Clonefile(src, dst, 0)
+}
@@ -0,0 +1,10 @@
+//go:build !darwin
+
+package workspace
+
+import "errors"
+
+// cloneTree reports that single-call tree cloning is unavailable on this platform.
+func cloneTree(src, dst string) error {
+	return errors.New("clonefile not supported")
+}
@@ -0,0 +1,69 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+)
+
+// copyTree copies src to dst, skipping the absolute paths in exclude. Entries
+// outside the excluded paths' ancestry are cloned copy-on-write when the
+// platform allows it, falling back to cp.
+func copyTree(src, dst string, exclude map[string]bool) error {
+	if err := os.Mkdir(dst, 0o755); err != nil {
+		return fmt.Errorf("copy checkout: %w", err)
+	}
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return fmt.Errorf("copy checkout: %w", err)
+	}
+	for _, entry := range entries {
+		from := filepath.Join(src, entry.Name())
+		to := filepath.Join(dst, entry.Name())
+		switch {
+		case exclude[from]:
+			continue
+		case entry.IsDir() && containsExcluded(from, exclude):
+			if err := copyTree(from, to, exclude); err != nil {
+				return err
+			}
+		default:
+			if err := copyEntry(from, to); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// containsExcluded reports whether any excluded path lies below dir.
+func containsExcluded(dir string, exclude map[string]bool) bool {
+	prefix := dir + string(filepath.Separator)
+	for path := range exclude {
+		if len(path) > len(prefix) && path[:len(prefix)] == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+// copyEntry attempts a copy-on-write clone of one file or directory before
+// falling back to a full copy.
+func copyEntry(src, dst string) error {
+	if err := cloneTree(src, dst); err == nil {
+		return nil
+	}
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux":
+		cmd = exec.Command("cp", "-a", "--reflink=auto", src, dst)
+	default:
+		cmd = exec.Command("cp", "-Rp", src, dst)
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("copy checkout: %w: %s", err, output)
+	}
+	return nil
+}
@@ -0,0 +1,2 @@
+// Package workspace provides isolated per-job checkouts of a repository.
+package workspace
@@ -0,0 +1,174 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"cogni/internal/vcs"
+)
+
+// gitMu serializes worktree add/remove across managers; git fails when they
+// race on the shared .git/worktrees metadata.
+var gitMu sync.Mutex
+
+// NewManager resolves ref to a commit and prepares a scratch directory for
+// job workspaces. Shared managers resolve nothing and create nothing.
+func NewManager(ctx context.Context, repoRoot, ref string, mode Mode) (*Manager, error) {
+	manager := &Manager{repoRoot: repoRoot, mode: mode, active: map[string]struct{}{}}
+	if mode == ModeShared {
+		return manager, nil
+	}
+	if ref == "" {
+		ref = "HEAD"
+	}
+	commit, err := vcs.ResolveRef(ctx, repoRoot, ref)
+	if err != nil {
+		return nil, err
+	}
+	baseDir, err := os.MkdirTemp("", "cogni-workspaces-*")
+	if err != nil {
+		return nil, fmt.Errorf("create workspace dir: %w", err)
+	}
+	manager.commit = commit
+	manager.baseDir = baseDir
+	return manager, nil
+}
+
+// Mode reports how the manager creates workspaces.
+func (m *Manager) Mode() Mode {
+	return m.mode
+}
+
+// Commit returns the commit workspaces are checked out at; empty when shared.
+func (m *Manager) Commit() string {
+	return m.commit
+}
+
+// Acquire creates a fresh workspace for one job.
+func (m *Manager) Acquire(ctx context.Context) (*Workspace, error) {
+	if m.mode == ModeShared {
+		return &Workspace{Dir: m.repoRoot, manager: m}, nil
+	}
+	m.mu.Lock()
+	m.next++
+	dir := filepath.Join(m.baseDir, fmt.Sprintf("job-%d", m.next))
+	m.mu.Unlock()
+
+	var err error
+	switch m.mode {
+	case ModeWorktree:
+		gitMu.Lock()
+		err = vcs.AddWorktree(ctx, m.repoRoot, dir, m.commit)
+		gitMu.Unlock()
+	case ModeCopy:
+		err = m.copyCheckout(ctx, dir)
+	default:
+		err = fmt.Errorf("unsupported workspace mode %q", m.mode)
+	}
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	m.mu.Lock()
+	m.active[dir] = struct{}{}
+	m.mu.Unlock()
+	return &Workspace{Dir: dir, manager: m}, nil
+}
+
+// Release removes the workspace. Releasing a shared workspace is a no-op.
+func (w *Workspace) Release(ctx context.Context) error {
+	if w == nil || w.manager.mode == ModeShared {
+		return nil
+	}
+	w.manager.mu.Lock()
+	_, ok := w.manager.active[w.Dir]
+	delete(w.manager.active, w.Dir)
+	w.manager.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return w.manager.remove(ctx, w.Dir)
+}
+
+// Close removes any workspaces that were not released and the scratch directory.
+func (m *Manager) Close(ctx context.Context) error {
+	if m.mode == ModeShared {
+		return nil
+	}
+	m.mu.Lock()
+	dirs := make([]string, 0, len(m.active))
+	for dir := range m.active {
+		dirs = append(dirs, dir)
+	}
+	m.active = map[string]struct{}{}
+	m.mu.Unlock()
+
+	var errs []error
+	for _, dir := range dirs {
+		errs = append(errs, m.remove(ctx, dir))
+	}
+	if err := os.RemoveAll(m.baseDir); err != nil {
+		errs = append(errs, fmt.Errorf("remove workspace dir: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// remove deletes a single workspace directory.
+func (m *Manager) remove(ctx context.Context, dir string) error {
+	var err error
+	if m.mode == ModeWorktree {
+		gitMu.Lock()
+		err = vcs.RemoveWorktree(ctx, m.repoRoot, dir)
+		gitMu.Unlock()
+	}
+	if removeErr := os.RemoveAll(dir); removeErr != nil && err == nil {
+		err = fmt.Errorf("remove workspace: %w", removeErr)
+	}
+	return err
+}
+
+// ExcludeFromCopies keeps paths, absolute or relative to the repository root,
+// out of copy-mode workspaces; typically the cogni output directory.
+func (m *Manager) ExcludeFromCopies(paths ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(m.repoRoot, path)
+		}
+		m.excludes = append(m.excludes, filepath.Clean(path))
+	}
+}
+
+// copyCheckout copies the repository directory, minus .git and excluded
+// paths, attaches a git directory that shares the repository's objects, and
+// pins it to the manager commit. Untracked and ignored files (for example
+// installed dependencies) are kept.
+func (m *Manager) copyCheckout(ctx context.Context, dir string) error {
+	root, err := filepath.Abs(m.repoRoot)
+	if err != nil {
+		return fmt.Errorf("resolve repo root: %w", err)
+	}
+	exclude := map[string]bool{filepath.Join(root, ".git"): true}
+	m.mu.Lock()
+	for _, path := range m.excludes {
+		if abs, err := filepath.Abs(path); err == nil {
+			exclude[abs] = true
+		}
+	}
+	m.mu.Unlock()
+	if err := copyTree(root, dir, exclude); err != nil {
+		return err
+	}
+	if err := vcs.AttachSharedRepo(ctx, root, dir); err != nil {
+		return err
+	}
+	return vcs.CheckoutDetached(ctx, dir, m.commit)
+}
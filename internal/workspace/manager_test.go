@@ -0,0 +1,137 @@
+package workspace
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"cogni/internal/testutil"
+)
+
+// initRepo creates a git repository with one committed file.
+func initRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "main.txt"), []byte("committed\n"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "-A"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, output)
+		}
+	}
+	return root
+}
+
+// TestManagerIsolatesJobs verifies each isolated mode gives jobs independent
+// checkouts at the pinned commit and removes them on release and close.
+func TestManagerIsolatesJobs(t *testing.T) {
+	for _, mode := range []Mode{ModeWorktree, ModeCopy} {
+		t.Run(string(mode), func(t *testing.T) {
+			repoRoot := initRepo(t)
+			// Uncommitted edits must not leak into workspaces.
+			if err := os.WriteFile(filepath.Join(repoRoot, "main.txt"), []byte("dirty\n"), 0o644); err != nil {
+				t.Fatalf("write file: %v", err)
+			}
+			ctx := testutil.Context(t, 0)
+			manager, err := NewManager(ctx, repoRoot, "", mode)
+			if err != nil {
+				t.Fatalf("new manager: %v", err)
+			}
+			first, err := manager.Acquire(ctx)
+			if err != nil {
+				t.Fatalf("acquire: %v", err)
+			}
+			second, err := manager.Acquire(ctx)
+			if err != nil {
+				t.Fatalf("acquire: %v", err)
+			}
+			if first.Dir == second.Dir || first.Dir == repoRoot {
+				t.Fatalf("expected distinct workspaces, got %q and %q", first.Dir, second.Dir)
+			}
+			data, err := os.ReadFile(filepath.Join(first.Dir, "main.txt"))
+			if err != nil || string(data) != "committed\n" {
+				t.Fatalf("expected committed content, got %q (%v)", data, err)
+			}
+			if err := os.WriteFile(filepath.Join(first.Dir, "scratch.txt"), []byte("x"), 0o644); err != nil {
+				t.Fatalf("write scratch: %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(second.Dir, "scratch.txt")); !os.IsNotExist(err) {
+				t.Fatalf("expected second workspace to be isolated, got %v", err)
+			}
+
+			if err := first.Release(ctx); err != nil {
+				t.Fatalf("release: %v", err)
+			}
+			if _, err := os.Stat(first.Dir); !os.IsNotExist(err) {
+				t.Fatalf("expected released workspace to be removed, got %v", err)
+			}
+			if err := manager.Close(ctx); err != nil {
+				t.Fatalf("close: %v", err)
+			}
+			if _, err := os.Stat(second.Dir); !os.IsNotExist(err) {
+				t.Fatalf("expected close to remove remaining workspaces, got %v", err)
+			}
+		})
+	}
+}
+
+// TestManagerShared verifies shared mode hands out the repository root.
+func TestManagerShared(t *testing.T) {
+	ctx := testutil.Context(t, 0)
+	manager, err := NewManager(ctx, "/repo", "", ModeShared)
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	workspace, err := manager.Acquire(ctx)
+	if err != nil || workspace.Dir != "/repo" {
+		t.Fatalf("expected repo root, got %+v (%v)", workspace, err)
+	}
+	if err := workspace.Release(ctx); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+}
+
+// TestManagerCopyExcludesOutputAndGitDir verifies copies skip run output and
+// get their own git directory instead of the repository's.
+func TestManagerCopyExcludesOutputAndGitDir(t *testing.T) {
+	repoRoot := initRepo(t)
+	for _, dir := range []string{filepath.Join(repoRoot, "out", "run-1"), filepath.Join(repoRoot, "node_modules")} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "file.txt"), []byte("x"), 0o644); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+	}
+	ctx := testutil.Context(t, 0)
+	manager, err := NewManager(ctx, repoRoot, "", ModeCopy)
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	defer func() { _ = manager.Close(ctx) }()
+	manager.ExcludeFromCopies("./out")
+	job, err := manager.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(job.Dir, "out")); !os.IsNotExist(err) {
+		t.Fatalf("expected output dir to be excluded, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(job.Dir, "node_modules", "file.txt")); err != nil {
+		t.Fatalf("expected untracked files to be kept: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(job.Dir, ".git", "objects", "info", "alternates")); err != nil {
+		t.Fatalf("expected a git dir sharing the repository objects: %v", err)
+	}
+}
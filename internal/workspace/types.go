@@ -0,0 +1,46 @@
+package workspace
+
+import "sync"
+
+// Mode selects how job workspaces are created.
+type Mode string
+
+const (
+	// ModeShared hands every job the repository root itself.
+	ModeShared Mode = "shared"
+	// ModeWorktree creates a detached git worktree per job.
+	ModeWorktree Mode = "worktree"
+	// ModeCopy creates a copy-on-write (or plain) copy of the checkout per job.
+	ModeCopy Mode = "copy"
+)
+
+// ParseMode converts a config value into a Mode; empty selects ModeShared.
+func ParseMode(value string) (Mode, bool) {
+	switch Mode(value) {
+	case "", ModeShared:
+		return ModeShared, true
+	case ModeWorktree, ModeCopy:
+		return Mode(value), true
+	default:
+		return "", false
+	}
+}
+
+// Manager creates and cleans up job workspaces pinned to a single commit.
+type Manager struct {
+	repoRoot string
+	commit   string
+	mode     Mode
+	baseDir  string
+	excludes []string
+
+	mu     sync.Mutex
+	next   int
+	active map[string]struct{}
+}
+
+// Workspace is a directory a single job may read and modify.
+type Workspace struct {
+	Dir     string
+	manager *Manager
+}
@@ -0,0 +1,101 @@
+package ratelimiter
+
+// LimitChangeAction classifies how applying a limit set changes one key.
+type LimitChangeAction string
+
+const (
+	// LimitChangeCreate adds a new limit.
+	LimitChangeCreate LimitChangeAction = "create"
+	// LimitChangeUpdate changes a limit without lowering its capacity.
+	LimitChangeUpdate LimitChangeAction = "update"
+	// LimitChangeDecrease lowers a limit's capacity through the decreasing flow.
+	LimitChangeDecrease LimitChangeAction = "decrease"
+	// LimitChangeDelete drains and removes a limit missing from the set.
+	LimitChangeDelete LimitChangeAction = "delete"
+)
+
+// LimitChange is one difference between a limit set and the registry.
+type LimitChange struct {
+	Key    LimitKey          `json:"key"`
+	Action LimitChangeAction `json:"action"`
+	Before *LimitDefinition  `json:"before,omitempty"`
+	After  *LimitDefinition  `json:"after,omitempty"`
+}
+
+// ApplyLimitsRequest replaces the registry with a full limit set.
+type ApplyLimitsRequest struct {
+	Limits []LimitDefinition `json:"limits"`
+	DryRun bool              `json:"dry_run"`
+}
+
+// ApplyLimitsResponse lists the changes planned or applied. When a change
+// fails, OK is false, Error and FailedKey name the failure, the changes
+// applied before it are rolled back, and Applied lists any that could not be
+// undone and remain in effect.
+type ApplyLimitsResponse struct {
+	OK        bool          `json:"ok"`
+	DryRun    bool          `json:"dry_run"`
+	Changes   []LimitChange `json:"changes"`
+	Unchanged int           `json:"unchanged"`
+	Error     string        `json:"error,omitempty"`
+	FailedKey LimitKey      `json:"failed_key,omitempty"`
+	Applied   []LimitChange `json:"applied,omitempty"`
+}
+
+// DeleteLimitResponse reports whether a deleted limit is gone or draining.
+type DeleteLimitResponse struct {
+	OK     bool        `json:"ok"`
+	Status LimitStatus `json:"status"`
+}
+
+// LimitStatusDeleted is reported once a deleted limit has been removed.
+const LimitStatusDeleted LimitStatus = "deleted"
+
+// LimitUsage is a point-in-time view of one limit's consumption.
+type LimitUsage struct {
+	Key      LimitKey  `json:"key"`
+	Kind     LimitKind `json:"kind"`
+	Capacity uint64    `json:"capacity"`
+	// Used is the capacity consumed right now: units reserved within the
+	// rolling window, or held slots for concurrency limits.
+	Used      uint64 `json:"used"`
+	Available uint64 `json:"available"`
+	// InFlight is the amount held by leases that have not completed.
+	InFlight          uint64      `json:"in_flight"`
+	Debt              uint64      `json:"debt"`
+	Status            LimitStatus `json:"status"`
+	PendingDecreaseTo uint64      `json:"pending_decrease_to,omitempty"`
+	// EffectiveCapacity is set while adaptive limits hold the limit below
+	// Capacity; Available is measured against it.
+	EffectiveCapacity uint64 `json:"effective_capacity,omitempty"`
+}
+
+// LeaseInfo describes a lease that has reserved capacity but not completed.
+type LeaseInfo struct {
+	LeaseID          string      `json:"lease_id"`
+	ReservedAtUnixMs int64       `json:"reserved_at_unix_ms"`
+	Holds            []LeaseHold `json:"holds"`
+}
+
+// LeaseHold is the capacity a lease holds on one limit and when the hold
+// lapses if the lease is never completed.
+type LeaseHold struct {
+	Key             LimitKey  `json:"key"`
+	Kind            LimitKind `json:"kind"`
+	Amount          uint64    `json:"amount"`
+	ExpiresAtUnixMs int64     `json:"expires_at_unix_ms"`
+}
+
+// LimitHold is one lease's hold on a limit, as listed by the usage endpoint.
+type LimitHold struct {
+	LeaseID          string `json:"lease_id"`
+	ReservedAtUnixMs int64  `json:"reserved_at_unix_ms"`
+	LeaseHold
+}
+
+// LimitUsageResponse reports a limit's usage and the leases holding it.
+type LimitUsageResponse struct {
+	Usage      LimitUsage       `json:"usage"`
+	Holds      []LimitHold      `json:"holds"`
+	Adjustment *LimitAdjustment `json:"adjustment,omitempty"`
+}
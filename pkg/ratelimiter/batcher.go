@@ -128,6 +128,15 @@ func (b *Batcher) BatchComplete(ctx context.Context, req BatchCompleteRequest) (
 	return b.limiter.BatchComplete(ctx, req)
 }
 
+// ReportFeedback forwards provider feedback unbatched when the underlying
+// limiter adapts capacity, and is a no-op otherwise.
+func (b *Batcher) ReportFeedback(ctx context.Context, req FeedbackRequest) (FeedbackResponse, error) {
+	if reporter, ok := b.limiter.(FeedbackReporter); ok {
+		return reporter.ReportFeedback(ctx, req)
+	}
+	return FeedbackResponse{}, nil
+}
+
 // Shutdown stops the batcher loop after flushing queued work.
 func (b *Batcher) Shutdown(ctx context.Context) error {
 	b.stopOnce.Do(func() { close(b.stopCh) })
@@ -0,0 +1,151 @@
+package ratelimiter
+
+import (
+	"context"
+	"time"
+)
+
+// ProviderFeedback is what an LLM provider response reveals about the
+// provider's own rate limits. Nil counts were not reported.
+type ProviderFeedback struct {
+	// Throttled is set when the provider rejected the call with HTTP 429.
+	Throttled bool
+	// RetryAfter is the provider's retry-after hint, if any.
+	RetryAfter        time.Duration
+	LimitRequests     *uint64
+	LimitTokens       *uint64
+	RemainingRequests *uint64
+	RemainingTokens   *uint64
+}
+
+// Empty reports whether the feedback carries no signal.
+func (f ProviderFeedback) Empty() bool {
+	return !f.Throttled && f.RetryAfter == 0 &&
+		f.LimitRequests == nil && f.LimitTokens == nil &&
+		f.RemainingRequests == nil && f.RemainingTokens == nil
+}
+
+// LimitFeedback is provider feedback attributed to one limit key.
+type LimitFeedback struct {
+	Key          LimitKey `json:"key"`
+	Throttled    bool     `json:"throttled,omitempty"`
+	RetryAfterMs int64    `json:"retry_after_ms,omitempty"`
+	// ProviderLimit is the capacity the provider reports for this limit.
+	ProviderLimit uint64 `json:"provider_limit,omitempty"`
+	// ProviderRemaining is what the provider reports is left in its window.
+	ProviderRemaining *uint64 `json:"provider_remaining,omitempty"`
+}
+
+// AdaptiveSettings tunes how provider feedback backs off and recovers limit
+// capacities. Zero fields use the defaults: halve capacity per throttle,
+// never below 10%, recover 10% every 30 seconds without throttling, and
+// trust a provider-reported limit for two minutes after its last report.
+type AdaptiveSettings struct {
+	// BackoffFactor multiplies the allowed fraction of capacity on each
+	// throttle signal.
+	BackoffFactor float64
+	// MinFraction is the lowest fraction of capacity backoff reaches.
+	MinFraction float64
+	// RecoveryStep is added to the fraction after every quiet RecoveryInterval.
+	RecoveryStep     float64
+	RecoveryInterval time.Duration
+	// ProviderLimitTTL is how long a provider-reported limit caps capacity.
+	ProviderLimitTTL time.Duration
+}
+
+// FeedbackRequest reports provider feedback for adaptive limits.
+type FeedbackRequest struct {
+	Feedback []LimitFeedback `json:"feedback"`
+}
+
+// FeedbackResponse returns the adjustments in force after the feedback.
+type FeedbackResponse struct {
+	OK          bool              `json:"ok"`
+	Adjustments []LimitAdjustment `json:"adjustments"`
+}
+
+// LimitAdjustment is an adaptive reduction of a limit below its defined
+// capacity, driven by provider feedback.
+type LimitAdjustment struct {
+	Key               LimitKey `json:"key"`
+	Capacity          uint64   `json:"capacity"`
+	EffectiveCapacity uint64   `json:"effective_capacity"`
+	// Fraction is the share of capacity allowed by backoff; it recovers
+	// toward 1 while the provider stops throttling.
+	Fraction              float64 `json:"fraction"`
+	ProviderLimit         uint64  `json:"provider_limit,omitempty"`
+	Throttles             uint64  `json:"throttles"`
+	LastThrottledAtUnixMs int64   `json:"last_throttled_at_unix_ms,omitempty"`
+	NextRecoveryAtUnixMs  int64   `json:"next_recovery_at_unix_ms,omitempty"`
+}
+
+// AdjustmentsResponse lists the adaptive adjustments in force.
+type AdjustmentsResponse struct {
+	Adjustments []LimitAdjustment `json:"adjustments"`
+}
+
+// FeedbackReporter is implemented by limiters that feed provider feedback
+// into adaptive limits.
+type FeedbackReporter interface {
+	ReportFeedback(ctx context.Context, req FeedbackRequest) (FeedbackResponse, error)
+}
+
+// BuildLLMFeedback attributes provider feedback to a provider/model's RPM and
+// TPM limits. A 429 throttles whichever limit the provider reports as
+// exhausted, or both when it does not say.
+func BuildLLMFeedback(provider, model string, fb ProviderFeedback) []LimitFeedback {
+	if fb.Empty() {
+		return nil
+	}
+	requestsOut := fb.RemainingRequests != nil && *fb.RemainingRequests == 0
+	tokensOut := fb.RemainingTokens != nil && *fb.RemainingTokens == 0
+	retryMs := fb.RetryAfter.Milliseconds()
+	rpm := LimitFeedback{
+		Key:               LimitKey(buildRPMKey(provider, model)),
+		Throttled:         fb.Throttled && (requestsOut || !tokensOut),
+		ProviderLimit:     valueOrZero(fb.LimitRequests),
+		ProviderRemaining: fb.RemainingRequests,
+	}
+	tpm := LimitFeedback{
+		Key:               LimitKey(buildTPMKey(provider, model)),
+		Throttled:         fb.Throttled && (tokensOut || !requestsOut),
+		ProviderLimit:     valueOrZero(fb.LimitTokens),
+		ProviderRemaining: fb.RemainingTokens,
+	}
+	var out []LimitFeedback
+	for _, entry := range []LimitFeedback{rpm, tpm} {
+		if entry.Throttled {
+			entry.RetryAfterMs = retryMs
+		}
+		if entry.Throttled || entry.ProviderLimit > 0 || entry.ProviderRemaining != nil {
+			out = append(out, entry)
+		}
+	}
+	return out
+}
+
+func valueOrZero(v *uint64) uint64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+type feedbackHookKey struct{}
+
+// WithProviderFeedback returns a context whose provider calls report their
+// feedback to fn.
+func WithProviderFeedback(ctx context.Context, fn func(ProviderFeedback)) context.Context {
+	return context.WithValue(ctx, feedbackHookKey{}, fn)
+}
+
+// ReportProviderFeedback passes feedback to the hook installed on ctx, if
+// any. Providers call it after every response.
+func ReportProviderFeedback(ctx context.Context, fb ProviderFeedback) {
+	if fb.Empty() {
+		return
+	}
+	if fn, ok := ctx.Value(feedbackHookKey{}).(func(ProviderFeedback)); ok && fn != nil {
+		fn(fb)
+	}
+}
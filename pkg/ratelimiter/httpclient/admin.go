@@ -0,0 +1,125 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"cogni/pkg/ratelimiter"
+)
+
+type limitsResponse struct {
+	Limits []ratelimiter.LimitState `json:"limits"`
+}
+
+type limitResponse struct {
+	Limit ratelimiter.LimitState `json:"limit"`
+}
+
+type putLimitResponse struct {
+	OK     bool                    `json:"ok"`
+	Status ratelimiter.LimitStatus `json:"status"`
+}
+
+// ListLimits returns every limit registered on the server.
+func (c *Client) ListLimits(ctx context.Context) ([]ratelimiter.LimitState, error) {
+	var res limitsResponse
+	if err := c.call(ctx, http.MethodGet, "/v1/admin/limits", nil, &res); err != nil {
+		return nil, err
+	}
+	return res.Limits, nil
+}
+
+// GetLimit returns one limit and its status.
+func (c *Client) GetLimit(ctx context.Context, key ratelimiter.LimitKey) (ratelimiter.LimitState, error) {
+	var res limitResponse
+	if err := c.call(ctx, http.MethodGet, limitPath(key), nil, &res); err != nil {
+		return ratelimiter.LimitState{}, err
+	}
+	return res.Limit, nil
+}
+
+// PutLimit creates or updates a limit and returns its resulting status.
+func (c *Client) PutLimit(ctx context.Context, def ratelimiter.LimitDefinition) (ratelimiter.LimitStatus, error) {
+	var res putLimitResponse
+	if err := c.call(ctx, http.MethodPut, "/v1/admin/limits", def, &res); err != nil {
+		return "", err
+	}
+	return res.Status, nil
+}
+
+// ApplyLimits replaces the server's limits with a full set, or plans the
+// change when req.DryRun is set. When the server fails partway, the error
+// comes with the server's response naming the failed key and any change
+// that stayed in effect.
+func (c *Client) ApplyLimits(ctx context.Context, req ratelimiter.ApplyLimitsRequest) (ratelimiter.ApplyLimitsResponse, error) {
+	var res ratelimiter.ApplyLimitsResponse
+	if err := c.call(ctx, http.MethodPost, "/v1/admin/limits:apply", req, &res); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// LimitUsage returns a limit's current usage and the leases holding it.
+func (c *Client) LimitUsage(ctx context.Context, key ratelimiter.LimitKey) (ratelimiter.LimitUsageResponse, error) {
+	var res ratelimiter.LimitUsageResponse
+	if err := c.call(ctx, http.MethodGet, limitPath(key)+"/usage", nil, &res); err != nil {
+		return ratelimiter.LimitUsageResponse{}, err
+	}
+	return res, nil
+}
+
+// call sends a JSON request and decodes a successful response into out.
+func (c *Client) call(ctx context.Context, method, path string, in, out any) error {
+	var payload []byte
+	if in != nil {
+		encoded, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		payload = encoded
+	}
+	body, status, err := c.do(ctx, method, path, payload)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		// Error bodies may carry a partial result, as from limits:apply.
+		_ = json.Unmarshal(body, out)
+		return decodeHTTPError(status, body)
+	}
+	return json.Unmarshal(body, out)
+}
+
+// limitPath escapes each segment of a key, which may itself contain slashes.
+func limitPath(key ratelimiter.LimitKey) string {
+	segments := strings.Split(string(key), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return "/v1/admin/limits/" + strings.Join(segments, "/")
+}
+
+// ReportFeedback sends provider feedback to the server's adaptive limits when
+// the client was built with Options.SendFeedback.
+func (c *Client) ReportFeedback(ctx context.Context, req ratelimiter.FeedbackRequest) (ratelimiter.FeedbackResponse, error) {
+	if !c.sendFeedback {
+		return ratelimiter.FeedbackResponse{OK: true, Adjustments: []ratelimiter.LimitAdjustment{}}, nil
+	}
+	var res ratelimiter.FeedbackResponse
+	if err := c.call(ctx, http.MethodPost, "/v1/feedback", req, &res); err != nil {
+		return ratelimiter.FeedbackResponse{}, err
+	}
+	return res, nil
+}
+
+// ListAdjustments returns the adaptive adjustments in force.
+func (c *Client) ListAdjustments(ctx context.Context) ([]ratelimiter.LimitAdjustment, error) {
+	var res ratelimiter.AdjustmentsResponse
+	if err := c.call(ctx, http.MethodGet, "/v1/admin/adjustments", nil, &res); err != nil {
+		return nil, err
+	}
+	return res.Adjustments, nil
+}
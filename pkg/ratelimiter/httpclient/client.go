@@ -3,6 +3,7 @@ package httpclient
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -17,6 +18,22 @@ import (
 type Client struct {
 	baseURL string
 	client  *http.Client
+	token   string
+	// sendFeedback enables ReportFeedback.
+	sendFeedback bool
+}
+
+// Options configures a Client.
+type Options struct {
+	// Timeout bounds each request; zero means no timeout.
+	Timeout time.Duration
+	// Token is sent as a bearer token when set.
+	Token string
+	// TLS configures HTTPS, including a client certificate for mTLS.
+	TLS *tls.Config
+	// SendFeedback makes ReportFeedback post provider feedback to the
+	// server's adaptive limits; otherwise it is a no-op.
+	SendFeedback bool
 }
 
 // New constructs a client for the given base URL.
@@ -26,10 +43,18 @@ func New(baseURL string) *Client {
 
 // NewWithTimeout constructs a client for the given base URL with a request timeout.
 func NewWithTimeout(baseURL string, timeout time.Duration) *Client {
-	return &Client{
-		baseURL: strings.TrimRight(baseURL, "/"),
-		client:  &http.Client{Timeout: timeout},
-	}
+	return NewWithOptions(baseURL, Options{Timeout: timeout})
+}
+
+// NewWithOptions constructs a client for the given base URL.
+func NewWithOptions(baseURL string, opts Options) *Client {
+	client := &http.Client{Timeout: opts.Timeout}
+	if opts.TLS != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = opts.TLS
+		client.Transport = transport
+	}
+	return &Client{baseURL: strings.TrimRight(baseURL, "/"), client: client, token: opts.Token, sendFeedback: opts.SendFeedback}
 }
 
 // Reserve requests a reservation over HTTP.
@@ -113,22 +138,34 @@ func (c *Client) BatchComplete(ctx context.Context, req ratelimiter.BatchComplet
 }
 
 func (c *Client) post(ctx context.Context, path string, payload []byte) ([]byte, int, error) {
-	url := c.baseURL + path
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	return c.do(ctx, http.MethodPost, path, payload)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, payload []byte) ([]byte, int, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
 	if err != nil {
 		return nil, 0, err
 	}
-	req.Header.Set("Content-Type", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, 0, err
 	}
 	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
+	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, resp.StatusCode, err
 	}
-	return body, resp.StatusCode, nil
+	return respBody, resp.StatusCode, nil
 }
 
 type errorResponse struct {
@@ -16,7 +16,7 @@ func TestNewWithTimeoutSetsTimeout(t *testing.T) {
 		timeout := 1500 * time.Millisecond
 		client := NewWithTimeout("http://example", timeout)
 		if client.client.Timeout != timeout {
-			t.Fatalf("expected timeout %s, got %s", timeout, client.client.Timeout)
+			t.Errorf("expected timeout %s, got %s", timeout, client.client.Timeout)
 		}
 	}()
 	select {
@@ -25,3 +25,11 @@ func TestNewWithTimeoutSetsTimeout(t *testing.T) {
 		t.Fatalf("test timed out")
 	}
 }
+
+// TestLimitPathEscapesSegments keeps slashes in keys as path separators.
+func TestLimitPathEscapesSegments(t *testing.T) {
+	got := limitPath("org/team a/rpm?")
+	if want := "/v1/admin/limits/org/team%20a/rpm%3F"; got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
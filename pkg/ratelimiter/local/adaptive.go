@@ -0,0 +1,48 @@
+package local
+
+import (
+	"context"
+
+	"cogni/internal/adaptive"
+	"cogni/pkg/ratelimiter"
+)
+
+// EnableAdaptive lets provider feedback lower effective capacities, which
+// recover as reservations arrive after quiet recovery intervals.
+func (c *Client) EnableAdaptive(settings ratelimiter.AdaptiveSettings) error {
+	cfg := adaptive.FromSettings(settings)
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	c.adaptive = adaptive.New(c.backend, cfg, c.now)
+	return nil
+}
+
+// ReportFeedback applies provider feedback when adaptive limits are enabled
+// and is a no-op otherwise.
+func (c *Client) ReportFeedback(ctx context.Context, req ratelimiter.FeedbackRequest) (ratelimiter.FeedbackResponse, error) {
+	if c.adaptive == nil {
+		return ratelimiter.FeedbackResponse{OK: true, Adjustments: []ratelimiter.LimitAdjustment{}}, nil
+	}
+	adjustments, err := c.adaptive.Observe(ctx, req.Feedback)
+	if err != nil {
+		return ratelimiter.FeedbackResponse{}, err
+	}
+	return ratelimiter.FeedbackResponse{OK: true, Adjustments: adjustments}, nil
+}
+
+// Adjustments lists the adaptive adjustments in force.
+func (c *Client) Adjustments(ctx context.Context) ([]ratelimiter.LimitAdjustment, error) {
+	if c.adaptive == nil {
+		return []ratelimiter.LimitAdjustment{}, nil
+	}
+	return c.adaptive.Adjustments(ctx)
+}
+
+// recover raises backed-off capacities whose recovery interval has passed.
+// The embedded limiter has no background loop, so reservations drive it.
+func (c *Client) recover(ctx context.Context) {
+	if c.adaptive != nil {
+		_ = c.adaptive.Recover(ctx)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"cogni/internal/adaptive"
 	"cogni/internal/backend/memory"
 	"cogni/internal/registry"
 	"cogni/pkg/ratelimiter"
@@ -12,8 +13,9 @@ import (
 
 // Client implements Limiter using the in-memory backend.
 type Client struct {
-	backend *memory.MemoryBackend
-	now     func() time.Time
+	backend  *memory.MemoryBackend
+	now      func() time.Time
+	adaptive *adaptive.Controller
 }
 
 // NewMemoryLimiterFromFile loads limits from disk and returns a local client.
@@ -38,6 +40,7 @@ func NewMemoryLimiterFromStates(states []ratelimiter.LimitState) (*Client, error
 
 // Reserve forwards reserve requests to the backend.
 func (c *Client) Reserve(ctx context.Context, req ratelimiter.ReserveRequest) (ratelimiter.ReserveResponse, error) {
+	c.recover(ctx)
 	return c.backend.Reserve(ctx, req, c.now())
 }
 
@@ -48,6 +51,7 @@ func (c *Client) Complete(ctx context.Context, req ratelimiter.CompleteRequest)
 
 // BatchReserve executes batch reserve requests locally.
 func (c *Client) BatchReserve(ctx context.Context, req ratelimiter.BatchReserveRequest) (ratelimiter.BatchReserveResponse, error) {
+	c.recover(ctx)
 	results := make([]ratelimiter.BatchReserveResult, 0, len(req.Requests))
 	for _, item := range req.Requests {
 		res, err := c.backend.Reserve(ctx, item, c.now())
@@ -44,6 +44,45 @@ func TestNewMemoryLimiterFromStatesAllowsReserve(t *testing.T) {
 	})
 }
 
+// TestClientAdaptiveLowersCapacityOnThrottle verifies feedback backs off an embedded limit.
+func TestClientAdaptiveLowersCapacityOnThrottle(t *testing.T) {
+	runWithTimeout(t, time.Second, func() {
+		limiter, err := NewMemoryLimiterFromStates([]ratelimiter.LimitState{{
+			Definition: ratelimiter.LimitDefinition{
+				Key:           "global:llm:test:model:rpm",
+				Kind:          ratelimiter.KindRolling,
+				Capacity:      10,
+				WindowSeconds: 60,
+				Unit:          "requests",
+				Overage:       ratelimiter.OverageDebt,
+			},
+			Status: ratelimiter.LimitStatusActive,
+		}})
+		if err != nil {
+			t.Fatalf("create limiter: %v", err)
+		}
+		ctx := context.Background()
+		feedback := ratelimiter.FeedbackRequest{Feedback: []ratelimiter.LimitFeedback{{Key: "global:llm:test:model:rpm", Throttled: true}}}
+		if res, err := limiter.ReportFeedback(ctx, feedback); err != nil || len(res.Adjustments) != 0 {
+			t.Fatalf("expected feedback ignored while disabled, got %+v %v", res, err)
+		}
+		if err := limiter.EnableAdaptive(ratelimiter.AdaptiveSettings{}); err != nil {
+			t.Fatalf("enable adaptive: %v", err)
+		}
+		res, err := limiter.ReportFeedback(ctx, feedback)
+		if err != nil || len(res.Adjustments) != 1 || res.Adjustments[0].EffectiveCapacity != 5 {
+			t.Fatalf("expected capacity backed off to 5, got %+v %v", res, err)
+		}
+		reserve, err := limiter.Reserve(ctx, ratelimiter.ReserveRequest{
+			LeaseID:      "lease-1",
+			Requirements: []ratelimiter.Requirement{{Key: "global:llm:test:model:rpm", Amount: 6}},
+		})
+		if err != nil || reserve.Allowed {
+			t.Fatalf("expected reserve above effective capacity denied, got %+v %v", reserve, err)
+		}
+	})
+}
+
 func runWithTimeout(t *testing.T, timeout time.Duration, fn func()) {
 	t.Helper()
 	done := make(chan struct{})
@@ -0,0 +1,56 @@
+package ratelimiter
+
+import "fmt"
+
+// Priority ranks jobs within a provider queue and at server admission.
+type Priority string
+
+const (
+	// PriorityLow yields to other work and leaves server headroom for it.
+	PriorityLow Priority = "low"
+	// PriorityNormal is the default; the empty Priority means normal.
+	PriorityNormal Priority = "normal"
+	// PriorityHigh is dispatched first and may use the full capacity.
+	PriorityHigh Priority = "high"
+)
+
+// priorityLevels is the number of priority classes.
+const priorityLevels = 3
+
+// ParsePriority validates a priority name; the empty string is normal.
+func ParsePriority(value string) (Priority, error) {
+	switch p := Priority(value); p {
+	case "":
+		return PriorityNormal, nil
+	case PriorityLow, PriorityNormal, PriorityHigh:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown priority %q (want low, normal, or high)", value)
+	}
+}
+
+// Valid reports whether p is a known priority or empty.
+func (p Priority) Valid() bool {
+	_, err := ParsePriority(string(p))
+	return err == nil
+}
+
+// Normalize maps the empty priority to PriorityNormal.
+func (p Priority) Normalize() Priority {
+	if p == "" {
+		return PriorityNormal
+	}
+	return p
+}
+
+// rank orders priorities for dispatch, highest first.
+func (p Priority) rank() int {
+	switch p {
+	case PriorityHigh:
+		return 0
+	case PriorityLow:
+		return 2
+	default:
+		return 1
+	}
+}
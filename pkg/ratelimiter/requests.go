@@ -5,6 +5,11 @@ type ReserveRequest struct {
 	LeaseID      string        `json:"lease_id"`
 	JobID        string        `json:"job_id"`
 	Requirements []Requirement `json:"requirements"`
+	// Priority selects the admission headroom; empty means normal.
+	Priority Priority `json:"priority,omitempty"`
+	// Tenant is the authenticated caller's tenant. The server sets it and
+	// never reads it from the wire; backends record it on the lease.
+	Tenant string `json:"-"`
 }
 
 // ReserveResponse reports whether a reservation was allowed.
@@ -20,6 +25,9 @@ type CompleteRequest struct {
 	LeaseID string   `json:"lease_id"`
 	JobID   string   `json:"job_id"`
 	Actuals []Actual `json:"actuals"`
+	// Tenant is the authenticated caller's tenant; a lease reserved by
+	// another tenant is not completed.
+	Tenant string `json:"-"`
 }
 
 // CompleteResponse reports whether completion succeeded.
@@ -1,6 +1,9 @@
 package ratelimiter
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // buildRPMKey formats the RPM limit key for a provider/model pair.
 func buildRPMKey(provider, model string) string {
@@ -21,3 +24,32 @@ func buildConcurrencyKey(provider, model string) string {
 func buildDailyKey(tenantID string) string {
 	return fmt.Sprintf("tenant:%s:llm:daily_tokens", tenantID)
 }
+
+// TenantDailyKey returns the daily token budget key of a tenant.
+func TenantDailyKey(tenantID string) LimitKey {
+	return LimitKey(buildDailyKey(tenantID))
+}
+
+// llmKeyPrefix starts every shared provider/model limit key.
+const llmKeyPrefix = "global:llm:"
+
+// IsLLMKey reports whether a key limits a provider/model pair.
+func IsLLMKey(key LimitKey) bool {
+	return strings.HasPrefix(string(key), llmKeyPrefix)
+}
+
+// tenantKeyPrefix starts every tenant-owned limit key.
+const tenantKeyPrefix = "tenant:"
+
+// KeyTenant returns the tenant that owns a limit key, if it is tenant-scoped.
+func KeyTenant(key LimitKey) (string, bool) {
+	rest, ok := strings.CutPrefix(string(key), tenantKeyPrefix)
+	if !ok {
+		return "", false
+	}
+	tenant, _, ok := strings.Cut(rest, ":")
+	if !ok || tenant == "" {
+		return "", false
+	}
+	return tenant, true
+}
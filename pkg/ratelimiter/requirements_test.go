@@ -37,3 +37,36 @@ func TestBuildLLMRequirements_ContainsExpectedKeys(t *testing.T) {
 		}
 	})
 }
+
+func TestKeyTenant_MatchesDailyKey(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		if tenant, ok := KeyTenant(LimitKey(buildDailyKey("tenant-a"))); !ok || tenant != "tenant-a" {
+			t.Fatalf("expected daily key owned by tenant-a, got %q %v", tenant, ok)
+		}
+		for _, key := range []string{buildTPMKey("openai", "gpt-4o"), "tenant:", "tenant:a"} {
+			if tenant, ok := KeyTenant(LimitKey(key)); ok {
+				t.Fatalf("expected %s to be global, got tenant %q", key, tenant)
+			}
+		}
+	})
+}
+
+func TestBuildLLMFeedback_AttributesExhaustedLimit(t *testing.T) {
+	zero, limit := uint64(0), uint64(1000)
+	entries := BuildLLMFeedback("openrouter", "m", ProviderFeedback{
+		Throttled:       true,
+		RetryAfter:      2 * time.Second,
+		LimitTokens:     &limit,
+		RemainingTokens: &zero,
+	})
+	if len(entries) != 1 {
+		t.Fatalf("expected only the tpm limit, got %+v", entries)
+	}
+	got := entries[0]
+	if got.Key != LimitKey(buildTPMKey("openrouter", "m")) || !got.Throttled || got.RetryAfterMs != 2000 || got.ProviderLimit != 1000 {
+		t.Fatalf("unexpected feedback: %+v", got)
+	}
+	if BuildLLMFeedback("openrouter", "m", ProviderFeedback{}) != nil {
+		t.Fatalf("expected no feedback without signals")
+	}
+}
@@ -0,0 +1,116 @@
+package ratelimiter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy re-runs a job's Execute after transient failures. Each retry
+// completes the failed attempt's lease and re-reserves capacity under a new
+// lease. The zero value never retries.
+type RetryPolicy struct {
+	// MaxAttempts caps Execute calls, including the first.
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry; it doubles per attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff; zero leaves it uncapped.
+	MaxDelay time.Duration
+	// Retryable classifies errors; nil uses IsRetryable.
+	Retryable func(error) bool
+}
+
+// DefaultRetryPolicy makes three attempts, backing off from one second.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: 30 * time.Second}
+}
+
+// allows reports whether a job that failed attempt with err runs again.
+func (p RetryPolicy) allows(attempt int, err error) bool {
+	if err == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return IsRetryable(err)
+}
+
+// backoff returns the exponential delay after a failed attempt, raised to
+// the error's own retry hint when it has one.
+func (p RetryPolicy) backoff(attempt int, err error) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	var hinted RetryDelayer
+	if errors.As(err, &hinted) && hinted.RetryDelay() > delay {
+		delay = hinted.RetryDelay()
+	}
+	return delay
+}
+
+// RetryableError is implemented by errors that know whether retrying the
+// call can succeed, such as provider HTTP errors.
+type RetryableError interface {
+	error
+	Retryable() bool
+}
+
+// RetryDelayer is implemented by errors carrying a provider retry-after hint.
+type RetryDelayer interface {
+	RetryDelay() time.Duration
+}
+
+// IsRetryable classifies transient failures: errors that say so, network
+// timeouts, and dropped or refused connections. Cancellation never retries.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var classified RetryableError
+	if errors.As(err, &classified) {
+		return classified.Retryable()
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// executeAttempt is the retry state visible to one Execute call.
+type executeAttempt struct {
+	attempt int
+	policy  RetryPolicy
+}
+
+type executeAttemptKey struct{}
+
+// withExecuteAttempt records the attempt number and policy for Execute.
+func withExecuteAttempt(ctx context.Context, job Job) context.Context {
+	return context.WithValue(ctx, executeAttemptKey{}, executeAttempt{attempt: job.Attempt, policy: job.Retry})
+}
+
+// AttemptFromContext returns the 1-based Execute attempt, or 0 outside a
+// scheduled job.
+func AttemptFromContext(ctx context.Context) int {
+	state, _ := ctx.Value(executeAttemptKey{}).(executeAttempt)
+	return state.attempt
+}
+
+// WillRetry reports whether the scheduler will run the job again after its
+// Execute returns err, so callers can treat the attempt as provisional.
+func WillRetry(ctx context.Context, err error) bool {
+	state, ok := ctx.Value(executeAttemptKey{}).(executeAttempt)
+	return ok && state.policy.allows(state.attempt, err)
+}
@@ -15,8 +15,22 @@ type Job struct {
 	Prompt                    string
 	MaxOutputTokens           uint64
 	WantDailyBudget           bool
+	// Priority orders the job within its provider queue and is sent with
+	// each reservation; empty means normal.
+	Priority Priority
 
 	Execute func(ctx context.Context) (actualTokens uint64, err error)
+	// Retry re-runs Execute after retryable errors; the zero value runs it once.
+	Retry RetryPolicy
+	// Attempt is the 1-based Execute attempt, set by the scheduler.
+	Attempt int
+	// Admit, when set, is checked before each reserve attempt; a non-nil
+	// error drops the job without reserving and is passed to OnCancel.
+	Admit func() error
+	// OnCancel is called instead of Execute when the job will not run,
+	// either because the scheduler's context ended or Admit refused it, so
+	// callers waiting on the job are released.
+	OnCancel func(err error)
 }
 
 // Scheduler coordinates Reserve/Complete attempts across per-provider queues.
@@ -38,9 +52,13 @@ type Scheduler struct {
 
 	state *schedulerState
 
+	pauseMu sync.Mutex
+	pauses  map[string]time.Time
+
 	now             func() time.Time
 	newLeaseID      func() string
 	jitter          func(time.Duration) time.Duration
+	retryJitter     func(time.Duration) time.Duration
 	errorRetryDelay time.Duration
 	idleInterval    time.Duration
 }
@@ -57,15 +75,37 @@ func NewSchedulerWithObserver(limiter Limiter, workers int, observer SchedulerOb
 	return newScheduler(limiter, workers, cfg)
 }
 
-// Submit enqueues a job for scheduling.
+// NewSchedulerWithContext creates a Scheduler that stops running jobs once ctx
+// ends: queued jobs are cancelled and in-flight jobs see a cancelled context.
+func NewSchedulerWithContext(ctx context.Context, limiter Limiter, workers int, observer SchedulerObserver) *Scheduler {
+	cfg := defaultSchedulerConfig()
+	cfg.parent = ctx
+	cfg.observer = observer
+	return newScheduler(limiter, workers, cfg)
+}
+
+// Submit enqueues a job for scheduling. Jobs submitted after the scheduler's
+// context ends are cancelled immediately.
 func (s *Scheduler) Submit(job Job) {
+	if err := s.ctx.Err(); err != nil {
+		cancelJob(job, err)
+		return
+	}
 	select {
 	case <-s.doneCh:
-		return
+		cancelJob(job, context.Canceled)
 	case s.submitCh <- job:
 	}
 }
 
+// SetTenantWeights sets the fair-queuing share of each tenant within a
+// priority class. Tenants not listed, and non-positive weights, count as 1.
+// It only orders jobs submitted to this scheduler; tenants in separate
+// processes are shared fairly by the server's admission.tenant_fair_share.
+func (s *Scheduler) SetTenantWeights(weights map[string]float64) {
+	s.state.setWeights(weights)
+}
+
 // Shutdown stops the scheduler and waits for workers to finish.
 func (s *Scheduler) Shutdown(ctx context.Context) error {
 	s.stopOnce.Do(func() {
@@ -100,13 +140,20 @@ func newScheduler(limiter Limiter, workers int, cfg schedulerConfig) *Scheduler
 	if cfg.jitter == nil {
 		cfg.jitter = func(time.Duration) time.Duration { return 0 }
 	}
+	if cfg.retryJitter == nil {
+		cfg.retryJitter = func(time.Duration) time.Duration { return 0 }
+	}
 	if cfg.errorRetryDelay <= 0 {
 		cfg.errorRetryDelay = defaultErrorRetryDelay
 	}
 	if cfg.idleInterval <= 0 {
 		cfg.idleInterval = defaultIdleInterval
 	}
-	ctx, cancel := context.WithCancel(context.Background())
+	parent := cfg.parent
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parent)
 	s := &Scheduler{
 		limiter:         limiter,
 		workers:         workers,
@@ -119,9 +166,11 @@ func newScheduler(limiter Limiter, workers int, cfg schedulerConfig) *Scheduler
 		ctx:             ctx,
 		cancel:          cancel,
 		state:           newSchedulerState(),
+		pauses:          map[string]time.Time{},
 		now:             cfg.now,
 		newLeaseID:      cfg.newLeaseID,
 		jitter:          cfg.jitter,
+		retryJitter:     cfg.retryJitter,
 		errorRetryDelay: cfg.errorRetryDelay,
 		idleInterval:    cfg.idleInterval,
 	}
@@ -1,6 +1,7 @@
 package ratelimiter
 
 import (
+	"context"
 	"math/rand"
 	"sync"
 	"time"
@@ -17,9 +18,11 @@ type schedulerConfig struct {
 	now             func() time.Time
 	newLeaseID      func() string
 	jitter          func(time.Duration) time.Duration
+	retryJitter     func(time.Duration) time.Duration
 	errorRetryDelay time.Duration
 	idleInterval    time.Duration
 	observer        SchedulerObserver
+	parent          context.Context
 }
 
 // defaultSchedulerConfig returns the production scheduler defaults.
@@ -29,6 +32,7 @@ func defaultSchedulerConfig() schedulerConfig {
 		now:             time.Now,
 		newLeaseID:      NewULID,
 		jitter:          jitterSource.Jitter,
+		retryJitter:     jitterSource.Spread,
 		errorRetryDelay: defaultErrorRetryDelay,
 		idleInterval:    defaultIdleInterval,
 		observer:        nil,
@@ -57,3 +61,14 @@ func (l *lockedRand) Jitter(base time.Duration) time.Duration {
 	n := l.r.Int63n(int64(max) + 1)
 	return time.Duration(n)
 }
+
+// Spread returns a random duration up to a fifth of base, so retries that
+// failed together do not return together.
+func (l *lockedRand) Spread(base time.Duration) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return time.Duration(l.r.Int63n(int64(base)/5 + 1))
+}
@@ -0,0 +1,77 @@
+package ratelimiter
+
+import (
+	"context"
+	"time"
+)
+
+// feedbackContext installs the provider feedback hook for one job's Execute.
+func (s *Scheduler) feedbackContext(ctx context.Context, job Job) context.Context {
+	return WithProviderFeedback(ctx, func(fb ProviderFeedback) {
+		s.handleFeedback(job, fb)
+	})
+}
+
+// lowRemainingFraction is the share of a provider's limit left in its
+// window below which feedback is worth a round trip to the limiter.
+const lowRemainingFraction = 0.1
+
+// handleFeedback pauses a throttled provider/model queue for the provider's
+// retry-after and forwards the feedback to limiters that adapt capacity. It
+// runs before the provider body is read, so only a 429 or a nearly
+// exhausted window is forwarded; routine rate-limit headers are not.
+func (s *Scheduler) handleFeedback(job Job, fb ProviderFeedback) {
+	if fb.Throttled {
+		delay := fb.RetryAfter
+		if delay < s.errorRetryDelay {
+			delay = s.errorRetryDelay
+		}
+		s.pause(queueKey(job), s.now().Add(delay))
+	}
+	reporter, ok := s.limiter.(FeedbackReporter)
+	if !ok || !(fb.Throttled || runningLow(fb.RemainingRequests, fb.LimitRequests) || runningLow(fb.RemainingTokens, fb.LimitTokens)) {
+		return
+	}
+	entries := BuildLLMFeedback(job.Provider, job.Model, fb)
+	if len(entries) == 0 {
+		return
+	}
+	_, _ = reporter.ReportFeedback(s.ctx, FeedbackRequest{Feedback: entries})
+}
+
+// pause holds back new reserve attempts on a queue until the given time.
+func (s *Scheduler) pause(key string, until time.Time) {
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+	if until.After(s.pauses[key]) {
+		s.pauses[key] = until
+	}
+}
+
+// pausedUntil reports whether a job's queue is paused and until when.
+func (s *Scheduler) pausedUntil(job Job) (time.Time, bool) {
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+	key := queueKey(job)
+	until, ok := s.pauses[key]
+	if !ok {
+		return time.Time{}, false
+	}
+	if !until.After(s.now()) {
+		delete(s.pauses, key)
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+// runningLow reports whether a provider's remaining count is exhausted or
+// below lowRemainingFraction of its limit.
+func runningLow(remaining, limit *uint64) bool {
+	if remaining == nil {
+		return false
+	}
+	if *remaining == 0 {
+		return true
+	}
+	return limit != nil && float64(*remaining) < float64(*limit)*lowRemainingFraction
+}
@@ -0,0 +1,128 @@
+package ratelimiter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"cogni/internal/testutil"
+)
+
+type feedbackLimiter struct {
+	fakeLimiter
+	feedbackMu sync.Mutex
+	feedback   []FeedbackRequest
+}
+
+func (f *feedbackLimiter) ReportFeedback(_ context.Context, req FeedbackRequest) (FeedbackResponse, error) {
+	f.feedbackMu.Lock()
+	defer f.feedbackMu.Unlock()
+	f.feedback = append(f.feedback, req)
+	return FeedbackResponse{OK: true}, nil
+}
+
+func TestScheduler_ThrottleFeedbackPausesQueue(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		lim := &feedbackLimiter{}
+		ids := &idSource{}
+		cfg := schedulerConfig{
+			now:             time.Now,
+			newLeaseID:      ids.Next,
+			jitter:          func(time.Duration) time.Duration { return 0 },
+			errorRetryDelay: 5 * time.Millisecond,
+			idleInterval:    time.Millisecond,
+		}
+		sched := newScheduler(lim, 1, cfg)
+		defer func() {
+			_ = sched.Shutdown(testutil.Context(t, time.Second))
+		}()
+
+		retryAfter := 100 * time.Millisecond
+		throttledAt := make(chan time.Time, 1)
+		sched.Submit(Job{
+			JobID:    "throttled",
+			Provider: "openrouter",
+			Model:    "m",
+			Execute: func(ctx context.Context) (uint64, error) {
+				ReportProviderFeedback(ctx, ProviderFeedback{Throttled: true, RetryAfter: retryAfter})
+				throttledAt <- time.Now()
+				return 0, nil
+			},
+		})
+		start := <-throttledAt
+
+		ran := make(chan time.Time, 1)
+		sched.Submit(Job{
+			JobID:    "next",
+			Provider: "openrouter",
+			Model:    "m",
+			Execute: func(context.Context) (uint64, error) {
+				ran <- time.Now()
+				return 0, nil
+			},
+		})
+		if waited := (<-ran).Sub(start); waited < retryAfter {
+			t.Fatalf("expected queue paused for %v, next job ran after %v", retryAfter, waited)
+		}
+
+		lim.feedbackMu.Lock()
+		defer lim.feedbackMu.Unlock()
+		if len(lim.feedback) != 1 || len(lim.feedback[0].Feedback) != 2 {
+			t.Fatalf("expected rpm and tpm feedback, got %+v", lim.feedback)
+		}
+		for _, entry := range lim.feedback[0].Feedback {
+			if !entry.Throttled || entry.RetryAfterMs != 100 {
+				t.Fatalf("unexpected feedback entry: %+v", entry)
+			}
+		}
+	})
+}
+
+func TestScheduler_ForwardsOnlyThrottledOrLowFeedback(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		lim := &feedbackLimiter{}
+		sched := newScheduler(lim, 1, schedulerConfig{
+			now:          time.Now,
+			newLeaseID:   (&idSource{}).Next,
+			jitter:       func(time.Duration) time.Duration { return 0 },
+			idleInterval: time.Millisecond,
+		})
+		defer func() {
+			_ = sched.Shutdown(testutil.Context(t, time.Second))
+		}()
+
+		limit, plenty, low := uint64(100), uint64(50), uint64(5)
+		reports := []ProviderFeedback{
+			{LimitRequests: &limit, RemainingRequests: &plenty},
+			{LimitTokens: &limit, RemainingTokens: &low},
+		}
+		done := make(chan struct{}, len(reports))
+		for _, fb := range reports {
+			sched.Submit(Job{
+				JobID:    "job",
+				Provider: "openrouter",
+				Model:    "m",
+				Execute: func(ctx context.Context) (uint64, error) {
+					ReportProviderFeedback(ctx, fb)
+					done <- struct{}{}
+					return 0, nil
+				},
+			})
+		}
+		for range reports {
+			<-done
+		}
+
+		lim.feedbackMu.Lock()
+		defer lim.feedbackMu.Unlock()
+		if len(lim.feedback) != 1 {
+			t.Fatalf("expected only the low-remaining report forwarded, got %+v", lim.feedback)
+		}
+		for _, entry := range lim.feedback[0].Feedback {
+			if entry.ProviderRemaining != nil && *entry.ProviderRemaining == plenty {
+				t.Fatalf("unexpected entry from the routine report: %+v", entry)
+			}
+		}
+	})
+}
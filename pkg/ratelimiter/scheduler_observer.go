@@ -1,5 +1,7 @@
 package ratelimiter
 
+import "time"
+
 // SchedulerObserver receives scheduler lifecycle events for a job.
 type SchedulerObserver interface {
 	// OnReserveStart signals a reserve attempt.
@@ -8,4 +10,7 @@ type SchedulerObserver interface {
 	OnReserveDenied(job Job, res ReserveResponse)
 	// OnReserveError signals a reserve error before retry.
 	OnReserveError(job Job, err error)
+	// OnExecuteRetry signals a failed Execute attempt that will be retried
+	// after delay; job.Attempt is the attempt that failed.
+	OnExecuteRetry(job Job, err error, delay time.Duration)
 }
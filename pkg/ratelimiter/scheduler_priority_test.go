@@ -0,0 +1,115 @@
+package ratelimiter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"cogni/internal/testutil"
+)
+
+func dispatchOrder(state *schedulerState, n int) string {
+	ids := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		job, ok := state.nextReady()
+		if !ok {
+			break
+		}
+		ids = append(ids, job.JobID)
+	}
+	return strings.Join(ids, " ")
+}
+
+func TestSchedulerState_HigherPriorityDispatchesFirst(t *testing.T) {
+	state := newSchedulerState()
+	state.enqueueReady(Job{JobID: "low", Provider: "p", Model: "m", Priority: PriorityLow})
+	state.enqueueReady(Job{JobID: "normal", Provider: "p", Model: "m"})
+	state.enqueueReady(Job{JobID: "high", Provider: "p", Model: "m", Priority: PriorityHigh})
+
+	if got := dispatchOrder(state, 3); got != "high normal low" {
+		t.Fatalf("unexpected dispatch order: %s", got)
+	}
+}
+
+func TestSchedulerState_FairQueuesTenantsByWeight(t *testing.T) {
+	state := newSchedulerState()
+	state.setWeights(map[string]float64{"interactive": 2})
+	for i := 0; i < 6; i++ {
+		state.enqueueReady(Job{JobID: "b", TenantID: "batch", Provider: "p", Model: "m"})
+	}
+	for i := 0; i < 4; i++ {
+		state.enqueueReady(Job{JobID: "i", TenantID: "interactive", Provider: "p", Model: "m"})
+	}
+
+	if got := dispatchOrder(state, 10); got != "b i i b i i b b b b" {
+		t.Fatalf("unexpected dispatch order: %s", got)
+	}
+}
+
+func TestSchedulerState_IdleTenantGetsNoBacklogCredit(t *testing.T) {
+	state := newSchedulerState()
+	state.enqueueReady(Job{JobID: "b", TenantID: "b", Provider: "p", Model: "m"})
+	for i := 0; i < 6; i++ {
+		state.enqueueReady(Job{JobID: "a", TenantID: "a", Provider: "p", Model: "m"})
+	}
+	if got := dispatchOrder(state, 4); got != "b a a a" {
+		t.Fatalf("unexpected dispatch order: %s", got)
+	}
+	for i := 0; i < 3; i++ {
+		state.enqueueReady(Job{JobID: "b", TenantID: "b", Provider: "p", Model: "m"})
+	}
+
+	if got := dispatchOrder(state, 4); got != "b b a b" {
+		t.Fatalf("unexpected dispatch order: %s", got)
+	}
+}
+
+func TestSchedulerState_DropsIdleTenantFlows(t *testing.T) {
+	state := newSchedulerState()
+	for i := 0; i < 100; i++ {
+		state.enqueueReady(Job{JobID: "j", TenantID: fmt.Sprintf("t%d", i), Provider: "p", Model: "m"})
+	}
+	dispatchOrder(state, 100)
+
+	q := state.queues[state.order[0]]
+	if flows := len(q.classes[PriorityNormal.rank()].flows); flows != 0 {
+		t.Fatalf("expected idle tenant flows dropped, got %d", flows)
+	}
+}
+
+func TestScheduler_SendsJobPriorityWithReservation(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		lim := &fakeLimiter{completeCh: make(chan struct{}, 1)}
+		ids := &idSource{}
+		sched := newScheduler(lim, 1, schedulerConfig{newLeaseID: ids.Next, idleInterval: time.Millisecond})
+		defer func() {
+			_ = sched.Shutdown(testutil.Context(t, time.Second))
+		}()
+
+		sched.Submit(Job{
+			JobID:    "job",
+			Provider: "openrouter",
+			Model:    "m",
+			Priority: PriorityLow,
+			Execute:  func(context.Context) (uint64, error) { return 1, nil },
+		})
+		waitFor(t, lim.completeCh, time.Second)
+
+		lim.mu.Lock()
+		defer lim.mu.Unlock()
+		if len(lim.reserveCalls) != 1 || lim.reserveCalls[0].Priority != PriorityLow {
+			t.Fatalf("expected one low-priority reservation, got %+v", lim.reserveCalls)
+		}
+	})
+}
+
+func TestParsePriority(t *testing.T) {
+	if p, err := ParsePriority(""); err != nil || p != PriorityNormal {
+		t.Fatalf("expected empty priority to be normal, got %q, %v", p, err)
+	}
+	if _, err := ParsePriority("urgent"); err == nil {
+		t.Fatalf("expected unknown priority error")
+	}
+}
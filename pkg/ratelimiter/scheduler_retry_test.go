@@ -0,0 +1,148 @@
+package ratelimiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"cogni/internal/testutil"
+)
+
+type transientError struct{ delay time.Duration }
+
+func (e transientError) Error() string             { return "provider overloaded" }
+func (e transientError) Retryable() bool           { return true }
+func (e transientError) RetryDelay() time.Duration { return e.delay }
+
+type retryObserver struct {
+	mu      sync.Mutex
+	retries []int
+	delays  []time.Duration
+}
+
+func (o *retryObserver) OnReserveStart(Job)                   {}
+func (o *retryObserver) OnReserveDenied(Job, ReserveResponse) {}
+func (o *retryObserver) OnReserveError(Job, error)            {}
+
+func (o *retryObserver) OnExecuteRetry(job Job, _ error, delay time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.retries = append(o.retries, job.Attempt)
+	o.delays = append(o.delays, delay)
+}
+
+func TestScheduler_RetriesTransientExecuteErrors(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		lim := &fakeLimiter{}
+		ids := &idSource{}
+		observer := &retryObserver{}
+		cfg := schedulerConfig{
+			now:             time.Now,
+			newLeaseID:      ids.Next,
+			errorRetryDelay: 5 * time.Millisecond,
+			idleInterval:    time.Millisecond,
+			observer:        observer,
+		}
+		sched := newScheduler(lim, 1, cfg)
+		defer func() {
+			_ = sched.Shutdown(testutil.Context(t, time.Second))
+		}()
+
+		done := make(chan int, 1)
+		var willRetry []bool
+		sched.Submit(Job{
+			JobID:    "job",
+			Provider: "openrouter",
+			Model:    "m",
+			Retry:    RetryPolicy{MaxAttempts: 3, BaseDelay: 5 * time.Millisecond, MaxDelay: 20 * time.Millisecond},
+			Execute: func(ctx context.Context) (uint64, error) {
+				attempt := AttemptFromContext(ctx)
+				var err error
+				if attempt < 3 {
+					err = transientError{}
+				}
+				willRetry = append(willRetry, WillRetry(ctx, err))
+				if err == nil {
+					done <- attempt
+				}
+				return 10, err
+			},
+		})
+		if attempt := <-done; attempt != 3 {
+			t.Fatalf("expected success on attempt 3, got %d", attempt)
+		}
+		if fmt.Sprint(willRetry) != "[true true false]" {
+			t.Fatalf("unexpected WillRetry results: %v", willRetry)
+		}
+
+		lim.mu.Lock()
+		leases := map[string]bool{}
+		for _, call := range lim.reserveCalls {
+			leases[call.LeaseID] = true
+		}
+		completes := len(lim.completeCalls)
+		lim.mu.Unlock()
+		if len(leases) != 3 || completes != 3 {
+			t.Fatalf("expected a fresh lease per attempt, got %d leases and %d completes", len(leases), completes)
+		}
+		observer.mu.Lock()
+		defer observer.mu.Unlock()
+		if fmt.Sprint(observer.retries) != "[1 2]" || fmt.Sprint(observer.delays) != "[5ms 10ms]" {
+			t.Fatalf("unexpected retries: attempts %v delays %v", observer.retries, observer.delays)
+		}
+	})
+}
+
+func TestScheduler_DoesNotRetryPermanentErrors(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		lim := &fakeLimiter{completeCh: make(chan struct{}, 1)}
+		sched := newScheduler(lim, 1, schedulerConfig{newLeaseID: (&idSource{}).Next, idleInterval: time.Millisecond})
+		defer func() {
+			_ = sched.Shutdown(testutil.Context(t, time.Second))
+		}()
+
+		var mu sync.Mutex
+		calls := 0
+		sched.Submit(Job{
+			JobID:    "job",
+			Provider: "openrouter",
+			Model:    "m",
+			Retry:    RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			Execute: func(context.Context) (uint64, error) {
+				mu.Lock()
+				calls++
+				mu.Unlock()
+				return 0, errors.New("invalid request")
+			},
+		})
+		waitFor(t, lim.completeCh, time.Second)
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		if calls != 1 {
+			t.Fatalf("expected a single attempt, got %d", calls)
+		}
+	})
+}
+
+func TestRetryPolicy_BackoffAndClassification(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second} {
+		if got := policy.backoff(attempt, errors.New("x")); got != want {
+			t.Fatalf("attempt %d: expected %v, got %v", attempt, want, got)
+		}
+	}
+	if got := policy.backoff(1, fmt.Errorf("wrapped: %w", transientError{delay: 8 * time.Second})); got != 8*time.Second {
+		t.Fatalf("expected retry hint to raise backoff, got %v", got)
+	}
+	if !IsRetryable(fmt.Errorf("read: %w", io.ErrUnexpectedEOF)) || IsRetryable(context.Canceled) || IsRetryable(errors.New("bad request")) {
+		t.Fatalf("unexpected classification")
+	}
+	if policy.allows(5, transientError{}) {
+		t.Fatalf("expected attempts to be capped")
+	}
+}
@@ -1,6 +1,9 @@
 package ratelimiter
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // requeueRequest carries a job and its next eligible time.
 type requeueRequest struct {
@@ -8,19 +11,29 @@ type requeueRequest struct {
 	notBefore time.Time
 }
 
-// run drives the scheduler loop until shutdown.
+// run drives the scheduler loop until shutdown. Once the context ends, jobs
+// still queued are cancelled rather than dispatched.
 func (s *Scheduler) run() {
 	timer := time.NewTimer(s.idleInterval)
 	defer timer.Stop()
 
 	for {
-		s.state.promoteReady(s.now())
-		s.dispatchReady()
+		if err := s.ctx.Err(); err != nil {
+			for _, job := range s.state.drain() {
+				cancelJob(job, err)
+			}
+		} else {
+			s.state.promoteReady(s.now())
+			s.dispatchReady()
+		}
 		nextDelay := s.nextWakeDelay()
 		resetTimer(timer, nextDelay)
 
 		select {
 		case <-s.stopCh:
+			for _, job := range s.state.drain() {
+				cancelJob(job, context.Canceled)
+			}
 			close(s.workCh)
 			close(s.doneCh)
 			return
@@ -33,6 +46,13 @@ func (s *Scheduler) run() {
 	}
 }
 
+// cancelJob reports a job that will never run to its submitter.
+func cancelJob(job Job, err error) {
+	if job.OnCancel != nil {
+		job.OnCancel(err)
+	}
+}
+
 // dispatchReady sends available work to workers.
 func (s *Scheduler) dispatchReady() {
 	for len(s.workCh) < cap(s.workCh) {
@@ -49,6 +69,7 @@ func (s *Scheduler) requeue(job Job, notBefore time.Time) {
 	msg := requeueRequest{job: job, notBefore: notBefore}
 	select {
 	case <-s.doneCh:
+		cancelJob(job, context.Canceled)
 	case s.requeueCh <- msg:
 	}
 }
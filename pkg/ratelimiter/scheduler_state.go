@@ -1,21 +1,43 @@
 package ratelimiter
 
-import "time"
+import (
+	"sync"
+	"time"
+)
 
 // schedulerState owns queue state for the scheduler loop.
 type schedulerState struct {
 	queues  map[string]*workQueue
 	order   []string
 	rrIndex int
+
+	weightsMu sync.Mutex
+	weights   map[string]float64
 }
 
-// workQueue holds ready and blocked jobs for a provider/model pair.
+// workQueue holds ready and blocked jobs for a provider/model pair. Ready
+// jobs are split by priority class, highest first.
 type workQueue struct {
 	key     string
-	ready   []Job
+	classes [priorityLevels]fairQueue
 	blocked blockedQueue
 }
 
+// fairQueue shares one priority class across tenants by weighted fair
+// queuing: each dispatch advances the tenant's virtual time by 1/weight and
+// the tenant furthest behind goes next.
+type fairQueue struct {
+	flows []*tenantFlow
+	vtime float64
+}
+
+// tenantFlow holds one tenant's ready jobs in submission order.
+type tenantFlow struct {
+	tenant string
+	jobs   []Job
+	vtime  float64
+}
+
 // blockedQueue maintains blocked jobs ordered by not-before time.
 type blockedQueue struct {
 	items []blockedItem
@@ -37,8 +59,7 @@ func newSchedulerState() *schedulerState {
 
 // enqueueReady adds a job to the ready list for its queue.
 func (s *schedulerState) enqueueReady(job Job) {
-	q := s.queue(queueKey(job))
-	q.ready = append(q.ready, job)
+	s.queue(queueKey(job)).pushReady(job)
 }
 
 // enqueueBlocked adds a job to the blocked list for its queue.
@@ -64,17 +85,38 @@ func (s *schedulerState) nextReady() (Job, bool) {
 		idx := (start + i) % len(s.order)
 		key := s.order[idx]
 		q := s.queues[key]
-		if q == nil || len(q.ready) == 0 {
+		if q == nil {
+			continue
+		}
+		job, ok := q.popReady(s.weight)
+		if !ok {
 			continue
 		}
-		job := q.ready[0]
-		q.ready = q.ready[1:]
 		s.rrIndex = (idx + 1) % len(s.order)
 		return job, true
 	}
 	return Job{}, false
 }
 
+// drain removes and returns every queued job, ready or blocked.
+func (s *schedulerState) drain() []Job {
+	var jobs []Job
+	for _, key := range s.order {
+		q := s.queues[key]
+		for i := range q.classes {
+			for _, flow := range q.classes[i].flows {
+				jobs = append(jobs, flow.jobs...)
+			}
+			q.classes[i] = fairQueue{}
+		}
+		for _, item := range q.blocked.items {
+			jobs = append(jobs, item.job)
+		}
+		q.blocked.items = nil
+	}
+	return jobs
+}
+
 // nextBlockedTime returns the earliest blocked job time.
 func (s *schedulerState) nextBlockedTime() (time.Time, bool) {
 	var earliest time.Time
@@ -90,6 +132,29 @@ func (s *schedulerState) nextBlockedTime() (time.Time, bool) {
 	return earliest, ok
 }
 
+// setWeights replaces the tenant weights used for fair queuing.
+func (s *schedulerState) setWeights(weights map[string]float64) {
+	copied := make(map[string]float64, len(weights))
+	for tenant, weight := range weights {
+		if weight > 0 {
+			copied[tenant] = weight
+		}
+	}
+	s.weightsMu.Lock()
+	defer s.weightsMu.Unlock()
+	s.weights = copied
+}
+
+// weight returns a tenant's fair-queuing weight, defaulting to 1.
+func (s *schedulerState) weight(tenant string) float64 {
+	s.weightsMu.Lock()
+	defer s.weightsMu.Unlock()
+	if weight, ok := s.weights[tenant]; ok {
+		return weight
+	}
+	return 1
+}
+
 // queue returns the workQueue for a key, creating it on demand.
 func (s *schedulerState) queue(key string) *workQueue {
 	if q, ok := s.queues[key]; ok {
@@ -108,8 +173,73 @@ func (q *workQueue) promoteReady(now time.Time) {
 		if !ok {
 			return
 		}
-		q.ready = append(q.ready, item.job)
+		q.pushReady(item.job)
+	}
+}
+
+// pushReady adds a job to its priority class.
+func (q *workQueue) pushReady(job Job) {
+	q.classes[job.Priority.rank()].push(job)
+}
+
+// popReady removes the next job from the highest non-empty class.
+func (q *workQueue) popReady(weight func(string) float64) (Job, bool) {
+	for i := range q.classes {
+		if job, ok := q.classes[i].pop(weight); ok {
+			return job, true
+		}
+	}
+	return Job{}, false
+}
+
+// push appends a job to its tenant's flow. A tenant that was idle starts at
+// the class's current virtual time so it cannot claim credit for the wait.
+func (f *fairQueue) push(job Job) {
+	for _, flow := range f.flows {
+		if flow.tenant == job.TenantID {
+			if len(flow.jobs) == 0 && flow.vtime < f.vtime {
+				flow.vtime = f.vtime
+			}
+			flow.jobs = append(flow.jobs, job)
+			return
+		}
+	}
+	f.flows = append(f.flows, &tenantFlow{tenant: job.TenantID, jobs: []Job{job}, vtime: f.vtime})
+}
+
+// pop removes the head job of the backlogged tenant with the lowest virtual
+// time, breaking ties by arrival order.
+func (f *fairQueue) pop(weight func(string) float64) (Job, bool) {
+	var next *tenantFlow
+	for _, flow := range f.flows {
+		if len(flow.jobs) == 0 {
+			continue
+		}
+		if next == nil || flow.vtime < next.vtime {
+			next = flow
+		}
+	}
+	if next == nil {
+		return Job{}, false
+	}
+	job := next.jobs[0]
+	next.jobs = next.jobs[1:]
+	f.vtime = next.vtime
+	next.vtime += 1 / weight(next.tenant)
+	f.prune()
+	return job, true
+}
+
+// prune forgets every flow once the class has no backlog left, so tenants
+// that come and go do not accumulate. A returning tenant starts at the
+// queue's virtual time like any new one.
+func (f *fairQueue) prune() {
+	for _, flow := range f.flows {
+		if len(flow.jobs) > 0 {
+			return
+		}
 	}
+	f.flows = nil
 }
 
 // push inserts a blocked item in time order.
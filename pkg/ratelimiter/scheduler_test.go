@@ -215,3 +215,119 @@ func hasProvider(reqs []Requirement, provider string) bool {
 	}
 	return false
 }
+
+func TestScheduler_ContextCancelReleasesJobs(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		lim := &fakeLimiter{}
+		completeCalled := make(chan struct{}, 2)
+		lim.completeCh = completeCalled
+		ids := &idSource{}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		cfg := schedulerConfig{
+			now:             time.Now,
+			newLeaseID:      ids.Next,
+			jitter:          func(time.Duration) time.Duration { return 0 },
+			errorRetryDelay: 5 * time.Millisecond,
+			idleInterval:    time.Millisecond,
+			parent:          ctx,
+		}
+		sched := newScheduler(lim, 1, cfg)
+		defer func() {
+			_ = sched.Shutdown(testutil.Context(t, time.Second))
+		}()
+
+		started := make(chan struct{})
+		var executeErr error
+		sched.Submit(Job{
+			JobID:    "running",
+			Provider: "anthropic",
+			Model:    "claude",
+			Execute: func(ctx context.Context) (uint64, error) {
+				close(started)
+				<-ctx.Done()
+				executeErr = ctx.Err()
+				return 0, executeErr
+			},
+		})
+		waitFor(t, started, 200*time.Millisecond)
+
+		cancelled := make(chan struct{}, 2)
+		for i := 0; i < 2; i++ {
+			sched.Submit(Job{
+				JobID:    fmt.Sprintf("queued-%d", i),
+				Provider: "anthropic",
+				Model:    "claude",
+				Execute: func(context.Context) (uint64, error) {
+					t.Errorf("queued job executed after cancellation")
+					return 0, nil
+				},
+				OnCancel: func(error) { cancelled <- struct{}{} },
+			})
+		}
+		cancel()
+		waitForCount(t, cancelled, 2, 200*time.Millisecond)
+		waitFor(t, completeCalled, 200*time.Millisecond)
+
+		lim.mu.Lock()
+		completes := len(lim.completeCalls)
+		lim.mu.Unlock()
+		if completes != 1 {
+			t.Fatalf("expected 1 complete call for the in-flight job, got %d", completes)
+		}
+		if executeErr != context.Canceled {
+			t.Fatalf("expected in-flight job to see context.Canceled, got %v", executeErr)
+		}
+
+		afterCancel := make(chan struct{}, 1)
+		sched.Submit(Job{JobID: "late", OnCancel: func(error) { afterCancel <- struct{}{} }})
+		waitFor(t, afterCancel, 200*time.Millisecond)
+	})
+}
+
+func TestScheduler_AdmitRefusalSkipsReserve(t *testing.T) {
+	runWithTimeout(t, 2*time.Second, func() {
+		lim := &fakeLimiter{}
+		ids := &idSource{}
+		cfg := schedulerConfig{
+			now:             time.Now,
+			newLeaseID:      ids.Next,
+			jitter:          func(time.Duration) time.Duration { return 0 },
+			errorRetryDelay: 5 * time.Millisecond,
+			idleInterval:    time.Millisecond,
+		}
+		sched := newScheduler(lim, 1, cfg)
+		defer func() {
+			_ = sched.Shutdown(testutil.Context(t, time.Second))
+		}()
+
+		refused := fmt.Errorf("budget exhausted")
+		cancelled := make(chan error, 1)
+		sched.Submit(Job{
+			JobID:    "refused",
+			Provider: "anthropic",
+			Model:    "claude",
+			Admit:    func() error { return refused },
+			Execute: func(context.Context) (uint64, error) {
+				t.Errorf("refused job executed")
+				return 0, nil
+			},
+			OnCancel: func(err error) { cancelled <- err },
+		})
+		select {
+		case err := <-cancelled:
+			if err != refused {
+				t.Fatalf("expected admit error, got %v", err)
+			}
+		case <-time.After(200 * time.Millisecond):
+			t.Fatalf("timeout waiting for refused job")
+		}
+
+		lim.mu.Lock()
+		reserves := len(lim.reserveCalls)
+		lim.mu.Unlock()
+		if reserves != 0 {
+			t.Fatalf("expected no reserve for a refused job, got %d", reserves)
+		}
+	})
+}
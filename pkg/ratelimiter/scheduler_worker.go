@@ -15,10 +15,31 @@ func (s *Scheduler) worker() {
 
 // handleJob runs a single reserve/execute/complete attempt.
 func (s *Scheduler) handleJob(job Job) {
+	if err := s.ctx.Err(); err != nil {
+		cancelJob(job, err)
+		return
+	}
+	if job.Admit != nil {
+		if err := job.Admit(); err != nil {
+			cancelJob(job, err)
+			return
+		}
+	}
+	if until, paused := s.pausedUntil(job); paused {
+		s.requeue(job, until)
+		return
+	}
 	job = s.ensureLeaseID(job)
 	s.notifyReserveStart(job)
 	req := buildReserveRequest(job)
 	res, err := s.limiter.Reserve(s.ctx, req)
+	if ctxErr := s.ctx.Err(); ctxErr != nil {
+		// The reservation may have been granted before the call was cut
+		// short; completing with no actuals releases it either way.
+		s.complete(job, nil)
+		cancelJob(job, ctxErr)
+		return
+	}
 	if err != nil {
 		s.notifyReserveError(job, err)
 		s.requeue(job, s.now().Add(s.errorRetryDelay))
@@ -31,11 +52,30 @@ func (s *Scheduler) handleJob(job Job) {
 		return
 	}
 	actuals := []Actual{}
+	var execErr error
 	if job.Execute != nil {
-		actualTokens, _ := job.Execute(s.ctx)
+		job.Attempt++
+		ctx := withExecuteAttempt(s.feedbackContext(s.ctx, job), job)
+		var actualTokens uint64
+		actualTokens, execErr = job.Execute(ctx)
 		actuals = buildLLMActuals(job, actualTokens)
 	}
 	s.complete(job, actuals)
+	if job.Retry.allows(job.Attempt, execErr) {
+		s.retry(job, execErr)
+	}
+}
+
+// retry requeues a failed job under a new lease after exponential backoff
+// with jitter.
+func (s *Scheduler) retry(job Job, err error) {
+	delay := job.Retry.backoff(job.Attempt, err)
+	if jitter := s.retryJitter(delay); jitter > 0 {
+		delay += jitter
+	}
+	s.notifyExecuteRetry(job, err, delay)
+	job.LeaseID = s.newLeaseID()
+	s.requeue(job, s.now().Add(delay))
 }
 
 // ensureLeaseID assigns a lease ID if one is missing.
@@ -71,7 +111,7 @@ func buildReserveRequest(job Job) ReserveRequest {
 		MaxOutputTokens: job.MaxOutputTokens,
 		WantDailyBudget: job.WantDailyBudget,
 	})
-	return ReserveRequest{LeaseID: job.LeaseID, JobID: job.JobID, Requirements: reqs}
+	return ReserveRequest{LeaseID: job.LeaseID, JobID: job.JobID, Requirements: reqs, Priority: job.Priority}
 }
 
 // buildLLMActuals builds actual usage entries for token-based limits.
@@ -117,3 +157,10 @@ func (s *Scheduler) notifyReserveError(job Job, err error) {
 		s.observer.OnReserveError(job, err)
 	}
 }
+
+// notifyExecuteRetry forwards execute retries to observers.
+func (s *Scheduler) notifyExecuteRetry(job Job, err error, delay time.Duration) {
+	if s.observer != nil {
+		s.observer.OnExecuteRetry(job, err, delay)
+	}
+}
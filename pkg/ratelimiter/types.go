@@ -31,6 +31,9 @@ const (
 	LimitStatusActive LimitStatus = "active"
 	// LimitStatusDecreasing blocks reservations until capacity drops.
 	LimitStatusDecreasing LimitStatus = "decreasing"
+	// LimitStatusDraining blocks reservations until in-flight leases finish,
+	// then the limit is deleted.
+	LimitStatusDraining LimitStatus = "draining"
 )
 
 // LimitDefinition is the server-side definition for a limit.